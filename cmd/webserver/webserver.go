@@ -17,21 +17,28 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
 	_ "database/sql"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	_ "github.com/go-sql-driver/mysql"
-	"github.com/gomodule/redigo/redis"
 	"github.com/jmoiron/sqlx"
 	"github.com/spf13/viper"
+	"net"
 	"net/http"
+	"net/smtp"
 	"os"
 	"runtime/pprof"
 	"shoppinglist/pkg/api"
 	"shoppinglist/pkg/endpoint"
+	"shoppinglist/pkg/notify"
 	"shoppinglist/pkg/service"
+	"shoppinglist/pkg/session"
+	"shoppinglist/pkg/store"
 	"shoppinglist/pkg/transport"
 	"text/tabwriter"
 	"time"
@@ -41,6 +48,12 @@ var (
 	debugPort   string
 	port        string
 	dbName      string
+	storage     string
+	sessions    string
+	retention   time.Duration
+	purgeEvery  time.Duration
+	recurEvery  time.Duration
+	remindEvery time.Duration
 	serviceName = "Shopping-List"
 )
 
@@ -48,16 +61,12 @@ func init() {
 	flag.StringVar(&port, "port", "8000", "specify port to run this server on")
 	flag.StringVar(&debugPort, "debug_port", "8080", "specify port to run debug server on")
 	flag.StringVar(&dbName, "db_name", "shopping_list", "specify database name")
-}
-
-func initCache() {
-	// Initialize the redis connection to a redis instance running on local machine
-	conn, err := redis.DialURL("redis://localhost")
-	if err != nil {
-		panic(err)
-	}
-	// Assign the connection to the package level `cache` variable
-	api.Cache = conn
+	flag.StringVar(&storage, "storage", "mysql", "specify storage backend, mysql or memory")
+	flag.StringVar(&sessions, "sessions", "redis", "specify session store, redis or memory")
+	flag.DurationVar(&retention, "trash_retention", 30*24*time.Hour, "specify how long deleted lists and items are kept")
+	flag.DurationVar(&purgeEvery, "purge_interval", time.Hour, "specify how often the trash is purged")
+	flag.DurationVar(&recurEvery, "recurrence_interval", time.Minute, "specify how often recurring lists are checked for a run")
+	flag.DurationVar(&remindEvery, "reminder_interval", 5*time.Minute, "specify how often reminders of what comes due are sent")
 }
 
 func usageFor(short string) func() {
@@ -75,20 +84,56 @@ func usageFor(short string) func() {
 	}
 }
 
-func buildConfigFromEnv() (*service.Config, error) {
+// buildConfigFromEnv returns the service configuration. Without INVITE_SECRET a
+// random secret is made up, which does not outlive the process.
+func buildConfigFromEnv(logger log.Logger) (*service.Config, error) {
+	viper.AutomaticEnv()
 	dbconn := viper.GetString("DB_CONNECTION_URL")
 	dbport := viper.GetString("DB_CONNECTION_PORT")
 	dbuser := viper.GetString("DB_USER")
 	dbpass := viper.GetString("DB_PASSWORD")
+	inviteSecret := viper.GetString("INVITE_SECRET")
+	if inviteSecret == "" {
+		// invitation and public link tokens handed out by this process stop
+		// working after a restart
+		logger.Log("config", "INVITE_SECRET", "warning", "not set, invitations and public links will not survive a restart")
+		secret := make([]byte, 32)
+		_, err := rand.Read(secret)
+		if err != nil {
+			return nil, err
+		}
+		inviteSecret = hex.EncodeToString(secret)
+	}
 	c := &service.Config{
-		DBConn:     dbconn,
-		DBPort:     dbport,
-		DBUser:     dbuser,
-		DBPassword: dbpass,
+		DBConn:       dbconn,
+		DBPort:       dbport,
+		DBUser:       dbuser,
+		DBPassword:   dbpass,
+		InviteSecret: inviteSecret,
 	}
 	return c, nil
 }
 
+// buildNotifiersFromEnv returns the notifiers of the reminder channels. Email is
+// sent through the SMTP server at SMTP_ADDR, without one it is only logged.
+func buildNotifiersFromEnv(logger log.Logger) map[string]notify.Notifier {
+	viper.AutomaticEnv()
+	var email notify.Notifier = notify.NewLog(logger)
+	if addr := viper.GetString("SMTP_ADDR"); addr != "" {
+		var auth smtp.Auth
+		if user := viper.GetString("SMTP_USER"); user != "" {
+			host, _, _ := net.SplitHostPort(addr)
+			auth = smtp.PlainAuth("", user, viper.GetString("SMTP_PASSWORD"), host)
+		}
+		from := viper.GetString("SMTP_FROM")
+		if from == "" {
+			from = "shopping-list@localhost"
+		}
+		email = notify.NewSMTP(addr, from, auth)
+	}
+	return map[string]notify.Notifier{api.EmailChannel: email}
+}
+
 func newWebServer(addr string, debugAddr string) {
 	var logger log.Logger
 	{
@@ -102,7 +147,7 @@ func newWebServer(addr string, debugAddr string) {
 	level.Info(logger).Log("msg", "service started")
 	defer level.Info(logger).Log("msg", "service ended")
 
-	c, err := buildConfigFromEnv()
+	c, err := buildConfigFromEnv(logger)
 	if err != nil {
 		level.Info(logger).Log("failed to read the environment variable with error:", err)
 		os.Exit(1)
@@ -118,18 +163,49 @@ func newWebServer(addr string, debugAddr string) {
 		StartTime:   serviceStartTime.Format("2006-01-02T15:04:05"),
 	}
 
-	//open a database connection
-	dataSourceName := fmt.Sprintf("root:root@/%s?parseTime=true", dbName)
-	db, err := sqlx.Open("mysql", dataSourceName)
-	if err != nil {
-		logger.Log("failed to open database connection with err: ", err)
+	var repo store.Repository
+	switch storage {
+	case "memory":
+		repo = store.NewMemory()
+	case "mysql":
+		//open a database connection
+		dataSourceName := fmt.Sprintf("root:root@/%s?parseTime=true", dbName)
+		db, err := sqlx.Open("mysql", dataSourceName)
+		if err != nil {
+			logger.Log("failed to open database connection with err: ", err)
+		}
+		defer db.Close()
+		repo = store.NewMySQL(db)
+	default:
+		level.Info(logger).Log("msg", "unknown storage backend", "storage", storage)
+		os.Exit(1)
 	}
-	defer db.Close()
+
+	var sessionStore session.Store
+	switch sessions {
+	case "memory":
+		sessionStore = session.NewMemory(session.DefaultTTL)
+	case "redis":
+		// use a pool of connections to a redis instance running on local machine
+		pool := session.NewRedisPool("redis://localhost")
+		defer pool.Close()
+		sessionStore = session.NewRedis(pool, session.DefaultTTL)
+	default:
+		level.Info(logger).Log("msg", "unknown session store", "sessions", sessions)
+		os.Exit(1)
+	}
+
+	// hard delete what stayed in the trash for longer than the retention period
+	go service.RunTrashPurge(context.Background(), repo, logger, retention, purgeEvery)
+	// start the lists of recurrences as they come due
+	go service.RunRecurrences(context.Background(), repo, logger, recurEvery)
+	// remind users of what comes due
+	go service.RunReminders(context.Background(), repo, buildNotifiersFromEnv(logger), logger, remindEvery)
 
 	var (
-		service     = service.New(db, logger, c, serviceInfo)
+		service     = service.New(repo, sessionStore, logger, c, serviceInfo)
 		endpoints   = endpoint.New(service, logger)
-		httpHandler = transport.NewHTTPHandler(endpoints, logger)
+		httpHandler = transport.NewHTTPHandler(endpoints, sessionStore, logger)
 	)
 	go func() {
 		logger.Log("transport", "debug/HTTP", "addr", debugAddr)
@@ -152,7 +228,6 @@ func main() {
 	flag.Usage = usageFor(os.Args[0] + " [flags]")
 	flag.Parse()
 
-	initCache()
 	// The debug listener mounts the http.DefaultServeMux, and serves up
 	// stuff like the Prometheus metrics route, the Go debug and profiling
 	// routes, and so on.
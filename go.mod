@@ -5,8 +5,10 @@ go 1.12
 require (
 	github.com/go-kit/kit v0.10.0
 	github.com/go-sql-driver/mysql v1.4.0
+	github.com/gomodule/redigo v1.8.0
 	github.com/google/uuid v1.0.0
 	github.com/gorilla/mux v1.7.3
+	github.com/jmoiron/sqlx v1.2.0
 	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
 	github.com/pkg/errors v0.9.1
 	github.com/spacemonkeygo/openssl v0.0.0-20181017203307-c2dcc5cca94a // indirect
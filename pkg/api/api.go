@@ -1,18 +1,18 @@
 package api
 
 import (
-	"github.com/gomodule/redigo/redis"
 	"time"
 )
 
-var Cache redis.Conn
-
 // Category identifies a category with different given properties
+// A category owned by neither a user nor a group is a system default everyone sees
 // swagger:model
 type Category struct {
-	ID   int64  `json:"id" db:"id"`
-	Name string `json:"name" db:"name"`
-	Type string `json:"type" db:"type"`
+	ID      int64  `json:"id" db:"id"`
+	Name    string `json:"name" db:"name"`
+	Type    string `json:"type" db:"type"`
+	OwnerID int64  `json:"owner_id,omitempty" db:"owner_user"`
+	GroupID int64  `json:"group_id,omitempty" db:"owner_group"`
 }
 
 // User identifies a user with different given properties
@@ -33,8 +33,12 @@ type List struct {
 	LastModifiedAt time.Time `json:"last_modified_at"`
 	Deadline       time.Time `json:"deadline"`
 	Status         string    `json:"status"`
+	DeletedAt      time.Time `json:"deleted_at"`
 	AccessType     string    `json:"access_type,omitempty"`
 	CreatedByMe    bool      `json:"created_by_me,omitempty"`
+	StoreID        int64     `json:"store_id,omitempty"`
+	// Template marks a list kept to start new lists from, its items are never bought
+	Template bool `json:"template,omitempty"`
 }
 
 // Item identifies an item with different given properties
@@ -44,6 +48,8 @@ type Item struct {
 	ListID         int64     `json:"list_id"`
 	Title          string    `json:"title"`
 	Description    string    `json:"description"`
+	Quantity       float64   `json:"quantity,omitempty"`
+	Unit           string    `json:"unit,omitempty"`
 	Status         string    `json:"status"`
 	Category       Category  `json:"category"`
 	CreatedBy      User      `json:"created_by"`
@@ -52,7 +58,143 @@ type Item struct {
 	CreatedAt      time.Time `json:"created_at"`
 	LastModifiedAt time.Time `json:"last_modified_at"`
 	BoughtAt       time.Time `json:"bought_at"`
+	DeletedAt      time.Time `json:"deleted_at"`
 	Deadline       time.Time `json:"deadline"`
+	Position       int64     `json:"position"`
+}
+
+// Contributor identifies a user a list is shared with and the access granted to them,
+// a zero ValidUntil means the access does not expire
+// swagger:model
+type Contributor struct {
+	UserID     int64     `json:"user_id"`
+	UserName   string    `json:"user_name"`
+	AccessType string    `json:"access_type"`
+	ValidUntil time.Time `json:"valid_until"`
+}
+
+// Group identifies a group of users, such as a household, lists can be shared with
+// Role is the role of logged in user in the group
+// swagger:model
+type Group struct {
+	ID        int64     `json:"group_id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GroupMember identifies a member of a group and their role in it
+// swagger:model
+type GroupMember struct {
+	UserID   int64  `json:"user_id"`
+	UserName string `json:"user_name"`
+	Role     string `json:"role"`
+}
+
+// GroupAccess identifies a group a list is shared with and the access granted to its members
+// swagger:model
+type GroupAccess struct {
+	GroupID    int64  `json:"group_id"`
+	Name       string `json:"name"`
+	AccessType string `json:"access_type"`
+}
+
+// Invite identifies a pending invitation to a list, whoever presents its token
+// after signing up or logging in is granted the access type on the list
+// swagger:model
+type Invite struct {
+	ID         int64     `json:"invite_id"`
+	ListID     int64     `json:"list_id"`
+	AccessType string    `json:"access_type"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Token      string    `json:"token"`
+}
+
+// PublicLink identifies a link to a list for people without an account, whoever
+// presents its token can read the list and, with the shopper access type, buy its items
+// swagger:model
+type PublicLink struct {
+	ID         int64     `json:"link_id"`
+	ListID     int64     `json:"list_id"`
+	AccessType string    `json:"access_type"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Token      string    `json:"token"`
+}
+
+// Store identifies a shop of a user, its categories are given in the order its
+// aisles are walked
+// swagger:model
+type Store struct {
+	ID         int64      `json:"store_id"`
+	Name       string     `json:"name"`
+	OwnerID    int64      `json:"owner_id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	Categories []Category `json:"categories"`
+}
+
+// ItemSection holds the items of a category in the shopping view of a list.
+// Aisle is the place of the category in the layout of the list's store, it is
+// 0 for categories the store does not lay out.
+// swagger:model
+type ItemSection struct {
+	Category Category `json:"category"`
+	Aisle    int      `json:"aisle,omitempty"`
+	Items    []Item   `json:"items"`
+}
+
+// Recurrence starts a fresh list of its owner from a list or template on a
+// schedule. Weekly and biweekly recurrences run on Weekday, monthly ones on Day
+// of the month, at midnight UTC. Generated lists are named Name, or the name of
+// the source list when empty, followed by the date they were scheduled for.
+// swagger:model
+type Recurrence struct {
+	ID           int64     `json:"recurrence_id"`
+	OwnerID      int64     `json:"owner_id"`
+	SourceListID int64     `json:"list_id"`
+	Name         string    `json:"name,omitempty"`
+	Frequency    string    `json:"frequency"`
+	Weekday      string    `json:"weekday,omitempty"`
+	Day          int       `json:"day,omitempty"`
+	NextRunAt    time.Time `json:"next_run_at"`
+	Paused       bool      `json:"paused"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// RecurrenceInstance records a list started by a recurrence, ListID is 0 once
+// the list has been purged from the trash
+// swagger:model
+type RecurrenceInstance struct {
+	ID           int64     `json:"instance_id"`
+	RecurrenceID int64     `json:"recurrence_id"`
+	ListID       int64     `json:"list_id"`
+	ScheduledFor time.Time `json:"scheduled_for"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ReminderPreferences tell how long before a list or item is due the user is
+// reminded of it and over which channel. Reminders wait out the quiet hours
+// from QuietFrom to QuietUntil, given as 15:04 in TimeZone.
+// swagger:model
+type ReminderPreferences struct {
+	LeadTime   string `json:"lead_time"`
+	QuietFrom  string `json:"quiet_from,omitempty"`
+	QuietUntil string `json:"quiet_until,omitempty"`
+	TimeZone   string `json:"time_zone"`
+	Channel    string `json:"channel"`
+}
+
+// CategoryRule puts items whose title matches Pattern in Category when they are
+// added without one. Pattern is a keyword matched as a whole word unless Regexp
+// is set. System rules have no id and apply after the rules of the user.
+// swagger:model
+type CategoryRule struct {
+	ID       int64    `json:"rule_id,omitempty"`
+	Pattern  string   `json:"pattern"`
+	Regexp   bool     `json:"regexp"`
+	Category Category `json:"category"`
+	System   bool     `json:"system"`
 }
 
 // PingRequest api is used for checking health of the service
@@ -103,10 +245,12 @@ type LoginResponse struct {
 }
 
 // LogoutRequest will invalidate the user session
+// If AllSessions is set every session of the user is invalidated
 // swagger:model
 type LogoutRequest struct {
 	UserID       int64
 	SessionToken string
+	AllSessions  bool `json:"all_sessions"`
 }
 
 // LogoutResponse represents the response struct returned by logoutAPI
@@ -147,12 +291,37 @@ type GetListsResponse struct {
 	Err   error  `json:"error,omitempty"`
 }
 
+// UpdateListRequest is request schema for updating a list
+// Only the fields present in the request are changed
+// swagger:model
+type UpdateListRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+	Name         *string    `json:"name,omitempty"`
+	Description  *string    `json:"description,omitempty"`
+	Deadline     *time.Time `json:"deadline,omitempty"`
+	Status       *string    `json:"status,omitempty"`
+	// StoreID links the list to a store of the user, 0 unlinks it
+	StoreID *int64 `json:"store_id,omitempty"`
+}
+
+// UpdateListResponse represents the response struct returned by PATCH listAPI
+// swagger:response UpdateListResponse
+type UpdateListResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
 // CreateItemRequest is request schema for creating new item
 // It will create an item for given shopping list
+// With Merge set the quantity is added to a todo item of the list
+// with the same title and a compatible unit, if there is one
 // swagger:model
 type CreateItemRequest struct {
 	SessionToken string
 	Item         Item `json:"item"`
+	Merge        bool `json:"merge"`
 }
 
 // CreateItemResponse represents the response struct returned by POST itemAPI
@@ -162,13 +331,39 @@ type CreateItemResponse struct {
 	Err          error `json:"error,omitempty"`
 }
 
+// UpdateItemRequest is request schema for updating an item
+// Only the fields present in the request are changed, a category
+// without id is added as a new category
+// swagger:model
+type UpdateItemRequest struct {
+	SessionToken string
+	UserID       int64
+	ItemID       int64
+	Title        *string    `json:"title,omitempty"`
+	Description  *string    `json:"description,omitempty"`
+	Quantity     *float64   `json:"quantity,omitempty"`
+	Unit         *string    `json:"unit,omitempty"`
+	Category     *Category  `json:"category,omitempty"`
+	Deadline     *time.Time `json:"deadline,omitempty"`
+}
+
+// UpdateItemResponse represents the response struct returned by PATCH itemAPI
+// swagger:response UpdateItemResponse
+type UpdateItemResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
 // GetListItemsRequest is request schema for reading items
-// It will read all the items from a lists for a user
+// It will read all the items from a lists for a user. The shopping view groups
+// the items by category in the aisle order of the list's store, with the bought
+// items at the bottom.
 // swagger:model
 type GetListItemsRequest struct {
 	SessionToken string
 	UserID       int64
-	ListID       int64 `json:"list_id"`
+	ListID       int64  `json:"list_id"`
+	View         string `json:"view"`
 }
 
 // GetListItemsResponse represents the response struct returned by GET itemAPI
@@ -177,7 +372,9 @@ type GetListItemsResponse struct {
 	SessionToken string
 	// Item represents individual item
 	Items []Item `json:"items"`
-	Err   error  `json:"error,omitempty"`
+	// Sections are only given in the shopping view
+	Sections []ItemSection `json:"sections,omitempty"`
+	Err      error         `json:"error,omitempty"`
 }
 
 // BuyItemRequest is request schema for buy item
@@ -198,14 +395,15 @@ type BuyItemResponse struct {
 }
 
 // ShareListRequest is request schema to share a list with another user
-// It will  share the list
+// It will  share the list until ValidUntil, or without expiry if it is not given
 // swagger:model
 type ShareListRequest struct {
 	SessionToken string
 	UserID       int64
-	ListID       int64  `json:"list_id"`
-	UserName     string `json:"user_name"`
-	AccessType   string `json:"access_type"`
+	ListID       int64     `json:"list_id"`
+	UserName     string    `json:"user_name"`
+	AccessType   string    `json:"access_type"`
+	ValidUntil   time.Time `json:"valid_until"`
 }
 
 // ShareListResponse represents the response struct returned by POST shareAPI
@@ -216,7 +414,7 @@ type ShareListResponse struct {
 }
 
 // GetAllCategoriesRequest is request schema to get categories
-// It will return all the registered categories in system
+// It will return the system categories and the categories of the user and their groups
 // swagger:model
 type GetAllCategoriesRequest struct {
 	SessionToken string
@@ -244,7 +442,7 @@ type DeleteListRequest struct {
 // swagger:response DeleteListResponse
 type DeleteListResponse struct {
 	SessionToken string
-	Err          error `json:error,omitempty`
+	Err          error `json:"error,omitempty"`
 }
 
 // DeleteItemRequest is request schema for delete item in list
@@ -259,5 +457,858 @@ type DeleteItemRequest struct {
 // swagger:response DeleteItemResponse
 type DeleteItemResponse struct {
 	SessionToken string
-	Err          error `json:error,omitempty`
+	Err          error `json:"error,omitempty"`
+}
+
+// UnbuyItemRequest is request schema for undoing the buy of an item
+// It will mark given bought item as todo again
+type UnbuyItemRequest struct {
+	SessionToken string
+	UserID       int64
+	ItemID       int64
+}
+
+// UnbuyItemResponse represents the response struct returned by POST unbuyitemAPI
+// swagger:response UnbuyItemResponse
+type UnbuyItemResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// RestoreItemRequest is request schema for restoring a deleted item
+// It will mark given deleted item as todo again
+type RestoreItemRequest struct {
+	SessionToken string
+	UserID       int64
+	ItemID       int64
+}
+
+// RestoreItemResponse represents the response struct returned by POST restoreitemAPI
+// swagger:response RestoreItemResponse
+type RestoreItemResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// GetTrashRequest is request schema for reading the trash of logged in user
+// swagger:model
+type GetTrashRequest struct {
+	SessionToken string
+	UserID       int64
+}
+
+// GetTrashResponse represents the response struct returned by GET trashAPI
+// It holds the deleted lists user can restore and the deleted items of other lists user can edit
+// swagger:model
+type GetTrashResponse struct {
+	SessionToken string
+	Lists        []List `json:"lists"`
+	Items        []Item `json:"items"`
+	Err          error  `json:"error,omitempty"`
+}
+
+// RestoreListRequest is request schema for restoring a deleted list
+// It will mark given deleted list as todo again
+type RestoreListRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+}
+
+// RestoreListResponse represents the response struct returned by POST restorelistAPI
+// swagger:response RestoreListResponse
+type RestoreListResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// GetContributorsRequest is request schema for reading the contributors of a list
+// It is only available to the managers and the owner of the list
+type GetContributorsRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+}
+
+// GetContributorsResponse represents the response struct returned by GET contributorsAPI
+// swagger:model
+type GetContributorsResponse struct {
+	SessionToken string
+	Contributors []Contributor `json:"contributors"`
+	Groups       []GroupAccess `json:"groups"`
+	Err          error         `json:"error,omitempty"`
+}
+
+// UpdateContributorRequest is request schema for changing the access of a contributor
+// It will set the access type of given user on given list
+// swagger:model
+type UpdateContributorRequest struct {
+	SessionToken  string
+	UserID        int64
+	ListID        int64
+	ContributorID int64
+	AccessType    string `json:"access_type"`
+}
+
+// UpdateContributorResponse represents the response struct returned by PATCH contributorAPI
+// swagger:response UpdateContributorResponse
+type UpdateContributorResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// RevokeContributorRequest is request schema for revoking the access of a contributor
+// It will remove given user from the contributors of given list
+type RevokeContributorRequest struct {
+	SessionToken  string
+	UserID        int64
+	ListID        int64
+	ContributorID int64
+}
+
+// RevokeContributorResponse represents the response struct returned by DELETE contributorAPI
+// swagger:response RevokeContributorResponse
+type RevokeContributorResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// CreateInviteRequest is request schema for inviting someone to a list
+// It creates a single use invitation valid until ExpiresAt, or for a week if it is not given
+// swagger:model
+type CreateInviteRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+	AccessType   string    `json:"access_type"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// CreateInviteResponse represents the response struct returned by POST inviteAPI
+// swagger:model
+type CreateInviteResponse struct {
+	SessionToken string
+	Invite       Invite `json:"invite"`
+	Err          error  `json:"error,omitempty"`
+}
+
+// GetInvitesRequest is request schema for reading the pending invitations to a list
+// It is only available to the managers and the owner of the list
+type GetInvitesRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+}
+
+// GetInvitesResponse represents the response struct returned by GET invitesAPI
+// swagger:model
+type GetInvitesResponse struct {
+	SessionToken string
+	Invites      []Invite `json:"invites"`
+	Err          error    `json:"error,omitempty"`
+}
+
+// CancelInviteRequest is request schema for cancelling a pending invitation
+// It is only available to the managers and the owner of the list
+type CancelInviteRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+	InviteID     int64
+}
+
+// CancelInviteResponse represents the response struct returned by DELETE inviteAPI
+// swagger:response CancelInviteResponse
+type CancelInviteResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// AcceptInviteRequest is request schema for accepting an invitation
+// It grants the logged in user the access the invitation was created for
+// swagger:model
+type AcceptInviteRequest struct {
+	SessionToken string
+	UserID       int64
+	Token        string `json:"token"`
+}
+
+// AcceptInviteResponse represents the response struct returned by POST acceptinviteAPI
+// swagger:model
+type AcceptInviteResponse struct {
+	SessionToken string
+	ListID       int64 `json:"list_id"`
+	Err          error `json:"error,omitempty"`
+}
+
+// TransferOwnershipRequest is request schema for handing a list over to another user
+// The new owner needs at least the editor role on the list, the old owner stays an editor
+// swagger:model
+type TransferOwnershipRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+	UserName     string `json:"user_name"`
+}
+
+// TransferOwnershipResponse represents the response struct returned by POST transferAPI
+// swagger:response TransferOwnershipResponse
+type TransferOwnershipResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// CreateGroupRequest is request schema for creating a group
+// The logged in user becomes the admin of the group
+// swagger:model
+type CreateGroupRequest struct {
+	SessionToken string
+	UserID       int64
+	Name         string `json:"name"`
+}
+
+// CreateGroupResponse represents the response struct returned by POST groupAPI
+// swagger:model
+type CreateGroupResponse struct {
+	SessionToken string
+	Group        Group `json:"group"`
+	Err          error `json:"error,omitempty"`
+}
+
+// GetGroupsRequest is request schema for reading the groups of logged in user
+type GetGroupsRequest struct {
+	SessionToken string
+	UserID       int64
+}
+
+// GetGroupsResponse represents the response struct returned by GET groupAPI
+// swagger:model
+type GetGroupsResponse struct {
+	SessionToken string
+	Groups       []Group `json:"groups"`
+	Err          error   `json:"error,omitempty"`
+}
+
+// GetGroupMembersRequest is request schema for reading the members of a group
+// It is only available to the members of the group
+type GetGroupMembersRequest struct {
+	SessionToken string
+	UserID       int64
+	GroupID      int64
+}
+
+// GetGroupMembersResponse represents the response struct returned by GET groupmembersAPI
+// swagger:model
+type GetGroupMembersResponse struct {
+	SessionToken string
+	Members      []GroupMember `json:"members"`
+	Err          error         `json:"error,omitempty"`
+}
+
+// AddGroupMemberRequest is request schema for adding a user to a group
+// Adding a member again changes their role, only group admins can add members
+// swagger:model
+type AddGroupMemberRequest struct {
+	SessionToken string
+	UserID       int64
+	GroupID      int64
+	UserName     string `json:"user_name"`
+	Role         string `json:"role"`
+}
+
+// AddGroupMemberResponse represents the response struct returned by POST groupmembersAPI
+// swagger:response AddGroupMemberResponse
+type AddGroupMemberResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// RemoveGroupMemberRequest is request schema for removing a user from a group
+// Group admins can remove anyone, other members can only leave the group
+type RemoveGroupMemberRequest struct {
+	SessionToken string
+	UserID       int64
+	GroupID      int64
+	MemberID     int64
+}
+
+// RemoveGroupMemberResponse represents the response struct returned by DELETE groupmemberAPI
+// swagger:response RemoveGroupMemberResponse
+type RemoveGroupMemberResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// ShareListWithGroupRequest is request schema to share a list with every member of a group
+// swagger:model
+type ShareListWithGroupRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64  `json:"list_id"`
+	GroupID      int64  `json:"group_id"`
+	AccessType   string `json:"access_type"`
+}
+
+// ShareListWithGroupResponse represents the response struct returned by POST sharegroupAPI
+// swagger:response ShareListWithGroupResponse
+type ShareListWithGroupResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// UnshareListWithGroupRequest is request schema to stop sharing a list with a group
+type UnshareListWithGroupRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+	GroupID      int64
+}
+
+// UnshareListWithGroupResponse represents the response struct returned by DELETE listgroupAPI
+// swagger:response UnshareListWithGroupResponse
+type UnshareListWithGroupResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// CreatePublicLinkRequest is request schema for publishing a list to people without an account
+// The link gives viewer access unless shopper is asked for, it is valid until ExpiresAt or for a week
+// swagger:model
+type CreatePublicLinkRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+	AccessType   string    `json:"access_type"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// CreatePublicLinkResponse represents the response struct returned by POST publiclinkAPI
+// swagger:model
+type CreatePublicLinkResponse struct {
+	SessionToken string
+	Link         PublicLink `json:"link"`
+	Err          error      `json:"error,omitempty"`
+}
+
+// GetPublicLinksRequest is request schema for reading the public links to a list that still work
+// It is only available to the owner of the list
+type GetPublicLinksRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+}
+
+// GetPublicLinksResponse represents the response struct returned by GET publiclinksAPI
+// swagger:model
+type GetPublicLinksResponse struct {
+	SessionToken string
+	Links        []PublicLink `json:"links"`
+	Err          error        `json:"error,omitempty"`
+}
+
+// RevokePublicLinkRequest is request schema for revoking a public link
+// It is only available to the owner of the list
+type RevokePublicLinkRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+	LinkID       int64
+}
+
+// RevokePublicLinkResponse represents the response struct returned by DELETE publiclinkAPI
+// swagger:response RevokePublicLinkResponse
+type RevokePublicLinkResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// GetPublicListRequest is request schema for reading a list through a public link
+// It does not need a logged in user
+type GetPublicListRequest struct {
+	Token string
+}
+
+// GetPublicListResponse represents the response struct returned by GET publiclistAPI
+// swagger:model
+type GetPublicListResponse struct {
+	List  List   `json:"list"`
+	Items []Item `json:"items"`
+	Err   error  `json:"error,omitempty"`
+}
+
+// BuyPublicItemRequest is request schema for marking an item bought through a public link
+// The link needs the shopper access type, the item is bought on behalf of the user who created the link
+type BuyPublicItemRequest struct {
+	Token  string
+	ItemID int64
+}
+
+// BuyPublicItemResponse represents the response struct returned by POST publicbuyAPI
+// swagger:response BuyPublicItemResponse
+type BuyPublicItemResponse struct {
+	Err error `json:"error,omitempty"`
+}
+
+// CreateCategoryRequest is request schema for registering an item category
+// The category belongs to the user, or to the group if one is given. Among the
+// categories a user sees names are unique regardless of their case.
+// swagger:model
+type CreateCategoryRequest struct {
+	SessionToken string
+	UserID       int64
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	GroupID      int64  `json:"group_id"`
+}
+
+// CreateCategoryResponse represents the response struct returned by POST categoriesAPI
+// swagger:model
+type CreateCategoryResponse struct {
+	SessionToken string
+	Category     Category `json:"category"`
+	Err          error    `json:"error,omitempty"`
+}
+
+// UpdateCategoryRequest is request schema for renaming a category or changing its type
+// swagger:model
+type UpdateCategoryRequest struct {
+	SessionToken string
+	UserID       int64
+	CategoryID   int64
+	Name         *string `json:"name"`
+	Type         *string `json:"type"`
+}
+
+// UpdateCategoryResponse represents the response struct returned by PATCH categoryAPI
+// swagger:model
+type UpdateCategoryResponse struct {
+	SessionToken string
+	Category     Category `json:"category"`
+	Err          error    `json:"error,omitempty"`
+}
+
+// DeleteCategoryRequest is request schema for deleting a category no item belongs to
+type DeleteCategoryRequest struct {
+	SessionToken string
+	UserID       int64
+	CategoryID   int64
+}
+
+// DeleteCategoryResponse represents the response struct returned by DELETE categoryAPI
+// swagger:response DeleteCategoryResponse
+type DeleteCategoryResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// MergeCategoriesRequest is request schema for merging duplicate categories into one
+// The items of the duplicates are moved to the category of the request before the duplicates are deleted
+// swagger:model
+type MergeCategoriesRequest struct {
+	SessionToken string
+	UserID       int64
+	CategoryID   int64
+	DuplicateIDs []int64 `json:"duplicate_ids"`
+}
+
+// MergeCategoriesResponse represents the response struct returned by POST mergecategoriesAPI
+// swagger:model
+type MergeCategoriesResponse struct {
+	SessionToken string
+	Category     Category `json:"category"`
+	MovedItems   int64    `json:"moved_items"`
+	Err          error    `json:"error,omitempty"`
+}
+
+// CreateCategoryRuleRequest is request schema for adding a categorisation rule of the user
+// The rule puts items in a category the user sees, newer rules apply before older ones
+// swagger:model
+type CreateCategoryRuleRequest struct {
+	SessionToken string
+	UserID       int64
+	Pattern      string `json:"pattern"`
+	Regexp       bool   `json:"regexp"`
+	CategoryID   int64  `json:"category_id"`
+}
+
+// CreateCategoryRuleResponse represents the response struct returned by POST categoryrulesAPI
+// swagger:model
+type CreateCategoryRuleResponse struct {
+	SessionToken string
+	Rule         CategoryRule `json:"rule"`
+	Err          error        `json:"error,omitempty"`
+}
+
+// GetCategoryRulesRequest is request schema for reading the rules that categorise the items of the user
+// The rules of the user come first, in the order they apply, followed by the system rules
+type GetCategoryRulesRequest struct {
+	SessionToken string
+	UserID       int64
+}
+
+// GetCategoryRulesResponse represents the response struct returned by GET categoryrulesAPI
+// swagger:model
+type GetCategoryRulesResponse struct {
+	SessionToken string
+	Rules        []CategoryRule `json:"rules"`
+	Err          error          `json:"error,omitempty"`
+}
+
+// DeleteCategoryRuleRequest is request schema for deleting a categorisation rule of the user
+type DeleteCategoryRuleRequest struct {
+	SessionToken string
+	UserID       int64
+	RuleID       int64
+}
+
+// DeleteCategoryRuleResponse represents the response struct returned by DELETE categoryruleAPI
+// swagger:response DeleteCategoryRuleResponse
+type DeleteCategoryRuleResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// CreateStoreRequest is request schema for adding a store of the user
+// The categories are given in the order the aisles of the store are walked
+// swagger:model
+type CreateStoreRequest struct {
+	SessionToken string
+	UserID       int64
+	Name         string  `json:"name"`
+	CategoryIDs  []int64 `json:"category_ids"`
+}
+
+// CreateStoreResponse represents the response struct returned by POST storesAPI
+// swagger:model
+type CreateStoreResponse struct {
+	SessionToken string
+	Store        Store `json:"store"`
+	Err          error `json:"error,omitempty"`
+}
+
+// GetStoresRequest is request schema for reading the stores of the user
+type GetStoresRequest struct {
+	SessionToken string
+	UserID       int64
+}
+
+// GetStoresResponse represents the response struct returned by GET storesAPI
+// swagger:model
+type GetStoresResponse struct {
+	SessionToken string
+	Stores       []Store `json:"stores"`
+	Err          error   `json:"error,omitempty"`
+}
+
+// UpdateStoreRequest is request schema for renaming a store or laying out its aisles again
+// Given categories replace the layout of the store
+// swagger:model
+type UpdateStoreRequest struct {
+	SessionToken string
+	UserID       int64
+	StoreID      int64
+	Name         *string  `json:"name"`
+	CategoryIDs  *[]int64 `json:"category_ids"`
+}
+
+// UpdateStoreResponse represents the response struct returned by PATCH storeAPI
+// swagger:model
+type UpdateStoreResponse struct {
+	SessionToken string
+	Store        Store `json:"store"`
+	Err          error `json:"error,omitempty"`
+}
+
+// DeleteStoreRequest is request schema for deleting a store, the lists of the store are unlinked from it
+type DeleteStoreRequest struct {
+	SessionToken string
+	UserID       int64
+	StoreID      int64
+}
+
+// DeleteStoreResponse represents the response struct returned by DELETE storeAPI
+// swagger:response DeleteStoreResponse
+type DeleteStoreResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// ReorderItemsRequest is request schema for changing the order of the items of a list
+// Either Order gives every item of the list that is not deleted in its new
+// order, or the item ItemID is moved right before BeforeID or right after AfterID
+// swagger:model
+type ReorderItemsRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+	Order        []int64 `json:"order"`
+	ItemID       int64   `json:"item_id"`
+	BeforeID     int64   `json:"before_id"`
+	AfterID      int64   `json:"after_id"`
+}
+
+// ReorderItemsResponse represents the response struct returned by POST reorderAPI
+// swagger:model
+type ReorderItemsResponse struct {
+	SessionToken string
+	Items        []Item `json:"items"`
+	Err          error  `json:"error,omitempty"`
+}
+
+// MoveItemsRequest is request schema for moving items of a list to another list
+// The items keep who created and bought them and when, they go to the end of the other list
+// swagger:model
+type MoveItemsRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+	ItemIDs      []int64 `json:"item_ids"`
+	ToListID     int64   `json:"to_list_id"`
+}
+
+// MoveItemsResponse represents the response struct returned by POST moveitemsAPI
+// swagger:model
+type MoveItemsResponse struct {
+	SessionToken string
+	Items        []Item `json:"items"`
+	Err          error  `json:"error,omitempty"`
+}
+
+// CopyItemsRequest is request schema for copying items of a list to another list
+// The copies are new items of the user that still have to be bought
+// swagger:model
+type CopyItemsRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+	ItemIDs      []int64 `json:"item_ids"`
+	ToListID     int64   `json:"to_list_id"`
+}
+
+// CopyItemsResponse represents the response struct returned by POST copyitemsAPI
+// swagger:model
+type CopyItemsResponse struct {
+	SessionToken string
+	Items        []Item `json:"items"`
+	Err          error  `json:"error,omitempty"`
+}
+
+// CloneListRequest is request schema for duplicating a list
+// The copy belongs to the user and all its items are still to be bought, it keeps the name of the list unless another is given
+// swagger:model
+type CloneListRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+	Name         string `json:"name"`
+}
+
+// CloneListResponse represents the response struct returned by POST clonelistAPI
+// swagger:model
+type CloneListResponse struct {
+	SessionToken string
+	List         List  `json:"list"`
+	Err          error `json:"error,omitempty"`
+}
+
+// CreateTemplateRequest is request schema for saving a list as a named template
+// Templates are shared, listed and deleted like lists
+// swagger:model
+type CreateTemplateRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64
+	Name         string `json:"name"`
+}
+
+// CreateTemplateResponse represents the response struct returned by POST templateAPI
+// swagger:model
+type CreateTemplateResponse struct {
+	SessionToken string
+	Template     List  `json:"template"`
+	Err          error `json:"error,omitempty"`
+}
+
+// GetTemplatesRequest is request schema for reading the templates of the user and the ones shared with them
+type GetTemplatesRequest struct {
+	SessionToken string
+	UserID       int64
+}
+
+// GetTemplatesResponse represents the response struct returned by GET templatesAPI
+// swagger:model
+type GetTemplatesResponse struct {
+	SessionToken string
+	Templates    []List `json:"templates"`
+	Err          error  `json:"error,omitempty"`
+}
+
+// InstantiateTemplateRequest is request schema for starting a new list from a template
+// The list takes the name of the template unless another is given
+// swagger:model
+type InstantiateTemplateRequest struct {
+	SessionToken string
+	UserID       int64
+	TemplateID   int64
+	Name         string     `json:"name"`
+	Deadline     *time.Time `json:"deadline,omitempty"`
+}
+
+// InstantiateTemplateResponse represents the response struct returned by POST instantiatetemplateAPI
+// swagger:model
+type InstantiateTemplateResponse struct {
+	SessionToken string
+	List         List  `json:"list"`
+	Err          error `json:"error,omitempty"`
+}
+
+// CreateRecurrenceRequest is request schema for starting a list again and again on a schedule
+// Weekly and biweekly recurrences take the Weekday, monthly ones the Day of the month from 1 to 28
+// swagger:model
+type CreateRecurrenceRequest struct {
+	SessionToken string
+	UserID       int64
+	ListID       int64  `json:"list_id"`
+	Name         string `json:"name"`
+	Frequency    string `json:"frequency"`
+	Weekday      string `json:"weekday"`
+	Day          int    `json:"day"`
+}
+
+// CreateRecurrenceResponse represents the response struct returned by POST recurrenceAPI
+// swagger:model
+type CreateRecurrenceResponse struct {
+	SessionToken string
+	Recurrence   Recurrence `json:"recurrence"`
+	Err          error      `json:"error,omitempty"`
+}
+
+// GetRecurrencesRequest is request schema for reading the recurring lists of the user
+type GetRecurrencesRequest struct {
+	SessionToken string
+	UserID       int64
+}
+
+// GetRecurrencesResponse represents the response struct returned by GET recurrenceAPI
+// swagger:model
+type GetRecurrencesResponse struct {
+	SessionToken string
+	Recurrences  []Recurrence `json:"recurrences"`
+	Err          error        `json:"error,omitempty"`
+}
+
+// PauseRecurrenceRequest is request schema for stopping a recurrence from starting lists until it is resumed
+type PauseRecurrenceRequest struct {
+	SessionToken string
+	UserID       int64
+	RecurrenceID int64
+}
+
+// PauseRecurrenceResponse represents the response struct returned by POST pauserecurrenceAPI
+// swagger:model
+type PauseRecurrenceResponse struct {
+	SessionToken string
+	Recurrence   Recurrence `json:"recurrence"`
+	Err          error      `json:"error,omitempty"`
+}
+
+// ResumeRecurrenceRequest is request schema for resuming a paused recurrence
+// The runs missed while it was paused are skipped
+type ResumeRecurrenceRequest struct {
+	SessionToken string
+	UserID       int64
+	RecurrenceID int64
+}
+
+// ResumeRecurrenceResponse represents the response struct returned by POST resumerecurrenceAPI
+// swagger:model
+type ResumeRecurrenceResponse struct {
+	SessionToken string
+	Recurrence   Recurrence `json:"recurrence"`
+	Err          error      `json:"error,omitempty"`
+}
+
+// DeleteRecurrenceRequest is request schema for deleting a recurrence, the lists it started are kept
+type DeleteRecurrenceRequest struct {
+	SessionToken string
+	UserID       int64
+	RecurrenceID int64
+}
+
+// DeleteRecurrenceResponse represents the response struct returned by DELETE recurrenceAPI
+// swagger:response DeleteRecurrenceResponse
+type DeleteRecurrenceResponse struct {
+	SessionToken string
+	Err          error `json:"error,omitempty"`
+}
+
+// GetRecurrenceInstancesRequest is request schema for reading the lists a recurrence started
+type GetRecurrenceInstancesRequest struct {
+	SessionToken string
+	UserID       int64
+	RecurrenceID int64
+}
+
+// GetRecurrenceInstancesResponse represents the response struct returned by GET recurrenceinstancesAPI
+// swagger:model
+type GetRecurrenceInstancesResponse struct {
+	SessionToken string
+	Instances    []RecurrenceInstance `json:"instances"`
+	Err          error                `json:"error,omitempty"`
+}
+
+// GetDueItemsRequest is request schema for reading the items still to be bought
+// that are overdue or due soon on the lists the user can view
+// Within is how far ahead an item counts as due soon, a duration such as 48h, 24h when empty
+type GetDueItemsRequest struct {
+	SessionToken string
+	UserID       int64
+	Within       string
+}
+
+// GetDueItemsResponse represents the response struct returned by GET dueitemsAPI
+// Both sets of items are sorted by deadline
+// swagger:model
+type GetDueItemsResponse struct {
+	SessionToken string
+	Overdue      []Item `json:"overdue"`
+	DueSoon      []Item `json:"due_soon"`
+	Err          error  `json:"error,omitempty"`
+}
+
+// GetReminderPreferencesRequest is request schema for reading the reminder preferences of the user
+type GetReminderPreferencesRequest struct {
+	SessionToken string
+	UserID       int64
+}
+
+// GetReminderPreferencesResponse represents the response struct returned by GET reminderpreferencesAPI
+// Users who never set their preferences get the defaults
+// swagger:model
+type GetReminderPreferencesResponse struct {
+	SessionToken string
+	Preferences  ReminderPreferences `json:"preferences"`
+	Err          error               `json:"error,omitempty"`
+}
+
+// UpdateReminderPreferencesRequest is request schema for replacing the reminder preferences of the user
+// LeadTime is a duration such as 24h of at most a week, quiet hours are given
+// as 15:04 and the channel is email or none, fields left out take their default
+// swagger:model
+type UpdateReminderPreferencesRequest struct {
+	SessionToken string
+	UserID       int64
+	LeadTime     string `json:"lead_time"`
+	QuietFrom    string `json:"quiet_from"`
+	QuietUntil   string `json:"quiet_until"`
+	TimeZone     string `json:"time_zone"`
+	Channel      string `json:"channel"`
+}
+
+// UpdateReminderPreferencesResponse represents the response struct returned by PUT reminderpreferencesAPI
+// swagger:model
+type UpdateReminderPreferencesResponse struct {
+	SessionToken string
+	Preferences  ReminderPreferences `json:"preferences"`
+	Err          error               `json:"error,omitempty"`
 }
@@ -28,9 +28,15 @@ func (r CreateListResponse) Failed() error { return r.Err }
 // Failed implements endpoint.Failer.
 func (r GetListsResponse) Failed() error { return r.Err }
 
+// Failed implements endpoint.Failer.
+func (r UpdateListResponse) Failed() error { return r.Err }
+
 // Failed implements endpoint.Failer.
 func (r CreateItemResponse) Failed() error { return r.Err }
 
+// Failed implements endpoint.Failer.
+func (r UpdateItemResponse) Failed() error { return r.Err }
+
 // Failed implements endpoint.Failer.
 func (r GetListItemsResponse) Failed() error { return r.Err }
 
@@ -48,3 +54,156 @@ func (r DeleteListResponse) Failed() error { return r.Err }
 
 // Failed implements endpoint.Failer.
 func (r DeleteItemResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r UnbuyItemResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r RestoreItemResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetTrashResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r RestoreListResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetContributorsResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r UpdateContributorResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r RevokeContributorResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r CreateInviteResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetInvitesResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r CancelInviteResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r AcceptInviteResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r TransferOwnershipResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r CreateGroupResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetGroupsResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetGroupMembersResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r AddGroupMemberResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r RemoveGroupMemberResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r ShareListWithGroupResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r UnshareListWithGroupResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r CreatePublicLinkResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetPublicLinksResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r RevokePublicLinkResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetPublicListResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r BuyPublicItemResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r CreateCategoryResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r UpdateCategoryResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r DeleteCategoryResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r MergeCategoriesResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r CreateCategoryRuleResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetCategoryRulesResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r DeleteCategoryRuleResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r CreateStoreResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetStoresResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r UpdateStoreResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r DeleteStoreResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r ReorderItemsResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r MoveItemsResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r CopyItemsResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r CloneListResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r CreateTemplateResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetTemplatesResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r InstantiateTemplateResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r CreateRecurrenceResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetRecurrencesResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r PauseRecurrenceResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r ResumeRecurrenceResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r DeleteRecurrenceResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetRecurrenceInstancesResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetDueItemsResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetReminderPreferencesResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r UpdateReminderPreferencesResponse) Failed() error { return r.Err }
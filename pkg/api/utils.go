@@ -1,10 +1,10 @@
 package api
 
 import (
-	"github.com/google/uuid"
+	"context"
 	"github.com/pkg/errors"
 	"net/http"
-	"strconv"
+	"shoppinglist/pkg/session"
 )
 
 type UserContext struct {
@@ -22,7 +22,96 @@ const (
 	ReadOnly = "read_only"
 )
 
-func GetUserContextFromSession(r *http.Request) (uc UserContext, err error) {
+// roles of group members
+const (
+	GroupAdminRole  = "admin"
+	GroupMemberRole = "member"
+)
+
+// roles a user can have on a list, from the least to the most privileged. The
+// owner role belongs to the owner of the list and can not be granted.
+const (
+	RoleViewer  = "viewer"
+	RoleShopper = "shopper"
+	RoleEditor  = "editor"
+	RoleManager = "manager"
+	RoleOwner   = "owner"
+)
+
+// roleRanks orders the roles, the access types Edit and ReadOnly of older
+// clients rank as editor and viewer
+var roleRanks = map[string]int{
+	ReadOnly:    1,
+	RoleViewer:  1,
+	RoleShopper: 2,
+	Edit:        3,
+	RoleEditor:  3,
+	RoleManager: 4,
+	RoleOwner:   5,
+}
+
+// NormaliseRole maps the access types of older clients to the matching role
+func NormaliseRole(accessType string) string {
+	switch accessType {
+	case ReadOnly:
+		return RoleViewer
+	case Edit:
+		return RoleEditor
+	}
+	return accessType
+}
+
+// RoleAllows reports whether role is at least as privileged as required
+func RoleAllows(role string, required string) bool {
+	rank, ok := roleRanks[role]
+	return ok && rank >= roleRanks[required]
+}
+
+// StrongerAccess returns the role that allows more of the two given
+func StrongerAccess(a string, b string) string {
+	if roleRanks[NormaliseRole(b)] > roleRanks[NormaliseRole(a)] {
+		return NormaliseRole(b)
+	}
+	return NormaliseRole(a)
+}
+
+// states of a list invitation
+const (
+	InvitePending   = "pending"
+	InviteAccepted  = "accepted"
+	InviteCancelled = "cancelled"
+)
+
+// views of the items of a list
+const (
+	ListView     = "list"
+	ShoppingView = "shopping"
+)
+
+// channels reminders are sent over, none turns reminders off
+const (
+	EmailChannel = "email"
+	NoChannel    = "none"
+)
+
+// frequencies of recurring lists
+const (
+	Weekly   = "weekly"
+	Biweekly = "biweekly"
+	Monthly  = "monthly"
+)
+
+// units supported for item quantities
+const (
+	UnitPieces     = "pcs"
+	UnitGram       = "g"
+	UnitKilogram   = "kg"
+	UnitMilliliter = "ml"
+	UnitLiter      = "l"
+	UnitPack       = "pack"
+)
+
+func GetUserContextFromSession(ctx context.Context, r *http.Request, sessions session.Store) (uc UserContext, err error) {
 	// obtain the session token from the requests cookies
 	c, err := r.Cookie("session_token")
 	if err != nil {
@@ -35,54 +124,13 @@ func GetUserContextFromSession(r *http.Request) (uc UserContext, err error) {
 	}
 	sessionToken := c.Value
 	uc.SessionToken = sessionToken
-	// get the user id from cache
-	response, err := Cache.Do("GET", sessionToken)
+	// get the user id from session store
+	uc.UserID, err = sessions.Get(ctx, sessionToken)
 	if err != nil {
-		return uc, errors.New("failed to read user id from cache")
-	}
-	if response == nil {
-		return uc, errors.New("unauthorised access")
-	}
-	uid := string(response.([]byte))
-	uc.UserID, err = strconv.ParseInt(uid, 10, 64)
-	if err != nil {
-		return uc, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+		if err == session.ErrNoSession {
+			return uc, errors.New("unauthorised access")
+		}
+		return uc, errors.Wrap(err, "failed to read user id from cache")
 	}
 	return uc, nil
 }
-
-func SetSessionContext(uc UserContext) (sessionToken string, err error) {
-	// Create a new random session token
-	sessionToken = uuid.New().String()
-	// Set the token in the cache, along with the user whom it represents
-	// The token has an expiry time of 120 seconds
-	_, err = Cache.Do("SETEX", sessionToken, "120", uc.UserID)
-	if err != nil {
-		// If there is an error in setting the cache, return an internal server error
-		return "", errors.Wrapf(err, "failed to set the session for username %v", uc.UserName)
-	}
-	return
-}
-
-func DeleteSessionContext(sessionToken string) error {
-	// Delete the older session token
-	_, err := Cache.Do("DEL", sessionToken)
-	if err != nil {
-		return errors.Wrap(err, "failed to delete old session")
-	}
-	return nil
-}
-
-func RefreshSessionContext(uc UserContext) (string, error) {
-	newSessionToken, err := SetSessionContext(uc)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to refresh user session")
-	}
-
-	// Delete the older session token
-	err = DeleteSessionContext(uc.SessionToken)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to delete old session while refreshing user session")
-	}
-	return newSessionToken, nil
-}
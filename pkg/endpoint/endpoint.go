@@ -9,19 +9,72 @@ import (
 )
 
 type Endpoints struct {
-	Ping             endpoint.Endpoint
-	Signup           endpoint.Endpoint
-	Login            endpoint.Endpoint
-	CreateList       endpoint.Endpoint
-	GetLists         endpoint.Endpoint
-	CreateItem       endpoint.Endpoint
-	GetListItems     endpoint.Endpoint
-	BuyItem          endpoint.Endpoint
-	ShareList        endpoint.Endpoint
-	Logout           endpoint.Endpoint
-	GetAllCategories endpoint.Endpoint
-	DeleteList       endpoint.Endpoint
-	DeleteItem       endpoint.Endpoint
+	Ping                      endpoint.Endpoint
+	Signup                    endpoint.Endpoint
+	Login                     endpoint.Endpoint
+	CreateList                endpoint.Endpoint
+	GetLists                  endpoint.Endpoint
+	UpdateList                endpoint.Endpoint
+	CreateItem                endpoint.Endpoint
+	UpdateItem                endpoint.Endpoint
+	GetListItems              endpoint.Endpoint
+	BuyItem                   endpoint.Endpoint
+	ShareList                 endpoint.Endpoint
+	Logout                    endpoint.Endpoint
+	GetAllCategories          endpoint.Endpoint
+	DeleteList                endpoint.Endpoint
+	DeleteItem                endpoint.Endpoint
+	UnbuyItem                 endpoint.Endpoint
+	RestoreItem               endpoint.Endpoint
+	GetTrash                  endpoint.Endpoint
+	RestoreList               endpoint.Endpoint
+	GetContributors           endpoint.Endpoint
+	UpdateContributor         endpoint.Endpoint
+	RevokeContributor         endpoint.Endpoint
+	CreateInvite              endpoint.Endpoint
+	GetInvites                endpoint.Endpoint
+	CancelInvite              endpoint.Endpoint
+	AcceptInvite              endpoint.Endpoint
+	TransferOwnership         endpoint.Endpoint
+	CreateGroup               endpoint.Endpoint
+	GetGroups                 endpoint.Endpoint
+	GetGroupMembers           endpoint.Endpoint
+	AddGroupMember            endpoint.Endpoint
+	RemoveGroupMember         endpoint.Endpoint
+	ShareListWithGroup        endpoint.Endpoint
+	UnshareListWithGroup      endpoint.Endpoint
+	CreatePublicLink          endpoint.Endpoint
+	GetPublicLinks            endpoint.Endpoint
+	RevokePublicLink          endpoint.Endpoint
+	GetPublicList             endpoint.Endpoint
+	BuyPublicItem             endpoint.Endpoint
+	CreateCategory            endpoint.Endpoint
+	UpdateCategory            endpoint.Endpoint
+	DeleteCategory            endpoint.Endpoint
+	MergeCategories           endpoint.Endpoint
+	CreateCategoryRule        endpoint.Endpoint
+	GetCategoryRules          endpoint.Endpoint
+	DeleteCategoryRule        endpoint.Endpoint
+	CreateStore               endpoint.Endpoint
+	GetStores                 endpoint.Endpoint
+	UpdateStore               endpoint.Endpoint
+	DeleteStore               endpoint.Endpoint
+	ReorderItems              endpoint.Endpoint
+	MoveItems                 endpoint.Endpoint
+	CopyItems                 endpoint.Endpoint
+	CloneList                 endpoint.Endpoint
+	CreateTemplate            endpoint.Endpoint
+	GetTemplates              endpoint.Endpoint
+	InstantiateTemplate       endpoint.Endpoint
+	CreateRecurrence          endpoint.Endpoint
+	GetRecurrences            endpoint.Endpoint
+	PauseRecurrence           endpoint.Endpoint
+	ResumeRecurrence          endpoint.Endpoint
+	DeleteRecurrence          endpoint.Endpoint
+	GetRecurrenceInstances    endpoint.Endpoint
+	GetDueItems               endpoint.Endpoint
+	GetReminderPreferences    endpoint.Endpoint
+	UpdateReminderPreferences endpoint.Endpoint
 }
 
 func New(s service.Service, logger log.Logger) Endpoints {
@@ -60,12 +113,24 @@ func New(s service.Service, logger log.Logger) Endpoints {
 		getListsEndpoint = LoggingMiddleware(log.With(logger, "method", "GetLists"))(getListsEndpoint)
 	}
 
+	var updateListEndpoint endpoint.Endpoint
+	{
+		updateListEndpoint = MakeUpdateListEndpoint(s)
+		updateListEndpoint = LoggingMiddleware(log.With(logger, "method", "UpdateList"))(updateListEndpoint)
+	}
+
 	var createItemEndpoint endpoint.Endpoint
 	{
 		createItemEndpoint = MakeCreateItemEndpoint(s)
 		createItemEndpoint = LoggingMiddleware(log.With(logger, "method", "GetItem"))(createItemEndpoint)
 	}
 
+	var updateItemEndpoint endpoint.Endpoint
+	{
+		updateItemEndpoint = MakeUpdateItemEndpoint(s)
+		updateItemEndpoint = LoggingMiddleware(log.With(logger, "method", "UpdateItem"))(updateItemEndpoint)
+	}
+
 	var getListItemsEndpoint endpoint.Endpoint
 	{
 		getListItemsEndpoint = MakeGetListItemsEndpoint(s)
@@ -102,20 +167,379 @@ func New(s service.Service, logger log.Logger) Endpoints {
 		deleteItemEndpoint = LoggingMiddleware(log.With(logger, "method", "DeleteItem"))(deleteItemEndpoint)
 	}
 
+	var unbuyItemEndpoint endpoint.Endpoint
+	{
+		unbuyItemEndpoint = MakeUnbuyItemEndpoint(s)
+		unbuyItemEndpoint = LoggingMiddleware(log.With(logger, "method", "UnbuyItem"))(unbuyItemEndpoint)
+	}
+
+	var restoreItemEndpoint endpoint.Endpoint
+	{
+		restoreItemEndpoint = MakeRestoreItemEndpoint(s)
+		restoreItemEndpoint = LoggingMiddleware(log.With(logger, "method", "RestoreItem"))(restoreItemEndpoint)
+	}
+
+	var getTrashEndpoint endpoint.Endpoint
+	{
+		getTrashEndpoint = MakeGetTrashEndpoint(s)
+		getTrashEndpoint = LoggingMiddleware(log.With(logger, "method", "GetTrash"))(getTrashEndpoint)
+	}
+
+	var restoreListEndpoint endpoint.Endpoint
+	{
+		restoreListEndpoint = MakeRestoreListEndpoint(s)
+		restoreListEndpoint = LoggingMiddleware(log.With(logger, "method", "RestoreList"))(restoreListEndpoint)
+	}
+
+	var getContributorsEndpoint endpoint.Endpoint
+	{
+		getContributorsEndpoint = MakeGetContributorsEndpoint(s)
+		getContributorsEndpoint = LoggingMiddleware(log.With(logger, "method", "GetContributors"))(getContributorsEndpoint)
+	}
+
+	var updateContributorEndpoint endpoint.Endpoint
+	{
+		updateContributorEndpoint = MakeUpdateContributorEndpoint(s)
+		updateContributorEndpoint = LoggingMiddleware(log.With(logger, "method", "UpdateContributor"))(updateContributorEndpoint)
+	}
+
+	var revokeContributorEndpoint endpoint.Endpoint
+	{
+		revokeContributorEndpoint = MakeRevokeContributorEndpoint(s)
+		revokeContributorEndpoint = LoggingMiddleware(log.With(logger, "method", "RevokeContributor"))(revokeContributorEndpoint)
+	}
+
+	var createInviteEndpoint endpoint.Endpoint
+	{
+		createInviteEndpoint = MakeCreateInviteEndpoint(s)
+		createInviteEndpoint = LoggingMiddleware(log.With(logger, "method", "CreateInvite"))(createInviteEndpoint)
+	}
+
+	var getInvitesEndpoint endpoint.Endpoint
+	{
+		getInvitesEndpoint = MakeGetInvitesEndpoint(s)
+		getInvitesEndpoint = LoggingMiddleware(log.With(logger, "method", "GetInvites"))(getInvitesEndpoint)
+	}
+
+	var cancelInviteEndpoint endpoint.Endpoint
+	{
+		cancelInviteEndpoint = MakeCancelInviteEndpoint(s)
+		cancelInviteEndpoint = LoggingMiddleware(log.With(logger, "method", "CancelInvite"))(cancelInviteEndpoint)
+	}
+
+	var acceptInviteEndpoint endpoint.Endpoint
+	{
+		acceptInviteEndpoint = MakeAcceptInviteEndpoint(s)
+		acceptInviteEndpoint = LoggingMiddleware(log.With(logger, "method", "AcceptInvite"))(acceptInviteEndpoint)
+	}
+
+	var transferOwnershipEndpoint endpoint.Endpoint
+	{
+		transferOwnershipEndpoint = MakeTransferOwnershipEndpoint(s)
+		transferOwnershipEndpoint = LoggingMiddleware(log.With(logger, "method", "TransferOwnership"))(transferOwnershipEndpoint)
+	}
+
+	var createGroupEndpoint endpoint.Endpoint
+	{
+		createGroupEndpoint = MakeCreateGroupEndpoint(s)
+		createGroupEndpoint = LoggingMiddleware(log.With(logger, "method", "CreateGroup"))(createGroupEndpoint)
+	}
+
+	var getGroupsEndpoint endpoint.Endpoint
+	{
+		getGroupsEndpoint = MakeGetGroupsEndpoint(s)
+		getGroupsEndpoint = LoggingMiddleware(log.With(logger, "method", "GetGroups"))(getGroupsEndpoint)
+	}
+
+	var getGroupMembersEndpoint endpoint.Endpoint
+	{
+		getGroupMembersEndpoint = MakeGetGroupMembersEndpoint(s)
+		getGroupMembersEndpoint = LoggingMiddleware(log.With(logger, "method", "GetGroupMembers"))(getGroupMembersEndpoint)
+	}
+
+	var addGroupMemberEndpoint endpoint.Endpoint
+	{
+		addGroupMemberEndpoint = MakeAddGroupMemberEndpoint(s)
+		addGroupMemberEndpoint = LoggingMiddleware(log.With(logger, "method", "AddGroupMember"))(addGroupMemberEndpoint)
+	}
+
+	var removeGroupMemberEndpoint endpoint.Endpoint
+	{
+		removeGroupMemberEndpoint = MakeRemoveGroupMemberEndpoint(s)
+		removeGroupMemberEndpoint = LoggingMiddleware(log.With(logger, "method", "RemoveGroupMember"))(removeGroupMemberEndpoint)
+	}
+
+	var shareListWithGroupEndpoint endpoint.Endpoint
+	{
+		shareListWithGroupEndpoint = MakeShareListWithGroupEndpoint(s)
+		shareListWithGroupEndpoint = LoggingMiddleware(log.With(logger, "method", "ShareListWithGroup"))(shareListWithGroupEndpoint)
+	}
+
+	var unshareListWithGroupEndpoint endpoint.Endpoint
+	{
+		unshareListWithGroupEndpoint = MakeUnshareListWithGroupEndpoint(s)
+		unshareListWithGroupEndpoint = LoggingMiddleware(log.With(logger, "method", "UnshareListWithGroup"))(unshareListWithGroupEndpoint)
+	}
+
+	var createPublicLinkEndpoint endpoint.Endpoint
+	{
+		createPublicLinkEndpoint = MakeCreatePublicLinkEndpoint(s)
+		createPublicLinkEndpoint = LoggingMiddleware(log.With(logger, "method", "CreatePublicLink"))(createPublicLinkEndpoint)
+	}
+
+	var getPublicLinksEndpoint endpoint.Endpoint
+	{
+		getPublicLinksEndpoint = MakeGetPublicLinksEndpoint(s)
+		getPublicLinksEndpoint = LoggingMiddleware(log.With(logger, "method", "GetPublicLinks"))(getPublicLinksEndpoint)
+	}
+
+	var revokePublicLinkEndpoint endpoint.Endpoint
+	{
+		revokePublicLinkEndpoint = MakeRevokePublicLinkEndpoint(s)
+		revokePublicLinkEndpoint = LoggingMiddleware(log.With(logger, "method", "RevokePublicLink"))(revokePublicLinkEndpoint)
+	}
+
+	var getPublicListEndpoint endpoint.Endpoint
+	{
+		getPublicListEndpoint = MakeGetPublicListEndpoint(s)
+		getPublicListEndpoint = LoggingMiddleware(log.With(logger, "method", "GetPublicList"))(getPublicListEndpoint)
+	}
+
+	var buyPublicItemEndpoint endpoint.Endpoint
+	{
+		buyPublicItemEndpoint = MakeBuyPublicItemEndpoint(s)
+		buyPublicItemEndpoint = LoggingMiddleware(log.With(logger, "method", "BuyPublicItem"))(buyPublicItemEndpoint)
+	}
+
+	var createCategoryEndpoint endpoint.Endpoint
+	{
+		createCategoryEndpoint = MakeCreateCategoryEndpoint(s)
+		createCategoryEndpoint = LoggingMiddleware(log.With(logger, "method", "CreateCategory"))(createCategoryEndpoint)
+	}
+
+	var updateCategoryEndpoint endpoint.Endpoint
+	{
+		updateCategoryEndpoint = MakeUpdateCategoryEndpoint(s)
+		updateCategoryEndpoint = LoggingMiddleware(log.With(logger, "method", "UpdateCategory"))(updateCategoryEndpoint)
+	}
+
+	var deleteCategoryEndpoint endpoint.Endpoint
+	{
+		deleteCategoryEndpoint = MakeDeleteCategoryEndpoint(s)
+		deleteCategoryEndpoint = LoggingMiddleware(log.With(logger, "method", "DeleteCategory"))(deleteCategoryEndpoint)
+	}
+
+	var mergeCategoriesEndpoint endpoint.Endpoint
+	{
+		mergeCategoriesEndpoint = MakeMergeCategoriesEndpoint(s)
+		mergeCategoriesEndpoint = LoggingMiddleware(log.With(logger, "method", "MergeCategories"))(mergeCategoriesEndpoint)
+	}
+
+	var createCategoryRuleEndpoint endpoint.Endpoint
+	{
+		createCategoryRuleEndpoint = MakeCreateCategoryRuleEndpoint(s)
+		createCategoryRuleEndpoint = LoggingMiddleware(log.With(logger, "method", "CreateCategoryRule"))(createCategoryRuleEndpoint)
+	}
+
+	var getCategoryRulesEndpoint endpoint.Endpoint
+	{
+		getCategoryRulesEndpoint = MakeGetCategoryRulesEndpoint(s)
+		getCategoryRulesEndpoint = LoggingMiddleware(log.With(logger, "method", "GetCategoryRules"))(getCategoryRulesEndpoint)
+	}
+
+	var deleteCategoryRuleEndpoint endpoint.Endpoint
+	{
+		deleteCategoryRuleEndpoint = MakeDeleteCategoryRuleEndpoint(s)
+		deleteCategoryRuleEndpoint = LoggingMiddleware(log.With(logger, "method", "DeleteCategoryRule"))(deleteCategoryRuleEndpoint)
+	}
+
+	var createStoreEndpoint endpoint.Endpoint
+	{
+		createStoreEndpoint = MakeCreateStoreEndpoint(s)
+		createStoreEndpoint = LoggingMiddleware(log.With(logger, "method", "CreateStore"))(createStoreEndpoint)
+	}
+
+	var getStoresEndpoint endpoint.Endpoint
+	{
+		getStoresEndpoint = MakeGetStoresEndpoint(s)
+		getStoresEndpoint = LoggingMiddleware(log.With(logger, "method", "GetStores"))(getStoresEndpoint)
+	}
+
+	var updateStoreEndpoint endpoint.Endpoint
+	{
+		updateStoreEndpoint = MakeUpdateStoreEndpoint(s)
+		updateStoreEndpoint = LoggingMiddleware(log.With(logger, "method", "UpdateStore"))(updateStoreEndpoint)
+	}
+
+	var deleteStoreEndpoint endpoint.Endpoint
+	{
+		deleteStoreEndpoint = MakeDeleteStoreEndpoint(s)
+		deleteStoreEndpoint = LoggingMiddleware(log.With(logger, "method", "DeleteStore"))(deleteStoreEndpoint)
+	}
+
+	var reorderItemsEndpoint endpoint.Endpoint
+	{
+		reorderItemsEndpoint = MakeReorderItemsEndpoint(s)
+		reorderItemsEndpoint = LoggingMiddleware(log.With(logger, "method", "ReorderItems"))(reorderItemsEndpoint)
+	}
+
+	var moveItemsEndpoint endpoint.Endpoint
+	{
+		moveItemsEndpoint = MakeMoveItemsEndpoint(s)
+		moveItemsEndpoint = LoggingMiddleware(log.With(logger, "method", "MoveItems"))(moveItemsEndpoint)
+	}
+
+	var copyItemsEndpoint endpoint.Endpoint
+	{
+		copyItemsEndpoint = MakeCopyItemsEndpoint(s)
+		copyItemsEndpoint = LoggingMiddleware(log.With(logger, "method", "CopyItems"))(copyItemsEndpoint)
+	}
+
+	var cloneListEndpoint endpoint.Endpoint
+	{
+		cloneListEndpoint = MakeCloneListEndpoint(s)
+		cloneListEndpoint = LoggingMiddleware(log.With(logger, "method", "CloneList"))(cloneListEndpoint)
+	}
+
+	var createTemplateEndpoint endpoint.Endpoint
+	{
+		createTemplateEndpoint = MakeCreateTemplateEndpoint(s)
+		createTemplateEndpoint = LoggingMiddleware(log.With(logger, "method", "CreateTemplate"))(createTemplateEndpoint)
+	}
+
+	var getTemplatesEndpoint endpoint.Endpoint
+	{
+		getTemplatesEndpoint = MakeGetTemplatesEndpoint(s)
+		getTemplatesEndpoint = LoggingMiddleware(log.With(logger, "method", "GetTemplates"))(getTemplatesEndpoint)
+	}
+
+	var instantiateTemplateEndpoint endpoint.Endpoint
+	{
+		instantiateTemplateEndpoint = MakeInstantiateTemplateEndpoint(s)
+		instantiateTemplateEndpoint = LoggingMiddleware(log.With(logger, "method", "InstantiateTemplate"))(instantiateTemplateEndpoint)
+	}
+
+	var createRecurrenceEndpoint endpoint.Endpoint
+	{
+		createRecurrenceEndpoint = MakeCreateRecurrenceEndpoint(s)
+		createRecurrenceEndpoint = LoggingMiddleware(log.With(logger, "method", "CreateRecurrence"))(createRecurrenceEndpoint)
+	}
+
+	var getRecurrencesEndpoint endpoint.Endpoint
+	{
+		getRecurrencesEndpoint = MakeGetRecurrencesEndpoint(s)
+		getRecurrencesEndpoint = LoggingMiddleware(log.With(logger, "method", "GetRecurrences"))(getRecurrencesEndpoint)
+	}
+
+	var pauseRecurrenceEndpoint endpoint.Endpoint
+	{
+		pauseRecurrenceEndpoint = MakePauseRecurrenceEndpoint(s)
+		pauseRecurrenceEndpoint = LoggingMiddleware(log.With(logger, "method", "PauseRecurrence"))(pauseRecurrenceEndpoint)
+	}
+
+	var resumeRecurrenceEndpoint endpoint.Endpoint
+	{
+		resumeRecurrenceEndpoint = MakeResumeRecurrenceEndpoint(s)
+		resumeRecurrenceEndpoint = LoggingMiddleware(log.With(logger, "method", "ResumeRecurrence"))(resumeRecurrenceEndpoint)
+	}
+
+	var deleteRecurrenceEndpoint endpoint.Endpoint
+	{
+		deleteRecurrenceEndpoint = MakeDeleteRecurrenceEndpoint(s)
+		deleteRecurrenceEndpoint = LoggingMiddleware(log.With(logger, "method", "DeleteRecurrence"))(deleteRecurrenceEndpoint)
+	}
+
+	var getRecurrenceInstancesEndpoint endpoint.Endpoint
+	{
+		getRecurrenceInstancesEndpoint = MakeGetRecurrenceInstancesEndpoint(s)
+		getRecurrenceInstancesEndpoint = LoggingMiddleware(log.With(logger, "method", "GetRecurrenceInstances"))(getRecurrenceInstancesEndpoint)
+	}
+
+	var getDueItemsEndpoint endpoint.Endpoint
+	{
+		getDueItemsEndpoint = MakeGetDueItemsEndpoint(s)
+		getDueItemsEndpoint = LoggingMiddleware(log.With(logger, "method", "GetDueItems"))(getDueItemsEndpoint)
+	}
+
+	var getReminderPreferencesEndpoint endpoint.Endpoint
+	{
+		getReminderPreferencesEndpoint = MakeGetReminderPreferencesEndpoint(s)
+		getReminderPreferencesEndpoint = LoggingMiddleware(log.With(logger, "method", "GetReminderPreferences"))(getReminderPreferencesEndpoint)
+	}
+
+	var updateReminderPreferencesEndpoint endpoint.Endpoint
+	{
+		updateReminderPreferencesEndpoint = MakeUpdateReminderPreferencesEndpoint(s)
+		updateReminderPreferencesEndpoint = LoggingMiddleware(log.With(logger, "method", "UpdateReminderPreferences"))(updateReminderPreferencesEndpoint)
+	}
+
 	return Endpoints{
-		Ping:             pingEndpoint,
-		Signup:           singupEndpoint,
-		Login:            loginEndpoint,
-		CreateList:       createListEndpoint,
-		GetLists:         getListsEndpoint,
-		CreateItem:       createItemEndpoint,
-		GetListItems:     getListItemsEndpoint,
-		BuyItem:          buyItemEndpoint,
-		ShareList:        shareListEndpoint,
-		Logout:           logoutEndpoint,
-		GetAllCategories: getAllCategoriesEndpoint,
-		DeleteList:       deleteListEndpoint,
-		DeleteItem:       deleteItemEndpoint,
+		Ping:                      pingEndpoint,
+		Signup:                    singupEndpoint,
+		Login:                     loginEndpoint,
+		CreateList:                createListEndpoint,
+		GetLists:                  getListsEndpoint,
+		UpdateList:                updateListEndpoint,
+		CreateItem:                createItemEndpoint,
+		UpdateItem:                updateItemEndpoint,
+		GetListItems:              getListItemsEndpoint,
+		BuyItem:                   buyItemEndpoint,
+		ShareList:                 shareListEndpoint,
+		Logout:                    logoutEndpoint,
+		GetAllCategories:          getAllCategoriesEndpoint,
+		DeleteList:                deleteListEndpoint,
+		DeleteItem:                deleteItemEndpoint,
+		UnbuyItem:                 unbuyItemEndpoint,
+		RestoreItem:               restoreItemEndpoint,
+		GetTrash:                  getTrashEndpoint,
+		RestoreList:               restoreListEndpoint,
+		GetContributors:           getContributorsEndpoint,
+		UpdateContributor:         updateContributorEndpoint,
+		RevokeContributor:         revokeContributorEndpoint,
+		CreateInvite:              createInviteEndpoint,
+		GetInvites:                getInvitesEndpoint,
+		CancelInvite:              cancelInviteEndpoint,
+		AcceptInvite:              acceptInviteEndpoint,
+		TransferOwnership:         transferOwnershipEndpoint,
+		CreateGroup:               createGroupEndpoint,
+		GetGroups:                 getGroupsEndpoint,
+		GetGroupMembers:           getGroupMembersEndpoint,
+		AddGroupMember:            addGroupMemberEndpoint,
+		RemoveGroupMember:         removeGroupMemberEndpoint,
+		ShareListWithGroup:        shareListWithGroupEndpoint,
+		UnshareListWithGroup:      unshareListWithGroupEndpoint,
+		CreatePublicLink:          createPublicLinkEndpoint,
+		GetPublicLinks:            getPublicLinksEndpoint,
+		RevokePublicLink:          revokePublicLinkEndpoint,
+		GetPublicList:             getPublicListEndpoint,
+		BuyPublicItem:             buyPublicItemEndpoint,
+		CreateCategory:            createCategoryEndpoint,
+		UpdateCategory:            updateCategoryEndpoint,
+		DeleteCategory:            deleteCategoryEndpoint,
+		MergeCategories:           mergeCategoriesEndpoint,
+		CreateCategoryRule:        createCategoryRuleEndpoint,
+		GetCategoryRules:          getCategoryRulesEndpoint,
+		DeleteCategoryRule:        deleteCategoryRuleEndpoint,
+		CreateStore:               createStoreEndpoint,
+		GetStores:                 getStoresEndpoint,
+		UpdateStore:               updateStoreEndpoint,
+		DeleteStore:               deleteStoreEndpoint,
+		ReorderItems:              reorderItemsEndpoint,
+		MoveItems:                 moveItemsEndpoint,
+		CopyItems:                 copyItemsEndpoint,
+		CloneList:                 cloneListEndpoint,
+		CreateTemplate:            createTemplateEndpoint,
+		GetTemplates:              getTemplatesEndpoint,
+		InstantiateTemplate:       instantiateTemplateEndpoint,
+		CreateRecurrence:          createRecurrenceEndpoint,
+		GetRecurrences:            getRecurrencesEndpoint,
+		PauseRecurrence:           pauseRecurrenceEndpoint,
+		ResumeRecurrence:          resumeRecurrenceEndpoint,
+		DeleteRecurrence:          deleteRecurrenceEndpoint,
+		GetRecurrenceInstances:    getRecurrenceInstancesEndpoint,
+		GetDueItems:               getDueItemsEndpoint,
+		GetReminderPreferences:    getReminderPreferencesEndpoint,
+		UpdateReminderPreferences: updateReminderPreferencesEndpoint,
 	}
 }
 
@@ -161,6 +585,13 @@ func MakeGetListsEndpoint(s service.Service) endpoint.Endpoint {
 	}
 }
 
+func MakeUpdateListEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.UpdateListRequest)
+		return s.UpdateList(ctx, req), nil
+	}
+}
+
 func MakeCreateItemEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(api.CreateItemRequest)
@@ -168,6 +599,13 @@ func MakeCreateItemEndpoint(s service.Service) endpoint.Endpoint {
 	}
 }
 
+func MakeUpdateItemEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.UpdateItemRequest)
+		return s.UpdateItem(ctx, req), nil
+	}
+}
+
 func MakeGetListItemsEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(api.GetListItemsRequest)
@@ -209,3 +647,360 @@ func MakeDeleteItemEndpoint(s service.Service) endpoint.Endpoint {
 		return s.DeleteItem(ctx, req), nil
 	}
 }
+
+func MakeUnbuyItemEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.UnbuyItemRequest)
+		return s.UnbuyItem(ctx, req), nil
+	}
+}
+
+func MakeRestoreItemEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.RestoreItemRequest)
+		return s.RestoreItem(ctx, req), nil
+	}
+}
+
+func MakeGetTrashEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.GetTrashRequest)
+		return s.GetTrash(ctx, req), nil
+	}
+}
+
+func MakeRestoreListEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.RestoreListRequest)
+		return s.RestoreList(ctx, req), nil
+	}
+}
+
+func MakeGetContributorsEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.GetContributorsRequest)
+		return s.GetContributors(ctx, req), nil
+	}
+}
+
+func MakeUpdateContributorEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.UpdateContributorRequest)
+		return s.UpdateContributor(ctx, req), nil
+	}
+}
+
+func MakeRevokeContributorEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.RevokeContributorRequest)
+		return s.RevokeContributor(ctx, req), nil
+	}
+}
+
+func MakeCreateInviteEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.CreateInviteRequest)
+		return s.CreateInvite(ctx, req), nil
+	}
+}
+
+func MakeGetInvitesEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.GetInvitesRequest)
+		return s.GetInvites(ctx, req), nil
+	}
+}
+
+func MakeCancelInviteEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.CancelInviteRequest)
+		return s.CancelInvite(ctx, req), nil
+	}
+}
+
+func MakeAcceptInviteEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.AcceptInviteRequest)
+		return s.AcceptInvite(ctx, req), nil
+	}
+}
+
+func MakeTransferOwnershipEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.TransferOwnershipRequest)
+		return s.TransferOwnership(ctx, req), nil
+	}
+}
+
+func MakeCreateGroupEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.CreateGroupRequest)
+		return s.CreateGroup(ctx, req), nil
+	}
+}
+
+func MakeGetGroupsEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.GetGroupsRequest)
+		return s.GetGroups(ctx, req), nil
+	}
+}
+
+func MakeGetGroupMembersEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.GetGroupMembersRequest)
+		return s.GetGroupMembers(ctx, req), nil
+	}
+}
+
+func MakeAddGroupMemberEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.AddGroupMemberRequest)
+		return s.AddGroupMember(ctx, req), nil
+	}
+}
+
+func MakeRemoveGroupMemberEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.RemoveGroupMemberRequest)
+		return s.RemoveGroupMember(ctx, req), nil
+	}
+}
+
+func MakeShareListWithGroupEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.ShareListWithGroupRequest)
+		return s.ShareListWithGroup(ctx, req), nil
+	}
+}
+
+func MakeUnshareListWithGroupEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.UnshareListWithGroupRequest)
+		return s.UnshareListWithGroup(ctx, req), nil
+	}
+}
+
+func MakeCreatePublicLinkEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.CreatePublicLinkRequest)
+		return s.CreatePublicLink(ctx, req), nil
+	}
+}
+
+func MakeGetPublicLinksEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.GetPublicLinksRequest)
+		return s.GetPublicLinks(ctx, req), nil
+	}
+}
+
+func MakeRevokePublicLinkEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.RevokePublicLinkRequest)
+		return s.RevokePublicLink(ctx, req), nil
+	}
+}
+
+func MakeGetPublicListEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.GetPublicListRequest)
+		return s.GetPublicList(ctx, req), nil
+	}
+}
+
+func MakeBuyPublicItemEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.BuyPublicItemRequest)
+		return s.BuyPublicItem(ctx, req), nil
+	}
+}
+
+func MakeCreateCategoryEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.CreateCategoryRequest)
+		return s.CreateCategory(ctx, req), nil
+	}
+}
+
+func MakeUpdateCategoryEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.UpdateCategoryRequest)
+		return s.UpdateCategory(ctx, req), nil
+	}
+}
+
+func MakeDeleteCategoryEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.DeleteCategoryRequest)
+		return s.DeleteCategory(ctx, req), nil
+	}
+}
+
+func MakeMergeCategoriesEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.MergeCategoriesRequest)
+		return s.MergeCategories(ctx, req), nil
+	}
+}
+
+func MakeCreateCategoryRuleEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.CreateCategoryRuleRequest)
+		return s.CreateCategoryRule(ctx, req), nil
+	}
+}
+
+func MakeGetCategoryRulesEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.GetCategoryRulesRequest)
+		return s.GetCategoryRules(ctx, req), nil
+	}
+}
+
+func MakeDeleteCategoryRuleEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.DeleteCategoryRuleRequest)
+		return s.DeleteCategoryRule(ctx, req), nil
+	}
+}
+
+func MakeCreateStoreEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.CreateStoreRequest)
+		return s.CreateStore(ctx, req), nil
+	}
+}
+
+func MakeGetStoresEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.GetStoresRequest)
+		return s.GetStores(ctx, req), nil
+	}
+}
+
+func MakeUpdateStoreEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.UpdateStoreRequest)
+		return s.UpdateStore(ctx, req), nil
+	}
+}
+
+func MakeDeleteStoreEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.DeleteStoreRequest)
+		return s.DeleteStore(ctx, req), nil
+	}
+}
+
+func MakeReorderItemsEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.ReorderItemsRequest)
+		return s.ReorderItems(ctx, req), nil
+	}
+}
+
+func MakeMoveItemsEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.MoveItemsRequest)
+		return s.MoveItems(ctx, req), nil
+	}
+}
+
+func MakeCopyItemsEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.CopyItemsRequest)
+		return s.CopyItems(ctx, req), nil
+	}
+}
+
+func MakeCloneListEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.CloneListRequest)
+		return s.CloneList(ctx, req), nil
+	}
+}
+
+func MakeCreateTemplateEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.CreateTemplateRequest)
+		return s.CreateTemplate(ctx, req), nil
+	}
+}
+
+func MakeGetTemplatesEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.GetTemplatesRequest)
+		return s.GetTemplates(ctx, req), nil
+	}
+}
+
+func MakeInstantiateTemplateEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.InstantiateTemplateRequest)
+		return s.InstantiateTemplate(ctx, req), nil
+	}
+}
+
+func MakeCreateRecurrenceEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.CreateRecurrenceRequest)
+		return s.CreateRecurrence(ctx, req), nil
+	}
+}
+
+func MakeGetRecurrencesEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.GetRecurrencesRequest)
+		return s.GetRecurrences(ctx, req), nil
+	}
+}
+
+func MakePauseRecurrenceEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.PauseRecurrenceRequest)
+		return s.PauseRecurrence(ctx, req), nil
+	}
+}
+
+func MakeResumeRecurrenceEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.ResumeRecurrenceRequest)
+		return s.ResumeRecurrence(ctx, req), nil
+	}
+}
+
+func MakeDeleteRecurrenceEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.DeleteRecurrenceRequest)
+		return s.DeleteRecurrence(ctx, req), nil
+	}
+}
+
+func MakeGetRecurrenceInstancesEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.GetRecurrenceInstancesRequest)
+		return s.GetRecurrenceInstances(ctx, req), nil
+	}
+}
+
+func MakeGetDueItemsEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.GetDueItemsRequest)
+		return s.GetDueItems(ctx, req), nil
+	}
+}
+
+func MakeGetReminderPreferencesEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.GetReminderPreferencesRequest)
+		return s.GetReminderPreferences(ctx, req), nil
+	}
+}
+
+func MakeUpdateReminderPreferencesEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(api.UpdateReminderPreferencesRequest)
+		return s.UpdateReminderPreferences(ctx, req), nil
+	}
+}
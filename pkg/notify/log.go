@@ -0,0 +1,25 @@
+package notify
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+)
+
+// Log is a Notifier that only writes messages to a logger, it stands in for a
+// channel that is not configured
+type Log struct {
+	logger log.Logger
+}
+
+// NewLog returns a Notifier writing messages to logger, a nil logger drops them
+func NewLog(logger log.Logger) *Log {
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+	return &Log{logger: log.With(logger, "notifier", "log")}
+}
+
+func (l *Log) Notify(ctx context.Context, msg Message) error {
+	l.logger.Log("to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
@@ -0,0 +1,25 @@
+package notify
+
+import (
+	"context"
+)
+
+// Message is a notification for a user, To is where the channel delivers it
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages to users over a channel such as email.
+// Implementations must be safe for concurrent use.
+type Notifier interface {
+	// Notify returns an error if msg could not be handed over for delivery
+	Notify(ctx context.Context, msg Message) error
+}
+
+// compile time assertions for our notifiers implementing Notifier.
+var (
+	_ Notifier = (*SMTP)(nil)
+	_ Notifier = (*Log)(nil)
+)
@@ -0,0 +1,114 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/pkg/errors"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// defaultSMTPTimeout bounds a whole delivery when the context sets no deadline,
+// so a mail server that stops answering can not hold up the caller for good
+const defaultSMTPTimeout = 30 * time.Second
+
+// SMTP is a Notifier sending messages as plain text email through a mail server
+type SMTP struct {
+	addr    string
+	from    string
+	auth    smtp.Auth
+	timeout time.Duration
+}
+
+// NewSMTP returns a Notifier sending email from address from through the
+// server at addr, given as host:port. The connection is upgraded to TLS when
+// the server offers it and auth, if not nil, is used when the server asks.
+func NewSMTP(addr string, from string, auth smtp.Auth) *SMTP {
+	return &SMTP{addr: addr, from: from, auth: auth, timeout: defaultSMTPTimeout}
+}
+
+// Notify delivers msg, giving up once ctx is done or, when ctx sets no
+// deadline, once the default timeout has passed
+func (s *SMTP) Notify(ctx context.Context, msg Message) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(s.timeout)
+	}
+	dialer := net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to connect to mail server %v", s.addr)
+	}
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		conn.Close()
+		return errors.Wrapf(err, "failed to set deadline on connection to mail server %v", s.addr)
+	}
+	host, _, err := net.SplitHostPort(s.addr)
+	if err != nil {
+		conn.Close()
+		return errors.Wrapf(err, "invalid mail server address %v", s.addr)
+	}
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return errors.Wrapf(err, "failed to greet mail server %v", s.addr)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		err = client.StartTLS(&tls.Config{ServerName: host})
+		if err != nil {
+			return errors.Wrap(err, "failed to start TLS with mail server")
+		}
+	}
+	if ok, _ := client.Extension("AUTH"); ok && s.auth != nil {
+		err = client.Auth(s.auth)
+		if err != nil {
+			return errors.Wrap(err, "failed to authenticate with mail server")
+		}
+	}
+	err = client.Mail(s.from)
+	if err != nil {
+		return errors.Wrapf(err, "mail server refused sender %v", s.from)
+	}
+	err = client.Rcpt(msg.To)
+	if err != nil {
+		return errors.Wrapf(err, "mail server refused recipient %v", msg.To)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return errors.Wrap(err, "failed to start message data")
+	}
+	_, err = w.Write(s.compose(msg))
+	if err != nil {
+		w.Close()
+		return errors.Wrap(err, "failed to write message")
+	}
+	err = w.Close()
+	if err != nil {
+		return errors.Wrap(err, "mail server did not accept message")
+	}
+	return client.Quit()
+}
+
+// compose returns msg as a plain text email
+func (s *SMTP) compose(msg Message) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", strings.NewReplacer("\r", "", "\n", " ").Replace(msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	// lines are ended with CRLF as the protocol requires
+	body := strings.Replace(msg.Body, "\r\n", "\n", -1)
+	b.WriteString(strings.Replace(body, "\n", "\r\n", -1))
+	b.WriteString("\r\n")
+	return b.Bytes()
+}
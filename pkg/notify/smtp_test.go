@@ -0,0 +1,110 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTP accepts one connection on a local port and plays a mail server
+// taking a single message, the commands and data it reads are sent on received
+func fakeSMTP(t *testing.T) (string, <-chan string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	received := make(chan string, 1)
+	go func() {
+		defer l.Close()
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var session strings.Builder
+		r := bufio.NewReader(conn)
+		fmt.Fprint(conn, "220 localhost ready\r\n")
+		inData := false
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				break
+			}
+			session.WriteString(line)
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					fmt.Fprint(conn, "250 queued\r\n")
+				}
+				continue
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				fmt.Fprint(conn, "250 localhost\r\n")
+			case cmd == "DATA":
+				inData = true
+				fmt.Fprint(conn, "354 go ahead\r\n")
+			case cmd == "QUIT":
+				fmt.Fprint(conn, "221 bye\r\n")
+				received <- session.String()
+				return
+			default:
+				fmt.Fprint(conn, "250 ok\r\n")
+			}
+		}
+		received <- session.String()
+	}()
+	return l.Addr().String(), received
+}
+
+func TestSMTPNotify(t *testing.T) {
+	addr, received := fakeSMTP(t)
+	s := NewSMTP(addr, "lists@example.com", nil)
+	err := s.Notify(context.Background(), Message{To: "bob@example.com", Subject: "Due soon", Body: "milk\nbread"})
+	if err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+	session := <-received
+	for _, want := range []string{
+		"MAIL FROM:<lists@example.com>",
+		"RCPT TO:<bob@example.com>",
+		"Subject: Due soon\r\n",
+		"milk\r\nbread\r\n",
+	} {
+		if !strings.Contains(session, want) {
+			t.Errorf("server did not receive %q in:\n%s", want, session)
+		}
+	}
+}
+
+func TestSMTPNotifyTimesOut(t *testing.T) {
+	// the server accepts the connection but never greets
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		time.Sleep(5 * time.Second)
+	}()
+
+	s := NewSMTP(l.Addr().String(), "lists@example.com", nil)
+	s.timeout = 100 * time.Millisecond
+	start := time.Now()
+	err = s.Notify(context.Background(), Message{To: "bob@example.com", Subject: "Due soon", Body: "milk"})
+	if err == nil {
+		t.Fatal("sending to a silent server succeeded")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("sending to a silent server took %v", elapsed)
+	}
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"shoppinglist/pkg/api"
+	"shoppinglist/pkg/store"
+	"strings"
+	"time"
+)
+
+// actions a user can take on a list, group, category, category rule, store or recurrence
+const (
+	actionViewList         = "view the list"
+	actionBuyItems         = "buy items of the list"
+	actionEditItems        = "edit items of the list"
+	actionEditList         = "edit the list"
+	actionDeleteList       = "delete the list"
+	actionShareList        = "share the list"
+	actionTransferList     = "transfer the list"
+	actionPublishList      = "publish the list"
+	actionViewGroup        = "view the group"
+	actionManageGroup      = "manage the group members"
+	actionUseCategory      = "use the category"
+	actionManageCategory   = "manage the category"
+	actionManageRule       = "manage the category rule"
+	actionManageStore      = "manage the store"
+	actionManageRecurrence = "manage the recurrence"
+)
+
+// requiredRoles holds the least privileged role allowed to take each action on a list
+var requiredRoles = map[string]string{
+	actionViewList:     api.RoleViewer,
+	actionBuyItems:     api.RoleShopper,
+	actionEditItems:    api.RoleEditor,
+	actionEditList:     api.RoleEditor,
+	actionDeleteList:   api.RoleManager,
+	actionShareList:    api.RoleManager,
+	actionTransferList: api.RoleOwner,
+	actionPublishList:  api.RoleOwner,
+}
+
+// getContributor returns the access user has on a list, an expired grant is
+// reported as not found. The access type is the strongest of the user's own
+// grant and the grants of the groups they are a member of, the returned
+// contributor has no ID when the access only comes through groups.
+func getContributor(ctx context.Context, repo store.Repository, listID int64, userID int64) (store.Contributor, error) {
+	contributor, err := repo.GetContributor(ctx, listID, userID)
+	if err == nil && contributor.Expired(time.Now()) {
+		err = errors.Wrapf(store.ErrNotFound, "access of user %v on list %v expired", userID, listID)
+	}
+	if err != nil && !store.IsNotFound(err) {
+		return contributor, err
+	}
+	groupAccess, groupErr := repo.GetGroupAccess(ctx, listID, userID)
+	if groupErr != nil {
+		if store.IsNotFound(groupErr) {
+			return contributor, err
+		}
+		return contributor, groupErr
+	}
+	if err != nil {
+		return store.Contributor{ListID: listID, UserID: userID, AccessType: api.NormaliseRole(groupAccess)}, nil
+	}
+	contributor.AccessType = api.StrongerAccess(contributor.AccessType, groupAccess)
+	return contributor, nil
+}
+
+// listRole returns the role user has on list, the owner of the list holds the
+// owner role and users the list is not shared with have no role
+func listRole(ctx context.Context, repo store.Repository, list api.List, userID int64) (string, error) {
+	if list.Owner.UserID == userID {
+		return api.RoleOwner, nil
+	}
+	contributor, err := getContributor(ctx, repo, list.ID, userID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "error checking list access for user")
+	}
+	return api.NormaliseRole(contributor.AccessType), nil
+}
+
+// getListWithRole returns the list with AccessType set to the role user has on it
+func getListWithRole(ctx context.Context, repo store.Repository, listID int64, userID int64) (api.List, error) {
+	list, err := repo.GetList(ctx, listID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return list, errors.New(fmt.Sprintf("list %v does not exist", listID))
+		}
+		return list, errors.Wrapf(err, "failed to read list details")
+	}
+	list.AccessType, err = listRole(ctx, repo, list, userID)
+	return list, err
+}
+
+// categoryRights reports whether user sees a category and whether they may
+// change it. Everyone sees the system categories and nobody changes them, the
+// categories of a group are seen by its members and changed by its admins.
+func categoryRights(ctx context.Context, repo store.Repository, category api.Category, userID int64) (bool, bool, error) {
+	switch {
+	case category.OwnerID != 0:
+		return category.OwnerID == userID, category.OwnerID == userID, nil
+	case category.GroupID != 0:
+		member, err := repo.GetGroupMember(ctx, category.GroupID, userID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return false, false, nil
+			}
+			return false, false, errors.Wrapf(err, "failed to check group membership")
+		}
+		return true, strings.Compare(member.Role, api.GroupAdminRole) == 0, nil
+	}
+	return true, false, nil
+}
+
+// authoriseGrant checks that a user with given role on a list may hand out
+// accessType, only the owner can grant or take away the manager role
+func authoriseGrant(role string, accessType string) error {
+	if api.RoleAllows(accessType, api.RoleManager) && !api.RoleAllows(role, api.RoleOwner) {
+		return errors.New("unauthorised access, only the owner of the list can manage its managers")
+	}
+	return nil
+}
+
+// Resource identifies what an action is taken on, an item stands for the list
+// it belongs to. MemberID names the group member an action is taken on.
+type Resource struct {
+	ListID       int64
+	ItemID       int64
+	GroupID      int64
+	MemberID     int64
+	CategoryID   int64
+	RuleID       int64
+	StoreID      int64
+	RecurrenceID int64
+}
+
+func (r Resource) String() string {
+	switch {
+	case r.RecurrenceID != 0:
+		return fmt.Sprintf("recurrence:%v", r.RecurrenceID)
+	case r.StoreID != 0:
+		return fmt.Sprintf("store:%v", r.StoreID)
+	case r.RuleID != 0:
+		return fmt.Sprintf("category rule:%v", r.RuleID)
+	case r.CategoryID != 0:
+		return fmt.Sprintf("category:%v", r.CategoryID)
+	case r.ItemID != 0:
+		return fmt.Sprintf("item:%v", r.ItemID)
+	case r.ListID != 0:
+		return fmt.Sprintf("list:%v", r.ListID)
+	case r.MemberID != 0:
+		return fmt.Sprintf("group:%v member:%v", r.GroupID, r.MemberID)
+	}
+	return fmt.Sprintf("group:%v", r.GroupID)
+}
+
+// Policy decides whether a user may take an action on a resource
+type Policy interface {
+	// Can returns an unauthorised access error if user may not take action on resource
+	Can(ctx context.Context, userID int64, action string, resource Resource) error
+}
+
+// NewPolicy returns the Policy granting actions by the role users have on a
+// list, by their membership of a group and by what they own
+func NewPolicy(repo store.Repository) Policy {
+	return rolePolicy{repo}
+}
+
+type rolePolicy struct {
+	repo store.Repository
+}
+
+func (p rolePolicy) Can(ctx context.Context, userID int64, action string, resource Resource) error {
+	allowed, err := p.allows(ctx, userID, action, resource)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errors.New(fmt.Sprintf("unauthorised access, user is not allowed to %v", action))
+	}
+	return nil
+}
+
+func (p rolePolicy) allows(ctx context.Context, userID int64, action string, resource Resource) (bool, error) {
+	switch action {
+	case actionManageRecurrence:
+		recurrence, err := p.repo.GetRecurrence(ctx, resource.RecurrenceID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return false, nil
+			}
+			return false, errors.Wrapf(err, "failed to read recurrence details")
+		}
+		return recurrence.OwnerID == userID, nil
+	case actionManageStore:
+		shop, err := p.repo.GetStore(ctx, resource.StoreID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return false, nil
+			}
+			return false, errors.Wrapf(err, "failed to read store details")
+		}
+		return shop.OwnerID == userID, nil
+	case actionManageRule:
+		rule, err := p.repo.GetCategoryRule(ctx, resource.RuleID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return false, nil
+			}
+			return false, errors.Wrapf(err, "failed to read category rule details")
+		}
+		return rule.UserID == userID, nil
+	case actionUseCategory, actionManageCategory:
+		category, err := p.repo.GetCategory(ctx, resource.CategoryID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return false, nil
+			}
+			return false, errors.Wrapf(err, "failed to read category details")
+		}
+		visible, manageable, err := categoryRights(ctx, p.repo, category, userID)
+		if action == actionUseCategory {
+			return visible, err
+		}
+		return manageable, err
+	case actionViewGroup, actionManageGroup:
+		member, err := p.repo.GetGroupMember(ctx, resource.GroupID, userID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return false, nil
+			}
+			return false, errors.Wrapf(err, "failed to check group membership")
+		}
+		// members can leave a group, only admins can manage the others
+		if action == actionViewGroup || resource.MemberID == userID {
+			return true, nil
+		}
+		return strings.Compare(member.Role, api.GroupAdminRole) == 0, nil
+	}
+
+	required, ok := requiredRoles[action]
+	if !ok {
+		return false, errors.New(fmt.Sprintf("unknown action %v", action))
+	}
+	listID := resource.ListID
+	if resource.ItemID != 0 {
+		item, err := p.repo.GetItem(ctx, resource.ItemID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return false, nil
+			}
+			return false, errors.Wrapf(err, "failed to read item details")
+		}
+		listID = item.ListID
+	}
+	list, err := p.repo.GetList(ctx, listID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to read list details")
+	}
+	role, err := listRole(ctx, p.repo, list, userID)
+	if err != nil {
+		return false, err
+	}
+	return api.RoleAllows(role, required), nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"github.com/go-kit/kit/log"
+	"shoppinglist/pkg/api"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEditorCanEditButNotDeleteList(t *testing.T) {
+	f := newFixture(t, "alice", "bob")
+	listID := f.createList(t, "alice")
+	if err := f.share(listID, "alice", "bob", api.RoleEditor, time.Time{}); err != nil {
+		t.Fatalf("failed to share with bob: %v", err)
+	}
+	s := New(f.repo, f.sessions, log.NewNopLogger(), &Config{}, &Info{})
+
+	name := "groceries"
+	resp := s.UpdateList(f.ctx, api.UpdateListRequest{UserID: f.users["bob"], ListID: listID, Name: &name})
+	if resp.Err != nil {
+		t.Fatalf("editor failed to rename the list: %v", resp.Err)
+	}
+	list, err := f.repo.GetList(f.ctx, listID)
+	if err != nil {
+		t.Fatalf("failed to read list: %v", err)
+	}
+	if list.Name != name {
+		t.Fatalf("list is named %q after rename", list.Name)
+	}
+
+	deleted := api.Deleted
+	resp = s.UpdateList(f.ctx, api.UpdateListRequest{UserID: f.users["bob"], ListID: listID, Status: &deleted})
+	if resp.Err == nil || !strings.Contains(resp.Err.Error(), "unauthorised access") {
+		t.Fatalf("editor moved the list to the trash, got error %v", resp.Err)
+	}
+}
+
+func TestOnlyOwnerCanDeleteCategoryRuleOrUseCategory(t *testing.T) {
+	f := newFixture(t, "alice", "bob")
+	s := New(f.repo, f.sessions, log.NewNopLogger(), &Config{}, &Info{})
+	category := s.CreateCategory(f.ctx, api.CreateCategoryRequest{UserID: f.users["alice"], Name: "Baking"})
+	if category.Err != nil {
+		t.Fatalf("failed to create category: %v", category.Err)
+	}
+	rule := s.CreateCategoryRule(f.ctx, api.CreateCategoryRuleRequest{UserID: f.users["alice"], Pattern: "flour", CategoryID: category.Category.ID})
+	if rule.Err != nil {
+		t.Fatalf("failed to create rule: %v", rule.Err)
+	}
+
+	deleted := s.DeleteCategoryRule(f.ctx, api.DeleteCategoryRuleRequest{UserID: f.users["bob"], RuleID: rule.Rule.ID})
+	if deleted.Err == nil || !strings.Contains(deleted.Err.Error(), "unauthorised access") {
+		t.Fatalf("bob deleted the rule of alice, got error %v", deleted.Err)
+	}
+	shop := s.CreateStore(f.ctx, api.CreateStoreRequest{UserID: f.users["bob"], Name: "corner", CategoryIDs: []int64{category.Category.ID}})
+	if shop.Err == nil || !strings.Contains(shop.Err.Error(), "unauthorised access") {
+		t.Fatalf("bob laid out a store with the category of alice, got error %v", shop.Err)
+	}
+
+	deleted = s.DeleteCategoryRule(f.ctx, api.DeleteCategoryRuleRequest{UserID: f.users["alice"], RuleID: rule.Rule.ID})
+	if deleted.Err != nil {
+		t.Fatalf("alice failed to delete the rule: %v", deleted.Err)
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"regexp"
+	"shoppinglist/pkg/api"
+	"shoppinglist/pkg/store"
+	"strings"
+	"time"
+)
+
+// defaultCategoryRules are the system rules, they apply when none of the rules
+// of a user match and name the system category they put items in
+var defaultCategoryRules = []api.CategoryRule{
+	systemRule("Dairy", "food", `\b(milk|cheese|butter|cream|yogh?urts?|eggs?)\b`),
+	systemRule("Fruit & Vegetables", "food", `\b(apples?|bananas?|oranges?|lemons?|grapes|berries|tomato(es)?|potato(es)?|onions?|garlic|carrots?|lettuce|salad|cucumbers?|peppers?)\b`),
+	systemRule("Bakery", "food", `\b(bread|baguettes?|rolls?|buns?|croissants?|bagels?|cakes?)\b`),
+	systemRule("Meat & Fish", "food", `\b(chicken|beef|pork|lamb|mince|ham|sausages?|bacon|fish|salmon|tuna|shrimps?)\b`),
+	systemRule("Pantry", "food", `\b(rice|pasta|spaghetti|noodles|flour|sugar|salt|oil|cereals?|beans|lentils)\b`),
+	systemRule("Drinks", "food", `\b(water|juice|soda|cola|beer|wine|coffee|tea)\b`),
+	systemRule("Household", "household", `\b(soap|shampoo|toothpaste|detergent|toilet paper|tissues|sponges?|bin bags)\b`),
+}
+
+// defaultCategoryPatterns are the compiled patterns of defaultCategoryRules, in the same order
+var defaultCategoryPatterns = compileDefaultCategoryRules(defaultCategoryRules)
+
+// compileDefaultCategoryRules compiles the patterns of the system rules once,
+// a system rule that does not compile is a programming error
+func compileDefaultCategoryRules(rules []api.CategoryRule) []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, len(rules))
+	for i, rule := range rules {
+		pattern, err := compileCategoryRule(rule.Pattern, rule.Regexp)
+		if err != nil {
+			panic(err)
+		}
+		patterns[i] = pattern
+	}
+	return patterns
+}
+
+// systemRule returns a system rule putting the items pattern matches in the named category
+func systemRule(name string, categoryType string, pattern string) api.CategoryRule {
+	return api.CategoryRule{Pattern: pattern, Regexp: true, Category: api.Category{Name: name, Type: categoryType}, System: true}
+}
+
+// compileCategoryRule returns the expression matching the titles a rule applies
+// to, titles are matched regardless of their case
+func compileCategoryRule(pattern string, isRegexp bool) (*regexp.Regexp, error) {
+	if !isRegexp {
+		pattern = `\b` + regexp.QuoteMeta(strings.TrimSpace(pattern)) + `\b`
+	}
+	compiled, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid rule pattern %v", pattern)
+	}
+	return compiled, nil
+}
+
+// choiceTitle returns the title a category choice is recorded under
+func choiceTitle(title string) string {
+	return strings.ToLower(strings.TrimSpace(title))
+}
+
+// categoriseItem picks the category for an item of user added without one. The
+// category the user picked for the same title before wins over the rules of the
+// user, which win over the system rules. It returns an empty category when
+// nothing applies.
+func categoriseItem(ctx context.Context, tx store.Repository, userID int64, title string) (api.Category, error) {
+	choice, err := tx.GetCategoryChoice(ctx, userID, choiceTitle(title))
+	if err != nil && !store.IsNotFound(err) {
+		return api.Category{}, errors.Wrapf(err, "failed to read earlier category choice")
+	}
+	if err == nil {
+		category, usable, err := usableCategory(ctx, tx, choice.CategoryID, userID)
+		if err != nil || usable {
+			return category, err
+		}
+	}
+
+	rules, err := tx.GetUserCategoryRules(ctx, userID)
+	if err != nil {
+		return api.Category{}, errors.Wrapf(err, "failed to read category rules of user")
+	}
+	for _, rule := range rules {
+		pattern, err := compileCategoryRule(rule.Pattern, rule.Regexp)
+		if err != nil || !pattern.MatchString(title) {
+			continue
+		}
+		category, usable, err := usableCategory(ctx, tx, rule.CategoryID, userID)
+		if err != nil || usable {
+			return category, err
+		}
+	}
+
+	for i, pattern := range defaultCategoryPatterns {
+		if pattern.MatchString(title) {
+			return systemCategory(ctx, tx, userID, defaultCategoryRules[i].Category)
+		}
+	}
+	return api.Category{}, nil
+}
+
+// findSystemCategory returns the system category with given name, names are
+// compared regardless of their case
+func findSystemCategory(ctx context.Context, repo store.Repository, userID int64, name string) (api.Category, bool, error) {
+	categories, err := repo.GetVisibleCategories(ctx, userID)
+	if err != nil {
+		return api.Category{}, false, errors.Wrapf(err, "failed to read categories of user")
+	}
+	for _, category := range categories {
+		if category.OwnerID == 0 && category.GroupID == 0 && strings.EqualFold(category.Name, name) {
+			return category, true, nil
+		}
+	}
+	return api.Category{}, false, nil
+}
+
+// systemCategory returns the stored system category of a system rule, it is
+// added to our DB when it was not seeded there
+func systemCategory(ctx context.Context, tx store.Repository, userID int64, category api.Category) (api.Category, error) {
+	stored, found, err := findSystemCategory(ctx, tx, userID, category.Name)
+	if err != nil || found {
+		return stored, err
+	}
+	category.OwnerID = 0
+	category.GroupID = 0
+	err = tx.CreateCategory(ctx, &category)
+	if err != nil {
+		return category, errors.Wrapf(err, "failed to add system category in DB")
+	}
+	return category, nil
+}
+
+// usableCategory returns the category with given id if user still sees it,
+// categories can go away or leave the user's reach after a rule was made
+func usableCategory(ctx context.Context, tx store.Repository, categoryID int64, userID int64) (api.Category, bool, error) {
+	category, err := tx.GetCategory(ctx, categoryID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return api.Category{}, false, nil
+		}
+		return api.Category{}, false, errors.Wrapf(err, "failed to read category details")
+	}
+	visible, _, err := categoryRights(ctx, tx, category, userID)
+	if err != nil || !visible {
+		return api.Category{}, false, err
+	}
+	return category, true, nil
+}
+
+// learnCategory records the category user picked for items of a title
+func learnCategory(ctx context.Context, tx store.Repository, userID int64, title string, categoryID int64) error {
+	if choiceTitle(title) == "" {
+		return nil
+	}
+	err := tx.SaveCategoryChoice(ctx, &store.CategoryChoice{
+		UserID:     userID,
+		Title:      choiceTitle(title),
+		CategoryID: categoryID,
+		ChosenAt:   time.Now(),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to record category choice")
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"shoppinglist/pkg/api"
+	"shoppinglist/pkg/store"
+	"testing"
+	"time"
+)
+
+func TestSystemRulesShareSystemCategories(t *testing.T) {
+	f := newFixture(t, "alice", "bob")
+	var categories []api.Category
+	for _, user := range []string{"alice", "bob"} {
+		itemID := f.createItem(t, f.createList(t, user), user, "Semi-skimmed milk")
+		item, err := f.repo.GetItem(f.ctx, itemID)
+		if err != nil {
+			t.Fatalf("failed to read item: %v", err)
+		}
+		categories = append(categories, item.Category)
+	}
+	for _, category := range categories {
+		if category.Name != "Dairy" || category.OwnerID != 0 || category.GroupID != 0 {
+			t.Fatalf("milk went in category %+v, expected the system Dairy", category)
+		}
+	}
+	if categories[0].ID != categories[1].ID {
+		t.Fatalf("alice and bob got their own Dairy categories %v and %v", categories[0].ID, categories[1].ID)
+	}
+}
+
+func TestCategoriseItem(t *testing.T) {
+	f := newFixture(t, "alice")
+	alice := f.users["alice"]
+	fridge := api.Category{Name: "Fridge", OwnerID: alice}
+	if err := f.repo.CreateCategory(f.ctx, &fridge); err != nil {
+		t.Fatalf("failed to add category: %v", err)
+	}
+	rule := store.CategoryRule{UserID: alice, Pattern: "cheese", CategoryID: fridge.ID, CreatedAt: time.Now()}
+	if err := f.repo.CreateCategoryRule(f.ctx, &rule); err != nil {
+		t.Fatalf("failed to add rule: %v", err)
+	}
+	if err := learnCategory(f.ctx, f.repo, alice, " Oat Milk", fridge.ID); err != nil {
+		t.Fatalf("failed to record choice: %v", err)
+	}
+
+	tests := []struct {
+		title    string
+		category string
+	}{
+		{"oat milk", "Fridge"},    // the earlier choice wins over the system rule
+		{"Goat cheese", "Fridge"}, // the rule of the user wins over the system rule
+		{"milk", "Dairy"},         // the system rule
+		{"Bread rolls", "Bakery"}, // the system rule
+		{"light bulbs", ""},       // nothing applies
+		{"cheesecake", ""},        // keywords match whole words
+	}
+	for _, test := range tests {
+		category, err := categoriseItem(f.ctx, f.repo, alice, test.title)
+		if err != nil {
+			t.Fatalf("failed to categorise %q: %v", test.title, err)
+		}
+		if category.Name != test.category {
+			t.Errorf("%q went in category %q, expected %q", test.title, category.Name, test.category)
+		}
+	}
+}
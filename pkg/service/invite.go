@@ -0,0 +1,63 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"github.com/pkg/errors"
+	"shoppinglist/pkg/api"
+	"shoppinglist/pkg/store"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultInviteTTL is how long an invitation can be accepted when no expiry is given
+const defaultInviteTTL = 7 * 24 * time.Hour
+
+// inviteToken returns the token handed out for an invite. It carries the invite
+// id and a signature over the invite, so a token can not be forged or reused
+// for a different list or access type.
+func inviteToken(secret []byte, invite store.Invite) string {
+	return fmt.Sprintf("%d.%s", invite.ID, inviteSignature(secret, invite))
+}
+
+func inviteSignature(secret []byte, invite store.Invite) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%d:%d:%s:%d", invite.ID, invite.ListID, invite.AccessType, invite.ExpiresAt.Unix())
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// inviteIDFromToken returns the id of the invite token was handed out for
+func inviteIDFromToken(token string) (int64, error) {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return 0, errors.New("malformed invitation token")
+	}
+	inviteID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, errors.New("malformed invitation token")
+	}
+	return inviteID, nil
+}
+
+// verifyInviteToken checks token was signed for invite
+func verifyInviteToken(secret []byte, invite store.Invite, token string) error {
+	if !hmac.Equal([]byte(token), []byte(inviteToken(secret, invite))) {
+		return errors.New("unauthorised access, invalid invitation token")
+	}
+	return nil
+}
+
+// toAPIInvite returns the client view of a stored invite
+func toAPIInvite(secret []byte, invite store.Invite) api.Invite {
+	return api.Invite{
+		ID:         invite.ID,
+		ListID:     invite.ListID,
+		AccessType: invite.AccessType,
+		CreatedAt:  invite.CreatedAt,
+		ExpiresAt:  invite.ExpiresAt,
+		Token:      inviteToken(secret, invite),
+	}
+}
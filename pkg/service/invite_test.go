@@ -0,0 +1,85 @@
+package service
+
+import (
+	"shoppinglist/pkg/api"
+	"shoppinglist/pkg/store"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInviteToken(t *testing.T) {
+	secret := []byte("secret")
+	invite := store.Invite{ID: 12, ListID: 3, AccessType: api.RoleEditor, ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second)}
+	token := inviteToken(secret, invite)
+
+	id, err := inviteIDFromToken(token)
+	if err != nil || id != invite.ID {
+		t.Fatalf("token carries invite %v, error %v", id, err)
+	}
+	if err := verifyInviteToken(secret, invite, token); err != nil {
+		t.Fatalf("token of the invite was rejected: %v", err)
+	}
+
+	// the token does not carry over to another list, role or expiry, nor verify with another secret
+	otherList, stronger, longer := invite, invite, invite
+	otherList.ListID = 4
+	stronger.AccessType = api.RoleManager
+	longer.ExpiresAt = longer.ExpiresAt.Add(time.Hour)
+	for _, other := range []store.Invite{otherList, stronger, longer} {
+		if err := verifyInviteToken(secret, other, token); err == nil {
+			t.Errorf("token verified for %+v", other)
+		}
+	}
+	if err := verifyInviteToken([]byte("other"), invite, token); err == nil {
+		t.Error("token verified with another secret")
+	}
+
+	for _, malformed := range []string{"", "12", "x.sig"} {
+		if _, err := inviteIDFromToken(malformed); err == nil {
+			t.Errorf("accepted malformed token %q", malformed)
+		}
+	}
+}
+
+func TestAcceptInviteOnlyOnce(t *testing.T) {
+	secret := []byte("secret")
+	f := newFixture(t, "alice", "bob", "carol")
+	listID := f.createList(t, "alice")
+	token := f.invite(t, secret, listID, "alice", api.RoleShopper)
+	accept := func(user string) error {
+		_, _, err := processAcceptInviteRequest(f.ctx, f.repo, f.sessions, secret, &api.AcceptInviteRequest{UserID: f.users[user], Token: token})
+		return err
+	}
+
+	if err := accept("bob"); err != nil {
+		t.Fatalf("failed to accept invitation: %v", err)
+	}
+	if c := f.access(t, listID, "bob"); c.AccessType != api.RoleShopper {
+		t.Fatalf("invitation gave %v access", c.AccessType)
+	}
+	if err := accept("carol"); err == nil || !strings.Contains(err.Error(), "already accepted") {
+		t.Fatalf("accepted a used invitation, error %v", err)
+	}
+}
+
+func TestOwnerCanNotUseUpInvite(t *testing.T) {
+	secret := []byte("secret")
+	f := newFixture(t, "alice", "bob")
+	listID := f.createList(t, "alice")
+	token := f.invite(t, secret, listID, "alice", api.RoleEditor)
+	accept := func(user string) error {
+		_, _, err := processAcceptInviteRequest(f.ctx, f.repo, f.sessions, secret, &api.AcceptInviteRequest{UserID: f.users[user], Token: token})
+		return err
+	}
+
+	if err := accept("alice"); err == nil || !strings.Contains(err.Error(), "list they own") {
+		t.Fatalf("owner accepted the invitation, error %v", err)
+	}
+	if err := accept("bob"); err != nil {
+		t.Fatalf("invitation was used up by the owner: %v", err)
+	}
+	if c := f.access(t, listID, "bob"); c.AccessType != api.RoleEditor {
+		t.Fatalf("invitation gave %v access", c.AccessType)
+	}
+}
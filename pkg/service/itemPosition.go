@@ -0,0 +1,121 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"shoppinglist/pkg/api"
+	"strings"
+)
+
+// positionGap is the room left between the positions of neighbouring items. An
+// item moved between two others takes the middle of their gap, so the items of
+// a list only need renumbering once a gap is used up.
+const positionGap = 1024
+
+// nextPosition returns the position after every one of items
+func nextPosition(items []api.Item) int64 {
+	var last int64
+	for _, item := range items {
+		if item.Position > last {
+			last = item.Position
+		}
+	}
+	return last + positionGap
+}
+
+// moveItem places item itemID of items, given by position, right before or
+// after item targetID and returns the items whose position changed
+func moveItem(items []api.Item, itemID int64, targetID int64, after bool) ([]api.Item, error) {
+	var (
+		moved  api.Item
+		others []api.Item
+	)
+	target := -1
+	for _, item := range items {
+		if item.ID == itemID {
+			moved = item
+			continue
+		}
+		if item.ID == targetID {
+			target = len(others)
+		}
+		others = append(others, item)
+	}
+	if moved.ID == 0 {
+		return nil, errors.New(fmt.Sprintf("item %v is not on the list", itemID))
+	}
+	if target < 0 {
+		return nil, errors.New(fmt.Sprintf("item %v is not on the list", targetID))
+	}
+
+	at := target
+	if after {
+		at++
+	}
+	var low, high int64
+	switch {
+	case at == 0:
+		high = others[0].Position
+		low = high - 2*positionGap
+	case at == len(others):
+		low = others[len(others)-1].Position
+		high = low + 2*positionGap
+	default:
+		low, high = others[at-1].Position, others[at].Position
+	}
+	if high-low >= 2 {
+		moved.Position = low + (high-low)/2
+		return []api.Item{moved}, nil
+	}
+
+	// no room left between the neighbours, space the whole list out again
+	ordered := make([]api.Item, 0, len(items))
+	ordered = append(ordered, others[:at]...)
+	ordered = append(ordered, moved)
+	ordered = append(ordered, others[at:]...)
+	return renumberItems(ordered), nil
+}
+
+// orderItems puts items, given by position, in the order of ids. The ids have
+// to name every item that is not deleted exactly once, deleted items keep their
+// place behind them. It returns the items whose position changed.
+func orderItems(items []api.Item, ids []int64) ([]api.Item, error) {
+	byID := make(map[int64]api.Item)
+	for _, item := range items {
+		if strings.Compare(item.Status, api.Deleted) != 0 {
+			byID[item.ID] = item
+		}
+	}
+	if len(ids) != len(byID) {
+		return nil, errors.New("the new order has to name every item of the list once")
+	}
+	ordered := make([]api.Item, 0, len(items))
+	for _, id := range ids {
+		item, ok := byID[id]
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("item %v is not on the list or named twice", id))
+		}
+		delete(byID, id)
+		ordered = append(ordered, item)
+	}
+	for _, item := range items {
+		if strings.Compare(item.Status, api.Deleted) == 0 {
+			ordered = append(ordered, item)
+		}
+	}
+	return renumberItems(ordered), nil
+}
+
+// renumberItems spaces the positions of ordered items out by positionGap and
+// returns the items whose position changed
+func renumberItems(ordered []api.Item) []api.Item {
+	var changed []api.Item
+	for i, item := range ordered {
+		position := int64(i+1) * positionGap
+		if item.Position != position {
+			item.Position = position
+			changed = append(changed, item)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"reflect"
+	"shoppinglist/pkg/api"
+	"sort"
+	"testing"
+)
+
+// positioned returns items with given ids at given positions
+func positioned(positions map[int64]int64) []api.Item {
+	var items []api.Item
+	for id, position := range positions {
+		items = append(items, api.Item{ID: id, Status: api.Todo, Position: position})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Position < items[j].Position })
+	return items
+}
+
+// applyPositions returns the ids of items by position once changed items took their new place
+func applyPositions(items []api.Item, changed []api.Item) []int64 {
+	byID := make(map[int64]api.Item)
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	for _, item := range changed {
+		byID[item.ID] = item
+	}
+	var all []api.Item
+	for _, item := range byID {
+		all = append(all, item)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Position < all[j].Position })
+	var ids []int64
+	for _, item := range all {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func TestMoveItem(t *testing.T) {
+	items := positioned(map[int64]int64{1: 1024, 2: 2048, 3: 3072})
+	tests := []struct {
+		name     string
+		itemID   int64
+		targetID int64
+		after    bool
+		order    []int64
+	}{
+		{"before the first", 3, 1, false, []int64{3, 1, 2}},
+		{"after the last", 1, 3, true, []int64{2, 3, 1}},
+		{"between two", 3, 2, false, []int64{1, 3, 2}},
+		{"after its neighbour", 1, 2, true, []int64{2, 1, 3}},
+	}
+	for _, tt := range tests {
+		changed, err := moveItem(items, tt.itemID, tt.targetID, tt.after)
+		if err != nil {
+			t.Fatalf("%v: %v", tt.name, err)
+		}
+		// a gap is left, only the moved item changes
+		if len(changed) != 1 || changed[0].ID != tt.itemID {
+			t.Errorf("%v: changed %+v", tt.name, changed)
+		}
+		if order := applyPositions(items, changed); !reflect.DeepEqual(order, tt.order) {
+			t.Errorf("%v: order is %v, expected %v", tt.name, order, tt.order)
+		}
+	}
+}
+
+func TestMoveItemRenumbersWhenGapIsUsedUp(t *testing.T) {
+	items := positioned(map[int64]int64{1: 10, 2: 11, 3: 12})
+	changed, err := moveItem(items, 3, 2, false)
+	if err != nil {
+		t.Fatalf("failed to move item: %v", err)
+	}
+	if order := applyPositions(items, changed); !reflect.DeepEqual(order, []int64{1, 3, 2}) {
+		t.Fatalf("order is %v", order)
+	}
+	for _, item := range changed {
+		if item.Position%positionGap != 0 {
+			t.Fatalf("item %v was not spaced out, position %v", item.ID, item.Position)
+		}
+	}
+}
+
+func TestMoveItemNotOnList(t *testing.T) {
+	items := positioned(map[int64]int64{1: 1024, 2: 2048})
+	if _, err := moveItem(items, 9, 1, false); err == nil {
+		t.Error("moved an item that is not on the list")
+	}
+	if _, err := moveItem(items, 1, 9, false); err == nil {
+		t.Error("moved an item next to one that is not on the list")
+	}
+}
+
+func TestOrderItems(t *testing.T) {
+	items := positioned(map[int64]int64{1: 1024, 2: 2048, 3: 3072, 4: 4096})
+	items[1].Status = api.Deleted
+
+	changed, err := orderItems(items, []int64{4, 1, 3})
+	if err != nil {
+		t.Fatalf("failed to order items: %v", err)
+	}
+	// the deleted item keeps its place behind the others
+	if order := applyPositions(items, changed); !reflect.DeepEqual(order, []int64{4, 1, 3, 2}) {
+		t.Fatalf("order is %v", order)
+	}
+
+	for name, ids := range map[string][]int64{
+		"missing an item":   {4, 1},
+		"naming one twice":  {4, 1, 1},
+		"naming a deleted":  {4, 1, 2},
+		"naming a stranger": {4, 1, 9},
+	} {
+		if _, err := orderItems(items, ids); err == nil {
+			t.Errorf("accepted an order %v", name)
+		}
+	}
+}
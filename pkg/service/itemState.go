@@ -0,0 +1,67 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"shoppinglist/pkg/api"
+	"time"
+)
+
+// transitions between item states
+const (
+	itemBuy     = "buy"
+	itemUnbuy   = "unbuy"
+	itemDelete  = "delete"
+	itemRestore = "restore"
+)
+
+// itemTransitions lists for every transition the states it is allowed from
+// and the state it leads to, restoring an item that was bought when deleted
+// leads back to bought
+var itemTransitions = map[string]struct {
+	from []string
+	to   string
+}{
+	itemBuy:     {from: []string{api.Todo}, to: api.Bought},
+	itemUnbuy:   {from: []string{api.Bought}, to: api.Todo},
+	itemDelete:  {from: []string{api.Todo, api.Bought}, to: api.Deleted},
+	itemRestore: {from: []string{api.Deleted}, to: api.Todo},
+}
+
+// applyItemTransition moves item to the state given transition leads to and
+// records userID as its last modifier. Moving back to todo clears the buyer
+// details, the buyer of a bought item is set by the caller. Deleting an item
+// records when it was moved to the trash and keeps the buyer details, so a
+// restored item returns to the state it was deleted in.
+func applyItemTransition(item *api.Item, transition string, userID int64) error {
+	t, ok := itemTransitions[transition]
+	if !ok {
+		return errors.New(fmt.Sprintf("unknown item transition %v", transition))
+	}
+	allowed := false
+	for _, from := range t.from {
+		if item.Status == from {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return errors.New(fmt.Sprintf("can not %v item in %v state", transition, item.Status))
+	}
+	to := t.to
+	if transition == itemRestore && !item.BoughtAt.IsZero() {
+		to = api.Bought
+	}
+	if to == api.Todo {
+		item.BoughtBy = api.User{}
+		item.BoughtAt = time.Time{}
+	}
+	item.DeletedAt = time.Time{}
+	if to == api.Deleted {
+		item.DeletedAt = time.Now()
+	}
+	item.Status = to
+	item.LastModifiedBy.UserID = userID
+	item.LastModifiedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"shoppinglist/pkg/api"
+	"testing"
+	"time"
+)
+
+func TestRestoreItemReturnsToDeletedState(t *testing.T) {
+	boughtAt := time.Now().Add(-time.Hour)
+	bought := api.Item{Status: api.Bought, BoughtBy: api.User{UserID: 2}, BoughtAt: boughtAt}
+	todo := api.Item{Status: api.Todo}
+	for _, item := range []*api.Item{&bought, &todo} {
+		for _, transition := range []string{itemDelete, itemRestore} {
+			err := applyItemTransition(item, transition, 3)
+			if err != nil {
+				t.Fatalf("failed to %v item: %v", transition, err)
+			}
+		}
+	}
+
+	if bought.Status != api.Bought || bought.BoughtBy.UserID != 2 || !bought.BoughtAt.Equal(boughtAt) {
+		t.Fatalf("restored bought item is %v, bought by %v at %v", bought.Status, bought.BoughtBy.UserID, bought.BoughtAt)
+	}
+	if !bought.DeletedAt.IsZero() {
+		t.Fatalf("restored item is still marked deleted at %v", bought.DeletedAt)
+	}
+	if todo.Status != api.Todo {
+		t.Fatalf("restored todo item is %v", todo.Status)
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"github.com/pkg/errors"
+	"shoppinglist/pkg/api"
+	"shoppinglist/pkg/store"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultPublicLinkTTL is how long a public link works when no expiry is given
+const defaultPublicLinkTTL = 7 * 24 * time.Hour
+
+// publicLinkToken returns the token handed out for a public link. Like an
+// invitation token it carries the link id and a signature over the link, the
+// signature is told apart from the one of an invitation with the same id.
+func publicLinkToken(secret []byte, link store.PublicLink) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "link:%d:%d:%s:%d", link.ID, link.ListID, link.AccessType, link.ExpiresAt.Unix())
+	return fmt.Sprintf("%d.%s", link.ID, base64.RawURLEncoding.EncodeToString(mac.Sum(nil)))
+}
+
+// getPublicLink returns the link token was handed out for if it still works
+func getPublicLink(ctx context.Context, repo store.Repository, secret []byte, token string) (store.PublicLink, error) {
+	invalid := errors.New("unauthorised access, invalid public link")
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return store.PublicLink{}, invalid
+	}
+	linkID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return store.PublicLink{}, invalid
+	}
+	link, err := repo.GetPublicLink(ctx, linkID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return link, invalid
+		}
+		return link, errors.Wrapf(err, "failed to read public link details")
+	}
+	if !hmac.Equal([]byte(token), []byte(publicLinkToken(secret, link))) {
+		return link, invalid
+	}
+	if !link.RevokedAt.IsZero() || !time.Now().Before(link.ExpiresAt) {
+		return link, errors.New("unauthorised access, public link has expired or was revoked")
+	}
+	return link, nil
+}
+
+// toAPIPublicLink returns the client view of a stored public link
+func toAPIPublicLink(secret []byte, link store.PublicLink) api.PublicLink {
+	return api.PublicLink{
+		ID:         link.ID,
+		ListID:     link.ListID,
+		AccessType: link.AccessType,
+		CreatedAt:  link.CreatedAt,
+		ExpiresAt:  link.ExpiresAt,
+		Token:      publicLinkToken(secret, link),
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+	"shoppinglist/pkg/api"
+	"shoppinglist/pkg/store"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPublicLinkToken(t *testing.T) {
+	secret := []byte("secret")
+	f := newFixture(t, "alice")
+	listID := f.createList(t, "alice")
+	newLink := func(expiresAt time.Time) store.PublicLink {
+		link := store.PublicLink{ListID: listID, AccessType: api.RoleViewer, CreatedBy: f.users["alice"], CreatedAt: time.Now(), ExpiresAt: expiresAt.Truncate(time.Second)}
+		if err := f.repo.CreatePublicLink(f.ctx, &link); err != nil {
+			t.Fatalf("failed to create public link: %v", err)
+		}
+		return link
+	}
+
+	link := newLink(time.Now().Add(time.Hour))
+	token := publicLinkToken(secret, link)
+	got, err := getPublicLink(f.ctx, f.repo, secret, token)
+	if err != nil || got.ID != link.ID {
+		t.Fatalf("token resolved to link %v, error %v", got.ID, err)
+	}
+
+	// a link token is not an invitation token for the same id, list and role
+	invite := store.Invite{ID: link.ID, ListID: link.ListID, AccessType: link.AccessType, ExpiresAt: link.ExpiresAt}
+	if inviteToken(secret, invite) == token {
+		t.Fatal("public link token doubles as an invitation token")
+	}
+
+	for name, forged := range map[string]string{
+		"other secret": publicLinkToken([]byte("other"), link),
+		"other id":     fmt.Sprintf("%d.%s", link.ID+1, strings.SplitN(token, ".", 2)[1]),
+		"malformed":    "link",
+	} {
+		if _, err := getPublicLink(f.ctx, f.repo, secret, forged); err == nil || !strings.Contains(err.Error(), "unauthorised access") {
+			t.Errorf("%v token was accepted, error %v", name, err)
+		}
+	}
+
+	expired := newLink(time.Now().Add(-time.Minute))
+	if _, err := getPublicLink(f.ctx, f.repo, secret, publicLinkToken(secret, expired)); err == nil {
+		t.Error("expired link still works")
+	}
+	link.RevokedAt = time.Now()
+	if err := f.repo.UpdatePublicLink(f.ctx, &link); err != nil {
+		t.Fatalf("failed to revoke link: %v", err)
+	}
+	if _, err := getPublicLink(f.ctx, f.repo, secret, token); err == nil {
+		t.Error("revoked link still works")
+	}
+}
+
+func TestBuyThroughPublicLinkLeavesBuyerUnknown(t *testing.T) {
+	secret := []byte("secret")
+	f := newFixture(t, "alice")
+	listID := f.createList(t, "alice")
+	itemID := f.createItem(t, listID, "alice", "milk")
+	link, _, err := processCreatePublicLinkRequest(f.ctx, f.repo, f.sessions, secret, &api.CreatePublicLinkRequest{UserID: f.users["alice"], ListID: listID, AccessType: api.RoleShopper})
+	if err != nil {
+		t.Fatalf("failed to create public link: %v", err)
+	}
+
+	err = processBuyPublicItemRequest(f.ctx, f.repo, secret, &api.BuyPublicItemRequest{Token: link.Token, ItemID: itemID})
+	if err != nil {
+		t.Fatalf("failed to buy item through public link: %v", err)
+	}
+	item, err := f.repo.GetItem(f.ctx, itemID)
+	if err != nil {
+		t.Fatalf("failed to read item: %v", err)
+	}
+	if item.Status != api.Bought || item.BoughtAt.IsZero() {
+		t.Fatalf("item is %v, bought at %v", item.Status, item.BoughtAt)
+	}
+	if item.BoughtBy.UserID != 0 {
+		t.Fatalf("item was recorded as bought by user %v", item.BoughtBy.UserID)
+	}
+	list, err := f.repo.GetList(f.ctx, listID)
+	if err != nil {
+		t.Fatalf("failed to read list: %v", err)
+	}
+	if !list.LastModifiedAt.Equal(item.LastModifiedAt) {
+		t.Fatalf("list was last modified at %v, item bought at %v", list.LastModifiedAt, item.LastModifiedAt)
+	}
+}
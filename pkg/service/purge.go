@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+	"shoppinglist/pkg/store"
+	"time"
+)
+
+// RunTrashPurge removes every interval the lists and items that have been in
+// the trash for longer than retention. It blocks until ctx is done.
+func RunTrashPurge(ctx context.Context, repo store.Repository, logger log.Logger, retention time.Duration, interval time.Duration) {
+	logger = log.With(logger, "method", "TrashPurge")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		lists, items, err := purgeTrash(ctx, repo, time.Now().Add(-retention))
+		if err != nil {
+			logger.Log("failed to purge trash with error :", err)
+		} else if lists > 0 || items > 0 {
+			logger.Log("purged lists :", lists, "purged items :", items)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeTrash removes the lists and items deleted before given time
+func purgeTrash(ctx context.Context, repo store.Repository, before time.Time) (lists int64, items int64, err error) {
+	err = repo.Tx(ctx, func(tx store.Repository) error {
+		lists, err = tx.PurgeDeletedLists(ctx, before)
+		if err != nil {
+			return errors.Wrapf(err, "failed to purge deleted lists")
+		}
+		items, err = tx.PurgeDeletedItems(ctx, before)
+		if err != nil {
+			return errors.Wrapf(err, "failed to purge deleted items")
+		}
+		return nil
+	})
+	return lists, items, err
+}
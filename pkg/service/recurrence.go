@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+	"shoppinglist/pkg/api"
+	"shoppinglist/pkg/store"
+	"strings"
+	"time"
+)
+
+// weekdays maps the weekdays a recurrence can run on to their number
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// scheduledOn reports whether a recurrence runs on the day of t
+func scheduledOn(recurrence api.Recurrence, t time.Time) bool {
+	if strings.Compare(recurrence.Frequency, api.Monthly) == 0 {
+		return t.Day() == recurrence.Day
+	}
+	return t.Weekday() == weekdays[recurrence.Weekday]
+}
+
+// firstRun returns the first midnight UTC from given time on which a recurrence runs
+func firstRun(recurrence api.Recurrence, from time.Time) time.Time {
+	from = from.UTC()
+	run := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	if run.Before(from) {
+		run = run.AddDate(0, 0, 1)
+	}
+	for !scheduledOn(recurrence, run) {
+		run = run.AddDate(0, 0, 1)
+	}
+	return run
+}
+
+// followingRun returns the run of a recurrence after the one at given time
+func followingRun(recurrence api.Recurrence, run time.Time) time.Time {
+	switch recurrence.Frequency {
+	case api.Biweekly:
+		return run.AddDate(0, 0, 14)
+	case api.Monthly:
+		return run.AddDate(0, 1, 0)
+	}
+	return run.AddDate(0, 0, 7)
+}
+
+// nextRunAfter returns the first run of a recurrence that is later than now,
+// the runs missed in between are skipped
+func nextRunAfter(recurrence api.Recurrence, now time.Time) time.Time {
+	run := recurrence.NextRunAt
+	for !run.After(now) {
+		run = followingRun(recurrence, run)
+	}
+	return run
+}
+
+// RunRecurrences starts every interval the lists of the recurrences that are
+// due. It blocks until ctx is done.
+func RunRecurrences(ctx context.Context, repo store.Repository, logger log.Logger, interval time.Duration) {
+	logger = log.With(logger, "method", "Recurrences")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		started, err := runDueRecurrences(ctx, repo, logger, time.Now())
+		if err != nil {
+			logger.Log("failed to run recurrences with error :", err)
+		} else if started > 0 {
+			logger.Log("started lists :", started)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDueRecurrences runs the recurrences due by now and returns the number of
+// lists started. A recurrence that fails is logged and retried next time.
+func runDueRecurrences(ctx context.Context, repo store.Repository, logger log.Logger, now time.Time) (int, error) {
+	due, err := repo.GetDueRecurrences(ctx, now)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to read due recurrences")
+	}
+	var started int
+	for _, recurrence := range due {
+		var ran bool
+		err = repo.Tx(ctx, func(tx store.Repository) error {
+			ran, err = runRecurrence(ctx, tx, recurrence.ID, now)
+			return err
+		})
+		if err != nil {
+			logger.Log("failed to run recurrence :", recurrence.ID, "error :", err)
+			continue
+		}
+		if ran {
+			started++
+		}
+	}
+	return started, nil
+}
+
+// runRecurrence starts the list of a recurrence that is due and schedules its
+// next run. The recurrence is paused instead once its owner can no longer view
+// the source list or the list was deleted. It reports whether a list was started.
+func runRecurrence(ctx context.Context, tx store.Repository, recurrenceID int64, now time.Time) (bool, error) {
+	recurrence, err := tx.GetRecurrence(ctx, recurrenceID)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to read recurrence details")
+	}
+	// it may have been paused or run since it was found due
+	if recurrence.Paused || recurrence.NextRunAt.After(now) {
+		return false, nil
+	}
+	source, err := getListWithRole(ctx, tx, recurrence.SourceListID, recurrence.OwnerID)
+	if err != nil {
+		return false, err
+	}
+	if !api.RoleAllows(source.AccessType, api.RoleViewer) || strings.Compare(source.Status, api.Deleted) == 0 {
+		recurrence.Paused = true
+		return false, tx.UpdateRecurrence(ctx, &recurrence)
+	}
+
+	name := recurrence.Name
+	if name == "" {
+		name = source.Name
+	}
+	name = fmt.Sprintf("%v %v", name, recurrence.NextRunAt.Format("2006-01-02"))
+	// each list is due by the time the next one is started
+	list, err := cloneList(ctx, tx, source, name, false, followingRun(recurrence, recurrence.NextRunAt), recurrence.OwnerID)
+	if err != nil {
+		return false, err
+	}
+	err = tx.AddRecurrenceInstance(ctx, &api.RecurrenceInstance{
+		RecurrenceID: recurrence.ID,
+		ListID:       list.ID,
+		ScheduledFor: recurrence.NextRunAt,
+		CreatedAt:    now,
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to record instance of recurrence:%v", recurrence.ID)
+	}
+	recurrence.NextRunAt = nextRunAfter(recurrence, now)
+	err = tx.UpdateRecurrence(ctx, &recurrence)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to schedule next run of recurrence:%v", recurrence.ID)
+	}
+	return true, nil
+}
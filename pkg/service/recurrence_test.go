@@ -0,0 +1,118 @@
+package service
+
+import (
+	"github.com/go-kit/kit/log"
+	"shoppinglist/pkg/api"
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestFirstRun(t *testing.T) {
+	monday := api.Recurrence{Frequency: api.Weekly, Weekday: "monday"}
+	fifteenth := api.Recurrence{Frequency: api.Monthly, Day: 15}
+	tests := []struct {
+		name       string
+		recurrence api.Recurrence
+		from       time.Time
+		run        time.Time
+	}{
+		// 2026-10-14 is a Wednesday
+		{"later in the week", monday, date(2026, 10, 14).Add(9 * time.Hour), date(2026, 10, 19)},
+		{"on the day at midnight", monday, date(2026, 10, 19), date(2026, 10, 19)},
+		{"on the day after midnight", monday, date(2026, 10, 19).Add(time.Minute), date(2026, 10, 26)},
+		{"later in the month", fifteenth, date(2026, 10, 20), date(2026, 11, 15)},
+	}
+	for _, tt := range tests {
+		if run := firstRun(tt.recurrence, tt.from); !run.Equal(tt.run) {
+			t.Errorf("%v: first run %v, expected %v", tt.name, run, tt.run)
+		}
+	}
+}
+
+func TestNextRunAfterSkipsMissedRuns(t *testing.T) {
+	tests := []struct {
+		frequency string
+		next      time.Time
+	}{
+		{api.Weekly, date(2026, 10, 26)},
+		{api.Biweekly, date(2026, 11, 2)},
+		{api.Monthly, date(2026, 11, 5)},
+	}
+	for _, tt := range tests {
+		recurrence := api.Recurrence{Frequency: tt.frequency, NextRunAt: date(2026, 10, 5)}
+		if next := nextRunAfter(recurrence, date(2026, 10, 20)); !next.Equal(tt.next) {
+			t.Errorf("%v: next run %v, expected %v", tt.frequency, next, tt.next)
+		}
+	}
+}
+
+func TestRunDueRecurrences(t *testing.T) {
+	f := newFixture(t, "alice")
+	listID := f.createList(t, "alice")
+	f.createItem(t, listID, "alice", "milk")
+	now := time.Now().UTC()
+	// due since midnight
+	recurrence := api.Recurrence{
+		OwnerID:      f.users["alice"],
+		SourceListID: listID,
+		Frequency:    api.Weekly,
+		Weekday:      strings.ToLower(now.Weekday().String()),
+		NextRunAt:    date(now.Year(), now.Month(), now.Day()),
+		CreatedAt:    now,
+	}
+	if err := f.repo.CreateRecurrence(f.ctx, &recurrence); err != nil {
+		t.Fatalf("failed to create recurrence: %v", err)
+	}
+	logger := log.NewNopLogger()
+
+	started, err := runDueRecurrences(f.ctx, f.repo, logger, now)
+	if err != nil || started != 1 {
+		t.Fatalf("started %v lists, error %v", started, err)
+	}
+	instances, err := f.repo.GetRecurrenceInstances(f.ctx, recurrence.ID)
+	if err != nil || len(instances) != 1 {
+		t.Fatalf("recorded %v instances, error %v", len(instances), err)
+	}
+	list, err := f.repo.GetList(f.ctx, instances[0].ListID)
+	if err != nil {
+		t.Fatalf("failed to read started list: %v", err)
+	}
+	scheduled := recurrence.NextRunAt
+	if !strings.HasPrefix(list.Name, "weekly ") || !list.Deadline.Equal(followingRun(recurrence, scheduled)) {
+		t.Fatalf("started list %q due %v", list.Name, list.Deadline)
+	}
+	items, err := f.repo.GetListItems(f.ctx, list.ID)
+	if err != nil || len(items) != 1 || items[0].Title != "milk" {
+		t.Fatalf("started list has items %+v, error %v", items, err)
+	}
+	stored, err := f.repo.GetRecurrence(f.ctx, recurrence.ID)
+	if err != nil || !stored.NextRunAt.After(now) {
+		t.Fatalf("next run is %v, error %v", stored.NextRunAt, err)
+	}
+
+	// nothing more is due until the next run
+	if started, err := runDueRecurrences(f.ctx, f.repo, logger, now); err != nil || started != 0 {
+		t.Fatalf("started %v more lists, error %v", started, err)
+	}
+
+	// once the source list is deleted the recurrence pauses
+	source, err := f.repo.GetList(f.ctx, listID)
+	if err != nil {
+		t.Fatalf("failed to read source list: %v", err)
+	}
+	source.Status = api.Deleted
+	if err := f.repo.UpdateList(f.ctx, &source); err != nil {
+		t.Fatalf("failed to delete source list: %v", err)
+	}
+	if started, err := runDueRecurrences(f.ctx, f.repo, logger, stored.NextRunAt); err != nil || started != 0 {
+		t.Fatalf("started %v lists from a deleted list, error %v", started, err)
+	}
+	if stored, err = f.repo.GetRecurrence(f.ctx, recurrence.ID); err != nil || !stored.Paused {
+		t.Fatalf("recurrence of a deleted list is not paused, error %v", err)
+	}
+}
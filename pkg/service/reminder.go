@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+	"shoppinglist/pkg/api"
+	"shoppinglist/pkg/notify"
+	"shoppinglist/pkg/store"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultLeadTime is how long before a deadline users are reminded unless they chose otherwise
+	defaultLeadTime = 24 * time.Hour
+	// maxLeadTime is the longest a user can be reminded ahead of a deadline
+	maxLeadTime = 7 * 24 * time.Hour
+	// maxQuietSpan is the longest quiet hours can hold a reminder back, what
+	// came due within it is still reminded of once the quiet hours end
+	maxQuietSpan = 24 * time.Hour
+	// clockLayout is how the quiet hours are given
+	clockLayout = "15:04"
+)
+
+// defaultReminderPreferences returns the preferences of a user who did not set any
+func defaultReminderPreferences(userID int64) store.ReminderPreferences {
+	return store.ReminderPreferences{
+		UserID:   userID,
+		LeadTime: defaultLeadTime,
+		TimeZone: "UTC",
+		Channel:  api.EmailChannel,
+	}
+}
+
+// reminderPreferences returns the preferences of user, the defaults if they did not set any
+func reminderPreferences(ctx context.Context, repo store.Repository, userID int64) (store.ReminderPreferences, error) {
+	preferences, err := repo.GetReminderPreferences(ctx, userID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return defaultReminderPreferences(userID), nil
+		}
+		return preferences, errors.Wrapf(err, "failed to read reminder preferences of user:%v", userID)
+	}
+	return preferences, nil
+}
+
+// formatLeadTime returns d in the short form it is usually given in, such as 48h or 90m
+func formatLeadTime(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return d.String()
+}
+
+// apiReminderPreferences returns preferences as the service hands them out
+func apiReminderPreferences(preferences store.ReminderPreferences) api.ReminderPreferences {
+	return api.ReminderPreferences{
+		LeadTime:   formatLeadTime(preferences.LeadTime),
+		QuietFrom:  preferences.QuietFrom,
+		QuietUntil: preferences.QuietUntil,
+		TimeZone:   preferences.TimeZone,
+		Channel:    preferences.Channel,
+	}
+}
+
+// minuteOfDay returns the minutes since midnight of a time given as 15:04
+func minuteOfDay(clock string) (int, error) {
+	t, err := time.Parse(clockLayout, clock)
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("invalid time of day %q, expected hours and minutes such as 22:00", clock))
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// quietAt reports whether t falls in the quiet hours of a user, quiet hours
+// ending before they start run over midnight
+func quietAt(preferences store.ReminderPreferences, t time.Time) bool {
+	if preferences.QuietFrom == "" || preferences.QuietUntil == "" {
+		return false
+	}
+	from, err := minuteOfDay(preferences.QuietFrom)
+	if err != nil {
+		return false
+	}
+	until, err := minuteOfDay(preferences.QuietUntil)
+	if err != nil {
+		return false
+	}
+	location, err := time.LoadLocation(preferences.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	if from <= until {
+		return from <= minute && minute < until
+	}
+	return minute >= from || minute < until
+}
+
+// dueEntry is a list, or one of its items when the item has an id, a user is
+// to be reminded of
+type dueEntry struct {
+	list api.List
+	item api.Item
+}
+
+func (e dueEntry) deadline() time.Time {
+	if e.item.ID != 0 {
+		return e.item.Deadline
+	}
+	return e.list.Deadline
+}
+
+// line describes the entry in a reminder sent at now, its deadline given in location
+func (e dueEntry) line(now time.Time, location *time.Location) string {
+	due := e.deadline().In(location).Format("Mon 2 Jan 15:04 MST")
+	tense := "is"
+	if e.deadline().Before(now) {
+		tense = "was"
+	}
+	if e.item.ID != 0 {
+		return fmt.Sprintf("- %q on list %q %v due %v", e.item.Title, e.list.Name, tense, due)
+	}
+	return fmt.Sprintf("- list %q %v due %v", e.list.Name, tense, due)
+}
+
+// reminderMessage returns the message reminding a user of entries at now
+func reminderMessage(to string, entries []dueEntry, preferences store.ReminderPreferences, now time.Time) notify.Message {
+	location, err := time.LoadLocation(preferences.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+	lines := []string{"Still to buy on your shopping lists:", ""}
+	for _, entry := range entries {
+		lines = append(lines, entry.line(now, location))
+	}
+	subject := "Shopping list reminder: 1 thing is due soon"
+	if len(entries) > 1 {
+		subject = fmt.Sprintf("Shopping list reminder: %v things are due soon", len(entries))
+	}
+	return notify.Message{To: to, Subject: subject, Body: strings.Join(lines, "\n")}
+}
+
+// reminderRecipients returns the users who can buy the items of a list
+func reminderRecipients(ctx context.Context, repo store.Repository, list api.List) ([]int64, error) {
+	candidates := map[int64]bool{list.Owner.UserID: true}
+	contributors, err := repo.GetListContributors(ctx, list.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read contributors of list:%v", list.ID)
+	}
+	for _, contributor := range contributors {
+		candidates[contributor.UserID] = true
+	}
+	listGroups, err := repo.GetListGroups(ctx, list.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read groups of list:%v", list.ID)
+	}
+	for _, listGroup := range listGroups {
+		members, err := repo.GetGroupMembers(ctx, listGroup.GroupID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read members of group:%v", listGroup.GroupID)
+		}
+		for _, member := range members {
+			candidates[member.UserID] = true
+		}
+	}
+
+	var users []int64
+	for userID := range candidates {
+		role, err := listRole(ctx, repo, list, userID)
+		if err != nil {
+			return nil, err
+		}
+		if api.RoleAllows(role, requiredRoles[actionBuyItems]) {
+			users = append(users, userID)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i] < users[j] })
+	return users, nil
+}
+
+// dueEntries returns the lists still to be bought and the items still to be
+// bought on them that are due within the longest lead time of now, by list.
+// Entries that came due within the longest quiet hours are included, so what
+// quiet hours held back is not missed when its deadline passes meanwhile.
+func dueEntries(ctx context.Context, repo store.Repository, now time.Time) ([][]dueEntry, error) {
+	from := now.Add(-maxQuietSpan)
+	lists, err := repo.GetListsDueBetween(ctx, from, now.Add(maxLeadTime))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read due lists")
+	}
+	items, err := repo.GetItemsDueBetween(ctx, from, now.Add(maxLeadTime))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read due items")
+	}
+
+	byList := make(map[int64][]dueEntry)
+	read := make(map[int64]api.List)
+	for _, list := range lists {
+		byList[list.ID] = append(byList[list.ID], dueEntry{list: list})
+		read[list.ID] = list
+	}
+	for _, item := range items {
+		list, ok := read[item.ListID]
+		if !ok {
+			list, err = repo.GetList(ctx, item.ListID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read list:%v", item.ListID)
+			}
+			read[list.ID] = list
+		}
+		// the items of a template or of a list that is bought or deleted are not due
+		if strings.Compare(list.Status, api.Todo) != 0 || list.Template {
+			continue
+		}
+		byList[list.ID] = append(byList[list.ID], dueEntry{list: list, item: item})
+	}
+
+	listIDs := make([]int64, 0, len(byList))
+	for id := range byList {
+		listIDs = append(listIDs, id)
+	}
+	sort.Slice(listIDs, func(i, j int) bool { return listIDs[i] < listIDs[j] })
+	due := make([][]dueEntry, 0, len(listIDs))
+	for _, id := range listIDs {
+		due = append(due, byList[id])
+	}
+	return due, nil
+}
+
+// RunReminders reminds users every interval of the lists and items that come
+// due within their lead time, over the channels of notifiers. It blocks until
+// ctx is done.
+func RunReminders(ctx context.Context, repo store.Repository, notifiers map[string]notify.Notifier, logger log.Logger, interval time.Duration) {
+	logger = log.With(logger, "method", "Reminders")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		sent, err := sendReminders(ctx, repo, notifiers, logger, time.Now())
+		if err != nil {
+			logger.Log("failed to send reminders with error :", err)
+		} else if sent > 0 {
+			logger.Log("sent reminders :", sent)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendReminders sends every user one message with what came due within their
+// lead time and they were not reminded of yet, and returns the number of
+// messages sent. Users in their quiet hours are reminded once these end, also
+// of what came due meanwhile, a message that fails is logged and retried next time.
+func sendReminders(ctx context.Context, repo store.Repository, notifiers map[string]notify.Notifier, logger log.Logger, now time.Time) (int, error) {
+	due, err := dueEntries(ctx, repo, now)
+	if err != nil {
+		return 0, err
+	}
+
+	preferences := make(map[int64]store.ReminderPreferences)
+	pending := make(map[int64][]dueEntry)
+	var users []int64
+	for _, entries := range due {
+		recipients, err := reminderRecipients(ctx, repo, entries[0].list)
+		if err != nil {
+			return 0, err
+		}
+		for _, userID := range recipients {
+			p, ok := preferences[userID]
+			if !ok {
+				p, err = reminderPreferences(ctx, repo, userID)
+				if err != nil {
+					return 0, err
+				}
+				preferences[userID] = p
+			}
+			if strings.Compare(p.Channel, api.NoChannel) == 0 {
+				continue
+			}
+			for _, entry := range entries {
+				if entry.deadline().Add(-p.LeadTime).After(now) {
+					continue
+				}
+				_, err = repo.GetReminder(ctx, userID, entry.list.ID, entry.item.ID, entry.deadline())
+				if err == nil {
+					continue
+				}
+				if !store.IsNotFound(err) {
+					return 0, errors.Wrapf(err, "failed to check reminders sent to user:%v", userID)
+				}
+				if _, ok := pending[userID]; !ok {
+					users = append(users, userID)
+				}
+				pending[userID] = append(pending[userID], entry)
+			}
+		}
+	}
+
+	var sent int
+	for _, userID := range users {
+		p := preferences[userID]
+		if quietAt(p, now) {
+			continue
+		}
+		notifier, ok := notifiers[p.Channel]
+		if !ok {
+			logger.Log("no notifier for channel :", p.Channel, "user :", userID)
+			continue
+		}
+		user, err := repo.GetUserByID(ctx, userID)
+		if err != nil {
+			return sent, errors.Wrapf(err, "failed to read user:%v", userID)
+		}
+		to := user.Email
+		if strings.Compare(p.Channel, api.EmailChannel) == 0 && to == "" {
+			// nowhere to send the reminder to
+			continue
+		}
+		err = notifier.Notify(ctx, reminderMessage(to, pending[userID], p, now))
+		if err != nil {
+			logger.Log("failed to remind user :", userID, "error :", err)
+			continue
+		}
+		sent++
+		for _, entry := range pending[userID] {
+			err = repo.AddReminder(ctx, &store.Reminder{
+				UserID:   userID,
+				ListID:   entry.list.ID,
+				ItemID:   entry.item.ID,
+				Deadline: entry.deadline(),
+				SentAt:   now,
+			})
+			if err != nil {
+				return sent, errors.Wrapf(err, "failed to record reminder of user:%v", userID)
+			}
+		}
+	}
+	return sent, nil
+}
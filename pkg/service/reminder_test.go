@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"shoppinglist/pkg/api"
+	"shoppinglist/pkg/notify"
+	"shoppinglist/pkg/store"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recorder is a Notifier keeping the messages it is given
+type recorder struct {
+	messages []notify.Message
+}
+
+func (r *recorder) Notify(ctx context.Context, msg notify.Message) error {
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+// dueList returns a repository holding a list of a single user due at deadline,
+// the user's reminder preferences are those given
+func dueList(t *testing.T, deadline time.Time, preferences store.ReminderPreferences) store.Repository {
+	ctx := context.Background()
+	repo := store.NewMemory()
+	user := store.User{UserName: "alice", Email: "alice@example.com", Password: "pw", Status: "active"}
+	if err := repo.CreateUser(ctx, &user); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+	list := api.List{Name: "party", Owner: api.User{UserID: user.ID}, Status: api.Todo, Deadline: deadline}
+	if err := repo.CreateList(ctx, &list); err != nil {
+		t.Fatalf("failed to add list: %v", err)
+	}
+	preferences.UserID = user.ID
+	if err := repo.SaveReminderPreferences(ctx, &preferences); err != nil {
+		t.Fatalf("failed to save reminder preferences: %v", err)
+	}
+	return repo
+}
+
+// sender returns a function sending the reminders due at a time, and the recorder they go to
+func sender(t *testing.T, repo store.Repository) (func(now time.Time) int, *recorder) {
+	r := &recorder{}
+	notifiers := map[string]notify.Notifier{api.EmailChannel: r}
+	return func(now time.Time) int {
+		sent, err := sendReminders(context.Background(), repo, notifiers, log.NewNopLogger(), now)
+		if err != nil {
+			t.Fatalf("failed to send reminders: %v", err)
+		}
+		return sent
+	}, r
+}
+
+func TestQuietAt(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		from, until, zone string
+		at                time.Duration
+		quiet             bool
+	}{
+		{"22:00", "07:00", "UTC", 23 * time.Hour, true},
+		{"22:00", "07:00", "UTC", 6*time.Hour + 59*time.Minute, true},
+		{"22:00", "07:00", "UTC", 7 * time.Hour, false},
+		{"13:00", "14:00", "UTC", 13*time.Hour + 30*time.Minute, true},
+		{"13:00", "14:00", "UTC", 12 * time.Hour, false},
+		{"", "07:00", "UTC", 3 * time.Hour, false},
+	}
+	for _, tt := range tests {
+		preferences := store.ReminderPreferences{QuietFrom: tt.from, QuietUntil: tt.until, TimeZone: tt.zone}
+		at := day.Add(tt.at)
+		if quiet := quietAt(preferences, at); quiet != tt.quiet {
+			t.Errorf("%v-%v in %v at %v: expected quiet %v", tt.from, tt.until, tt.zone, at.Format(clockLayout), tt.quiet)
+		}
+	}
+}
+
+func TestRemindersSentOnceWithinLeadTime(t *testing.T) {
+	deadline := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	preferences := defaultReminderPreferences(0)
+	preferences.LeadTime = 2 * time.Hour
+	send, r := sender(t, dueList(t, deadline, preferences))
+
+	if sent := send(deadline.Add(-3 * time.Hour)); sent != 0 {
+		t.Fatalf("sent %v reminders before the lead time", sent)
+	}
+	if sent := send(deadline.Add(-time.Hour)); sent != 1 {
+		t.Fatalf("sent %v reminders within the lead time, expected 1", sent)
+	}
+	if msg := r.messages[0]; msg.To != "alice@example.com" || !strings.Contains(msg.Body, `list "party" is due`) {
+		t.Fatalf("reminder is %+v", msg)
+	}
+	if sent := send(deadline.Add(-30 * time.Minute)); sent != 0 {
+		t.Fatalf("reminded again of the same deadline")
+	}
+}
+
+func TestRemindersHeldBackByQuietHoursAreSentOverdue(t *testing.T) {
+	// the reminder is due an hour before the deadline, in the middle of the
+	// quiet hours that end after the deadline passed
+	deadline := time.Now().Add(2 * time.Hour).Truncate(time.Minute)
+	remindAt := deadline.Add(-time.Hour)
+	preferences := defaultReminderPreferences(0)
+	preferences.LeadTime = time.Hour
+	preferences.QuietFrom = remindAt.Add(-time.Hour).UTC().Format(clockLayout)
+	preferences.QuietUntil = remindAt.Add(150 * time.Minute).UTC().Format(clockLayout)
+	send, r := sender(t, dueList(t, deadline, preferences))
+
+	if sent := send(remindAt); sent != 0 {
+		t.Fatalf("sent %v reminders during quiet hours", sent)
+	}
+	if sent := send(deadline.Add(2 * time.Hour)); sent != 1 {
+		t.Fatalf("sent %v reminders once quiet hours ended, expected 1", sent)
+	}
+	if body := r.messages[0].Body; !strings.Contains(body, `list "party" was due`) {
+		t.Fatalf("reminder does not tell the list is overdue:\n%v", body)
+	}
+	if sent := send(deadline.Add(3 * time.Hour)); sent != 0 {
+		t.Fatalf("reminded again of the same deadline")
+	}
+}
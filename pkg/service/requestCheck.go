@@ -1,6 +1,12 @@
 package service
 
-import "shoppinglist/pkg/api"
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"shoppinglist/pkg/api"
+	"strings"
+	"time"
+)
 
 func validateSignupRequest(req *api.SignupRequest) error {
 	return nil
@@ -11,6 +17,9 @@ func validateLoginRequest(req *api.LoginRequest) error {
 }
 
 func validateCreateListRequest(req *api.CreateListRequest) error {
+	if !req.List.Deadline.IsZero() && req.List.Deadline.Before(time.Now()) {
+		return errors.New("list deadline can not be in the past")
+	}
 	return nil
 }
 
@@ -18,18 +27,419 @@ func validateGetListsRequest(req *api.GetListsRequest) error {
 	return nil
 }
 
+func validateUpdateListRequest(req *api.UpdateListRequest) error {
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return errors.New("list name can not be empty")
+		}
+		req.Name = &name
+	}
+	if req.Status != nil {
+		switch *req.Status {
+		case api.Todo, api.Bought, api.Deleted:
+		default:
+			return errors.New(fmt.Sprintf("invalid list status %v", *req.Status))
+		}
+	}
+	// every list is due at some time, the deadline can be moved but not cleared
+	if req.Deadline != nil && req.Deadline.IsZero() {
+		return errors.New("list deadline can not be cleared")
+	}
+	if req.Deadline != nil && req.Deadline.Before(time.Now()) {
+		return errors.New("list deadline can not be in the past")
+	}
+	return nil
+}
+
 func validateCreateItemRequest(req *api.CreateItemRequest) error {
+	if req.Item.Quantity < 0 {
+		return errors.New("item quantity can not be negative")
+	}
+	if !req.Item.Deadline.IsZero() && req.Item.Deadline.Before(time.Now()) {
+		return errors.New("item deadline can not be in the past")
+	}
+	if req.Item.Quantity == 0 && req.Item.Unit == "" {
+		// quantity not given
+		return nil
+	}
+	unit, err := normaliseUnit(req.Item.Unit)
+	if err != nil {
+		return err
+	}
+	req.Item.Unit = unit
+	if req.Item.Quantity == 0 {
+		req.Item.Quantity = 1
+	}
 	return nil
 }
 
-func validateGetListItemsRequest(req *api.GetListItemsRequest) error {
+func validateUpdateItemRequest(req *api.UpdateItemRequest) error {
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			return errors.New("item title can not be empty")
+		}
+		req.Title = &title
+	}
+	if req.Category != nil && req.Category.ID == 0 && strings.TrimSpace(req.Category.Name) == "" {
+		return errors.New("new category needs a name")
+	}
+	if req.Quantity != nil && *req.Quantity < 0 {
+		return errors.New("item quantity can not be negative")
+	}
+	if req.Unit != nil {
+		unit, err := normaliseUnit(*req.Unit)
+		if err != nil {
+			return err
+		}
+		req.Unit = &unit
+	}
+	// every item is due at some time, the deadline can be moved but not cleared
+	if req.Deadline != nil && req.Deadline.IsZero() {
+		return errors.New("item deadline can not be cleared")
+	}
+	if req.Deadline != nil && req.Deadline.Before(time.Now()) {
+		return errors.New("item deadline can not be in the past")
+	}
 	return nil
 }
 
+func validateGetListItemsRequest(req *api.GetListItemsRequest) error {
+	switch req.View {
+	case "", api.ListView, api.ShoppingView:
+		return nil
+	}
+	return errors.New(fmt.Sprintf("invalid view %v", req.View))
+}
+
 func validateBuyItemRequest(req *api.BuyItemRequest) error {
 	return nil
 }
 
 func validateShareListRequest(req *api.ShareListRequest) error {
+	if strings.TrimSpace(req.UserName) == "" {
+		return errors.New("user to share the list with is not given")
+	}
+	if !req.ValidUntil.IsZero() && req.ValidUntil.Before(time.Now()) {
+		return errors.New("list can not be shared with an expiry in the past")
+	}
+	return validateAccessType(&req.AccessType)
+}
+
+func validateUpdateContributorRequest(req *api.UpdateContributorRequest) error {
+	return validateAccessType(&req.AccessType)
+}
+
+func validateCreateInviteRequest(req *api.CreateInviteRequest) error {
+	if !req.ExpiresAt.IsZero() && req.ExpiresAt.Before(time.Now()) {
+		return errors.New("invitation can not expire in the past")
+	}
+	return validateAccessType(&req.AccessType)
+}
+
+func validateAcceptInviteRequest(req *api.AcceptInviteRequest) error {
+	if strings.TrimSpace(req.Token) == "" {
+		return errors.New("invitation token is not given")
+	}
+	return nil
+}
+
+func validateTransferOwnershipRequest(req *api.TransferOwnershipRequest) error {
+	if strings.TrimSpace(req.UserName) == "" {
+		return errors.New("new owner of the list is not given")
+	}
+	return nil
+}
+
+func validateCreateGroupRequest(req *api.CreateGroupRequest) error {
+	if strings.TrimSpace(req.Name) == "" {
+		return errors.New("group name can not be empty")
+	}
+	return nil
+}
+
+func validateAddGroupMemberRequest(req *api.AddGroupMemberRequest) error {
+	if strings.TrimSpace(req.UserName) == "" {
+		return errors.New("user to add to the group is not given")
+	}
+	if req.Role == "" {
+		req.Role = api.GroupMemberRole
+	}
+	switch req.Role {
+	case api.GroupAdminRole, api.GroupMemberRole:
+		return nil
+	}
+	return errors.New(fmt.Sprintf("invalid group role %v", req.Role))
+}
+
+func validateShareListWithGroupRequest(req *api.ShareListWithGroupRequest) error {
+	return validateAccessType(&req.AccessType)
+}
+
+func validateCreatePublicLinkRequest(req *api.CreatePublicLinkRequest) error {
+	if !req.ExpiresAt.IsZero() && req.ExpiresAt.Before(time.Now()) {
+		return errors.New("public link can not expire in the past")
+	}
+	if req.AccessType == "" {
+		req.AccessType = api.RoleViewer
+	}
+	// people without an account can at most buy items
+	req.AccessType = api.NormaliseRole(req.AccessType)
+	switch req.AccessType {
+	case api.RoleViewer, api.RoleShopper:
+		return nil
+	}
+	return errors.New(fmt.Sprintf("invalid access type %v for a public link", req.AccessType))
+}
+
+func validateBuyPublicItemRequest(req *api.BuyPublicItemRequest) error {
+	if strings.TrimSpace(req.Token) == "" {
+		return errors.New("public link token is not given")
+	}
+	return nil
+}
+
+func validateCreateCategoryRequest(req *api.CreateCategoryRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return errors.New("category name can not be empty")
+	}
+	return nil
+}
+
+func validateUpdateCategoryRequest(req *api.UpdateCategoryRequest) error {
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return errors.New("category name can not be empty")
+		}
+		req.Name = &name
+	}
+	return nil
+}
+
+func validateMergeCategoriesRequest(req *api.MergeCategoriesRequest) error {
+	if len(req.DuplicateIDs) == 0 {
+		return errors.New("categories to merge are not given")
+	}
+	for _, id := range req.DuplicateIDs {
+		if id == req.CategoryID {
+			return errors.New("a category can not be merged into itself")
+		}
+	}
 	return nil
 }
+
+func validateCreateCategoryRuleRequest(req *api.CreateCategoryRuleRequest) error {
+	req.Pattern = strings.TrimSpace(req.Pattern)
+	if req.Pattern == "" {
+		return errors.New("rule pattern can not be empty")
+	}
+	if req.CategoryID == 0 {
+		return errors.New("category of the rule is not given")
+	}
+	_, err := compileCategoryRule(req.Pattern, req.Regexp)
+	return err
+}
+
+func validateReorderItemsRequest(req *api.ReorderItemsRequest) error {
+	if len(req.Order) != 0 {
+		if req.ItemID != 0 {
+			return errors.New("give either a new order or an item to move")
+		}
+		return nil
+	}
+	if req.ItemID == 0 {
+		return errors.New("neither a new order nor an item to move is given")
+	}
+	if (req.BeforeID == 0) == (req.AfterID == 0) {
+		return errors.New("give exactly one item to move the item before or after")
+	}
+	if req.BeforeID == req.ItemID || req.AfterID == req.ItemID {
+		return errors.New("an item can not be moved next to itself")
+	}
+	return nil
+}
+
+func validateMoveItemsRequest(req *api.MoveItemsRequest) error {
+	return validateItemTransfer(req.ListID, req.ToListID, req.ItemIDs)
+}
+
+func validateCopyItemsRequest(req *api.CopyItemsRequest) error {
+	return validateItemTransfer(req.ListID, req.ToListID, req.ItemIDs)
+}
+
+// validateItemTransfer checks the items moved or copied from list fromID to list toID
+func validateItemTransfer(fromID int64, toID int64, itemIDs []int64) error {
+	if toID == 0 {
+		return errors.New("list to move the items to is not given")
+	}
+	if toID == fromID {
+		return errors.New("items can not be moved to the list they are on")
+	}
+	if len(itemIDs) == 0 {
+		return errors.New("items to move are not given")
+	}
+	seen := make(map[int64]bool)
+	for _, id := range itemIDs {
+		if seen[id] {
+			return errors.New(fmt.Sprintf("item %v is given more than once", id))
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+func validateCloneListRequest(req *api.CloneListRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	return nil
+}
+
+func validateCreateTemplateRequest(req *api.CreateTemplateRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return errors.New("template name can not be empty")
+	}
+	return nil
+}
+
+func validateInstantiateTemplateRequest(req *api.InstantiateTemplateRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	return nil
+}
+
+func validateCreateRecurrenceRequest(req *api.CreateRecurrenceRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Frequency = strings.ToLower(strings.TrimSpace(req.Frequency))
+	req.Weekday = strings.ToLower(strings.TrimSpace(req.Weekday))
+	if req.ListID == 0 {
+		return errors.New("list of the recurrence is required")
+	}
+	switch req.Frequency {
+	case api.Weekly, api.Biweekly:
+		if _, ok := weekdays[req.Weekday]; !ok {
+			return errors.New(fmt.Sprintf("%v recurrences need a weekday, got %q", req.Frequency, req.Weekday))
+		}
+		if req.Day != 0 {
+			return errors.New(fmt.Sprintf("%v recurrences run on a weekday, not a day of the month", req.Frequency))
+		}
+	case api.Monthly:
+		if req.Day < 1 || req.Day > 28 {
+			return errors.New("monthly recurrences need a day of the month from 1 to 28")
+		}
+		if req.Weekday != "" {
+			return errors.New("monthly recurrences run on a day of the month, not a weekday")
+		}
+	default:
+		return errors.New(fmt.Sprintf("unknown frequency %q, expected %v, %v or %v", req.Frequency, api.Weekly, api.Biweekly, api.Monthly))
+	}
+	return nil
+}
+
+// defaultDueWithin is how far ahead items count as due soon unless asked otherwise
+const defaultDueWithin = 24 * time.Hour
+
+// dueWithin returns the duration given by within, defaultDueWithin when empty
+func dueWithin(within string) (time.Duration, error) {
+	if strings.TrimSpace(within) == "" {
+		return defaultDueWithin, nil
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(within))
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("invalid duration %q, expected a duration such as 48h", within))
+	}
+	if d <= 0 {
+		return 0, errors.New("due within has to be a positive duration")
+	}
+	return d, nil
+}
+
+func validateGetDueItemsRequest(req *api.GetDueItemsRequest) error {
+	_, err := dueWithin(req.Within)
+	return err
+}
+
+func validateUpdateReminderPreferencesRequest(req *api.UpdateReminderPreferencesRequest) error {
+	req.LeadTime = strings.TrimSpace(req.LeadTime)
+	req.QuietFrom = strings.TrimSpace(req.QuietFrom)
+	req.QuietUntil = strings.TrimSpace(req.QuietUntil)
+	req.TimeZone = strings.TrimSpace(req.TimeZone)
+	req.Channel = strings.ToLower(strings.TrimSpace(req.Channel))
+	if req.LeadTime != "" {
+		leadTime, err := time.ParseDuration(req.LeadTime)
+		if err != nil {
+			return errors.New(fmt.Sprintf("invalid lead time %q, expected a duration such as 24h", req.LeadTime))
+		}
+		if leadTime <= 0 || leadTime > maxLeadTime {
+			return errors.New(fmt.Sprintf("lead time has to be positive and at most %v", formatLeadTime(maxLeadTime)))
+		}
+	}
+	if (req.QuietFrom == "") != (req.QuietUntil == "") {
+		return errors.New("quiet hours need both a start and an end")
+	}
+	for _, clock := range []string{req.QuietFrom, req.QuietUntil} {
+		if clock == "" {
+			continue
+		}
+		if _, err := minuteOfDay(clock); err != nil {
+			return err
+		}
+	}
+	if req.TimeZone != "" {
+		if _, err := time.LoadLocation(req.TimeZone); err != nil {
+			return errors.New(fmt.Sprintf("unknown time zone %q", req.TimeZone))
+		}
+	}
+	switch req.Channel {
+	case "", api.EmailChannel, api.NoChannel:
+	default:
+		return errors.New(fmt.Sprintf("unknown channel %q, expected %v or %v", req.Channel, api.EmailChannel, api.NoChannel))
+	}
+	return nil
+}
+
+func validateCreateStoreRequest(req *api.CreateStoreRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return errors.New("store name can not be empty")
+	}
+	return validateStoreLayout(req.CategoryIDs)
+}
+
+func validateUpdateStoreRequest(req *api.UpdateStoreRequest) error {
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return errors.New("store name can not be empty")
+		}
+		req.Name = &name
+	}
+	if req.CategoryIDs != nil {
+		return validateStoreLayout(*req.CategoryIDs)
+	}
+	return nil
+}
+
+// validateStoreLayout checks that a category takes a single place in the aisles of a store
+func validateStoreLayout(categoryIDs []int64) error {
+	seen := make(map[int64]bool)
+	for _, id := range categoryIDs {
+		if seen[id] {
+			return errors.New(fmt.Sprintf("category %v is laid out more than once", id))
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// validateAccessType checks the role to grant, the access types of older
+// clients are replaced with the matching role
+func validateAccessType(accessType *string) error {
+	*accessType = api.NormaliseRole(*accessType)
+	switch *accessType {
+	case api.RoleViewer, api.RoleShopper, api.RoleEditor, api.RoleManager:
+		return nil
+	}
+	return errors.New(fmt.Sprintf("invalid access type %v", *accessType))
+}
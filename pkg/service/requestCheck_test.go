@@ -0,0 +1,49 @@
+package service
+
+import (
+	"shoppinglist/pkg/api"
+	"testing"
+	"time"
+)
+
+func TestValidateUpdateDeadline(t *testing.T) {
+	validators := map[string]func(deadline *time.Time) error{
+		"list": func(deadline *time.Time) error {
+			return validateUpdateListRequest(&api.UpdateListRequest{Deadline: deadline})
+		},
+		"item": func(deadline *time.Time) error {
+			return validateUpdateItemRequest(&api.UpdateItemRequest{Deadline: deadline})
+		},
+	}
+	past, future, cleared := time.Now().Add(-time.Hour), time.Now().Add(time.Hour), time.Time{}
+	tests := []struct {
+		name     string
+		deadline *time.Time
+		valid    bool
+	}{
+		{"unchanged", nil, true},
+		{"future", &future, true},
+		{"cleared", &cleared, false},
+		{"past", &past, false},
+	}
+	for kind, validate := range validators {
+		for _, tt := range tests {
+			err := validate(tt.deadline)
+			if (err == nil) != tt.valid {
+				t.Errorf("%v %v deadline: expected valid %v, got error %v", kind, tt.name, tt.valid, err)
+			}
+		}
+	}
+}
+
+func TestValidateUpdateListRequestTrimsName(t *testing.T) {
+	name := "  weekly "
+	req := api.UpdateListRequest{Name: &name}
+	err := validateUpdateListRequest(&req)
+	if err != nil {
+		t.Fatalf("valid name rejected: %v", err)
+	}
+	if *req.Name != "weekly" {
+		t.Fatalf("name is %q after validation", *req.Name)
+	}
+}
@@ -2,20 +2,22 @@ package service
 
 import (
 	"context"
-	_ "database/sql"
 	"github.com/go-kit/kit/log"
-	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/bcrypt"
 	"shoppinglist/pkg/api"
+	"shoppinglist/pkg/session"
+	"shoppinglist/pkg/store"
 	"time"
 )
 
 type Config struct {
-	DBConn     string `json:dbconn`
-	DBPort     string `json:dbport`
+	DBConn     string `json:"dbconn"`
+	DBPort     string `json:"dbport"`
 	DBUser     string `json:"db_user"`
 	DBPassword string `json:"db_password"`
+	// InviteSecret signs the tokens of list invitations
+	InviteSecret string `json:"invite_secret"`
 }
 
 type Info struct {
@@ -27,7 +29,8 @@ type Info struct {
 }
 
 type basicService struct {
-	db           *sqlx.DB
+	repo         store.Repository
+	sessions     session.Store
 	logger       log.Logger
 	ConfigObject *Config
 	serviceInfo  *Info
@@ -40,20 +43,74 @@ type Service interface {
 	Logout(ctx context.Context, req api.LogoutRequest) (resp api.LogoutResponse)
 	CreateList(ctx context.Context, req api.CreateListRequest) (resp api.CreateListResponse)
 	GetLists(ctx context.Context, req api.GetListsRequest) (resp api.GetListsResponse)
+	UpdateList(ctx context.Context, req api.UpdateListRequest) (resp api.UpdateListResponse)
 	CreateItem(ctx context.Context, req api.CreateItemRequest) (resp api.CreateItemResponse)
+	UpdateItem(ctx context.Context, req api.UpdateItemRequest) (resp api.UpdateItemResponse)
 	GetListItems(ctx context.Context, req api.GetListItemsRequest) (resp api.GetListItemsResponse)
 	BuyItem(ctx context.Context, req api.BuyItemRequest) (resp api.BuyItemResponse)
 	ShareList(ctx context.Context, req api.ShareListRequest) (resp api.ShareListResponse)
 	GetAllCategories(ctx context.Context, req api.GetAllCategoriesRequest) (resp api.GetAllCategoriesResponse)
 	DeleteList(ctx context.Context, req api.DeleteListRequest) (resp api.DeleteListResponse)
 	DeleteItem(ctx context.Context, req api.DeleteItemRequest) (resp api.DeleteItemResponse)
+	UnbuyItem(ctx context.Context, req api.UnbuyItemRequest) (resp api.UnbuyItemResponse)
+	RestoreItem(ctx context.Context, req api.RestoreItemRequest) (resp api.RestoreItemResponse)
+	GetTrash(ctx context.Context, req api.GetTrashRequest) (resp api.GetTrashResponse)
+	RestoreList(ctx context.Context, req api.RestoreListRequest) (resp api.RestoreListResponse)
+	GetContributors(ctx context.Context, req api.GetContributorsRequest) (resp api.GetContributorsResponse)
+	UpdateContributor(ctx context.Context, req api.UpdateContributorRequest) (resp api.UpdateContributorResponse)
+	RevokeContributor(ctx context.Context, req api.RevokeContributorRequest) (resp api.RevokeContributorResponse)
+	CreateInvite(ctx context.Context, req api.CreateInviteRequest) (resp api.CreateInviteResponse)
+	GetInvites(ctx context.Context, req api.GetInvitesRequest) (resp api.GetInvitesResponse)
+	CancelInvite(ctx context.Context, req api.CancelInviteRequest) (resp api.CancelInviteResponse)
+	AcceptInvite(ctx context.Context, req api.AcceptInviteRequest) (resp api.AcceptInviteResponse)
+	TransferOwnership(ctx context.Context, req api.TransferOwnershipRequest) (resp api.TransferOwnershipResponse)
+	CreateGroup(ctx context.Context, req api.CreateGroupRequest) (resp api.CreateGroupResponse)
+	GetGroups(ctx context.Context, req api.GetGroupsRequest) (resp api.GetGroupsResponse)
+	GetGroupMembers(ctx context.Context, req api.GetGroupMembersRequest) (resp api.GetGroupMembersResponse)
+	AddGroupMember(ctx context.Context, req api.AddGroupMemberRequest) (resp api.AddGroupMemberResponse)
+	RemoveGroupMember(ctx context.Context, req api.RemoveGroupMemberRequest) (resp api.RemoveGroupMemberResponse)
+	ShareListWithGroup(ctx context.Context, req api.ShareListWithGroupRequest) (resp api.ShareListWithGroupResponse)
+	UnshareListWithGroup(ctx context.Context, req api.UnshareListWithGroupRequest) (resp api.UnshareListWithGroupResponse)
+	CreatePublicLink(ctx context.Context, req api.CreatePublicLinkRequest) (resp api.CreatePublicLinkResponse)
+	GetPublicLinks(ctx context.Context, req api.GetPublicLinksRequest) (resp api.GetPublicLinksResponse)
+	RevokePublicLink(ctx context.Context, req api.RevokePublicLinkRequest) (resp api.RevokePublicLinkResponse)
+	GetPublicList(ctx context.Context, req api.GetPublicListRequest) (resp api.GetPublicListResponse)
+	BuyPublicItem(ctx context.Context, req api.BuyPublicItemRequest) (resp api.BuyPublicItemResponse)
+	CreateCategory(ctx context.Context, req api.CreateCategoryRequest) (resp api.CreateCategoryResponse)
+	UpdateCategory(ctx context.Context, req api.UpdateCategoryRequest) (resp api.UpdateCategoryResponse)
+	DeleteCategory(ctx context.Context, req api.DeleteCategoryRequest) (resp api.DeleteCategoryResponse)
+	MergeCategories(ctx context.Context, req api.MergeCategoriesRequest) (resp api.MergeCategoriesResponse)
+	CreateCategoryRule(ctx context.Context, req api.CreateCategoryRuleRequest) (resp api.CreateCategoryRuleResponse)
+	GetCategoryRules(ctx context.Context, req api.GetCategoryRulesRequest) (resp api.GetCategoryRulesResponse)
+	DeleteCategoryRule(ctx context.Context, req api.DeleteCategoryRuleRequest) (resp api.DeleteCategoryRuleResponse)
+	CreateStore(ctx context.Context, req api.CreateStoreRequest) (resp api.CreateStoreResponse)
+	GetStores(ctx context.Context, req api.GetStoresRequest) (resp api.GetStoresResponse)
+	UpdateStore(ctx context.Context, req api.UpdateStoreRequest) (resp api.UpdateStoreResponse)
+	DeleteStore(ctx context.Context, req api.DeleteStoreRequest) (resp api.DeleteStoreResponse)
+	ReorderItems(ctx context.Context, req api.ReorderItemsRequest) (resp api.ReorderItemsResponse)
+	MoveItems(ctx context.Context, req api.MoveItemsRequest) (resp api.MoveItemsResponse)
+	CopyItems(ctx context.Context, req api.CopyItemsRequest) (resp api.CopyItemsResponse)
+	CloneList(ctx context.Context, req api.CloneListRequest) (resp api.CloneListResponse)
+	CreateTemplate(ctx context.Context, req api.CreateTemplateRequest) (resp api.CreateTemplateResponse)
+	GetTemplates(ctx context.Context, req api.GetTemplatesRequest) (resp api.GetTemplatesResponse)
+	InstantiateTemplate(ctx context.Context, req api.InstantiateTemplateRequest) (resp api.InstantiateTemplateResponse)
+	CreateRecurrence(ctx context.Context, req api.CreateRecurrenceRequest) (resp api.CreateRecurrenceResponse)
+	GetRecurrences(ctx context.Context, req api.GetRecurrencesRequest) (resp api.GetRecurrencesResponse)
+	PauseRecurrence(ctx context.Context, req api.PauseRecurrenceRequest) (resp api.PauseRecurrenceResponse)
+	ResumeRecurrence(ctx context.Context, req api.ResumeRecurrenceRequest) (resp api.ResumeRecurrenceResponse)
+	DeleteRecurrence(ctx context.Context, req api.DeleteRecurrenceRequest) (resp api.DeleteRecurrenceResponse)
+	GetRecurrenceInstances(ctx context.Context, req api.GetRecurrenceInstancesRequest) (resp api.GetRecurrenceInstancesResponse)
+	GetDueItems(ctx context.Context, req api.GetDueItemsRequest) (resp api.GetDueItemsResponse)
+	GetReminderPreferences(ctx context.Context, req api.GetReminderPreferencesRequest) (resp api.GetReminderPreferencesResponse)
+	UpdateReminderPreferences(ctx context.Context, req api.UpdateReminderPreferencesRequest) (resp api.UpdateReminderPreferencesResponse)
 }
 
 // New returns a basic Service with all of the expected middlewares wired in.
-func New(db *sqlx.DB, logger log.Logger, configObject *Config, serviceInfo *Info /*other middlewares here*/) Service {
+func New(repo store.Repository, sessions session.Store, logger log.Logger, configObject *Config, serviceInfo *Info /*other middlewares here*/) Service {
 	var svc Service
 	{
-		svc = basicService{db, logger, configObject, serviceInfo}
+		svc = basicService{repo, sessions, logger, configObject, serviceInfo}
+		svc = AuthorisationMiddleware(NewPolicy(repo), logger)(svc)
 		svc = LoggingMiddleware(logger)(svc)
 		/*chain other middleware here*/
 	}
@@ -82,7 +139,7 @@ func (s basicService) Signup(ctx context.Context, req api.SignupRequest) (resp a
 	}
 	req.Password = string(encryptedPass)
 	//store the user in DB
-	err = processSingupRequest(ctx, s.db, &req)
+	err = processSingupRequest(ctx, s.repo, s.sessions, &req)
 	if err != nil {
 		resp.Err = errors.Wrap(err, "failed to process signup service")
 		return
@@ -98,7 +155,7 @@ func (s basicService) Login(ctx context.Context, req api.LoginRequest) (resp api
 		resp.Err = errors.Wrapf(err, "request validation failed for login service")
 		return
 	}
-	st, err := processLoginRequest(ctx, s.db, &req)
+	st, err := processLoginRequest(ctx, s.repo, s.sessions, &req)
 	if err != nil {
 		resp.Err = errors.Wrap(err, "failed to process login service")
 		return
@@ -110,7 +167,7 @@ func (s basicService) Login(ctx context.Context, req api.LoginRequest) (resp api
 
 func (s basicService) Logout(ctx context.Context, req api.LogoutRequest) (resp api.LogoutResponse) {
 	logger := log.With(s.logger, "method", "LogoutService")
-	resp.Err = processLogoutRequest(ctx, s.db, &req)
+	resp.Err = processLogoutRequest(ctx, s.repo, s.sessions, &req)
 	if resp.Err != nil {
 		return
 	}
@@ -125,7 +182,7 @@ func (s basicService) CreateList(ctx context.Context, req api.CreateListRequest)
 		resp.Err = errors.Wrapf(err, "request validation failed for create list service")
 		return
 	}
-	st, err := processCreateListRequest(ctx, s.db, &req)
+	st, err := processCreateListRequest(ctx, s.repo, s.sessions, &req)
 	resp.SessionToken = st
 	if err != nil {
 		resp.Err = errors.Wrap(err, "failed to process create list service")
@@ -141,7 +198,7 @@ func (s basicService) GetLists(ctx context.Context, req api.GetListsRequest) (re
 	if err != nil {
 		resp.Err = errors.Wrapf(err, "request validation failed for get lists service")
 	}
-	lists, st, err := processGetListsRequest(ctx, s.db, &req)
+	lists, st, err := processGetListsRequest(ctx, s.repo, s.sessions, &req)
 	resp.SessionToken = st
 	if err != nil {
 		resp.Err = errors.Wrapf(err, "failed to process get lists service")
@@ -152,13 +209,31 @@ func (s basicService) GetLists(ctx context.Context, req api.GetListsRequest) (re
 	return
 }
 
+func (s basicService) UpdateList(ctx context.Context, req api.UpdateListRequest) (resp api.UpdateListResponse) {
+	logger := log.With(s.logger, "method", "UpdateListService")
+	err := validateUpdateListRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for update list service")
+		return
+	}
+	st, err := processUpdateListRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process update list service")
+		return
+	}
+	logger.Log("successfully_updated_list :", req.ListID)
+	return
+}
+
 func (s basicService) CreateItem(ctx context.Context, req api.CreateItemRequest) (resp api.CreateItemResponse) {
 	logger := log.With(s.logger, "method", "CreateItemService")
 	err := validateCreateItemRequest(&req)
 	if err != nil {
 		resp.Err = errors.Wrapf(err, "request validation failed for create item service")
+		return
 	}
-	st, err := processCreateItemRequest(ctx, s.db, &req)
+	st, err := processCreateItemRequest(ctx, s.repo, s.sessions, &req)
 	resp.SessionToken = st
 	if err != nil {
 		resp.Err = errors.Wrapf(err, "failed to process create item service")
@@ -168,19 +243,38 @@ func (s basicService) CreateItem(ctx context.Context, req api.CreateItemRequest)
 	return
 }
 
+func (s basicService) UpdateItem(ctx context.Context, req api.UpdateItemRequest) (resp api.UpdateItemResponse) {
+	logger := log.With(s.logger, "method", "UpdateItemService")
+	err := validateUpdateItemRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for update item service")
+		return
+	}
+	st, err := processUpdateItemRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process update item service")
+		return
+	}
+	logger.Log("successfully_updated_item :", req.ItemID)
+	return
+}
+
 func (s basicService) GetListItems(ctx context.Context, req api.GetListItemsRequest) (resp api.GetListItemsResponse) {
 	logger := log.With(s.logger, "method", "GetListItems")
 	err := validateGetListItemsRequest(&req)
 	if err != nil {
 		resp.Err = errors.Wrapf(err, "request validation failed for get list items service")
+		return
 	}
-	items, st, err := processGetListItemsRequest(ctx, s.db, &req)
+	items, sections, st, err := processGetListItemsRequest(ctx, s.repo, s.sessions, &req)
 	resp.SessionToken = st
 	if err != nil {
 		resp.Err = errors.Wrapf(err, "failed to process get list items service")
 		return
 	}
 	resp.Items = items
+	resp.Sections = sections
 	logger.Log("successfully_returned_items_for_list :", req.ListID)
 	return
 }
@@ -191,7 +285,7 @@ func (s basicService) BuyItem(ctx context.Context, req api.BuyItemRequest) (resp
 	if err != nil {
 		resp.Err = errors.Wrapf(err, "request validation failed for buy item service")
 	}
-	st, err := processBuyItemRequest(ctx, s.db, &req)
+	st, err := processBuyItemRequest(ctx, s.repo, s.sessions, &req)
 	resp.SessionToken = st
 	if err != nil {
 		resp.Err = errors.Wrapf(err, "failed to process get list items service")
@@ -206,8 +300,9 @@ func (s basicService) ShareList(ctx context.Context, req api.ShareListRequest) (
 	err := validateShareListRequest(&req)
 	if err != nil {
 		resp.Err = errors.Wrapf(err, "request validation failed for share list service")
+		return
 	}
-	st, err := processShareListRequest(ctx, s.db, &req)
+	st, err := processShareListRequest(ctx, s.repo, s.sessions, &req)
 	resp.SessionToken = st
 	if err != nil {
 		resp.Err = errors.Wrapf(err, "failed to process share list service")
@@ -218,7 +313,7 @@ func (s basicService) ShareList(ctx context.Context, req api.ShareListRequest) (
 }
 
 func (s basicService) GetAllCategories(ctx context.Context, req api.GetAllCategoriesRequest) (resp api.GetAllCategoriesResponse) {
-	categories, st, err := processGetAllCategoriesRequest(ctx, s.db, &req)
+	categories, st, err := processGetAllCategoriesRequest(ctx, s.repo, s.sessions, &req)
 	resp.SessionToken = st
 	if err != nil {
 		resp.Err = errors.Wrapf(err, "failed to process get all categories service")
@@ -229,7 +324,7 @@ func (s basicService) GetAllCategories(ctx context.Context, req api.GetAllCatego
 }
 
 func (s basicService) DeleteList(ctx context.Context, req api.DeleteListRequest) (resp api.DeleteListResponse) {
-	st, err := processDeleteListRequest(ctx, s.db, &req)
+	st, err := processDeleteListRequest(ctx, s.repo, s.sessions, &req)
 	resp.SessionToken = st
 	if err != nil {
 		resp.Err = errors.Wrapf(err, "failed to delete the list")
@@ -239,7 +334,7 @@ func (s basicService) DeleteList(ctx context.Context, req api.DeleteListRequest)
 }
 
 func (s basicService) DeleteItem(ctx context.Context, req api.DeleteItemRequest) (resp api.DeleteItemResponse) {
-	st, err := processDeleteItemRequest(ctx, s.db, &req)
+	st, err := processDeleteItemRequest(ctx, s.repo, s.sessions, &req)
 	resp.SessionToken = st
 	if err != nil {
 		resp.Err = errors.Wrapf(err, "failed to delete the item")
@@ -247,3 +342,772 @@ func (s basicService) DeleteItem(ctx context.Context, req api.DeleteItemRequest)
 	}
 	return
 }
+
+func (s basicService) UnbuyItem(ctx context.Context, req api.UnbuyItemRequest) (resp api.UnbuyItemResponse) {
+	logger := log.With(s.logger, "method", "UnbuyItemService")
+	st, err := processUnbuyItemRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process unbuy item service")
+		return
+	}
+	logger.Log("successfully_marked_item_as_not_bought :", req.ItemID)
+	return
+}
+
+func (s basicService) RestoreItem(ctx context.Context, req api.RestoreItemRequest) (resp api.RestoreItemResponse) {
+	logger := log.With(s.logger, "method", "RestoreItemService")
+	st, err := processRestoreItemRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process restore item service")
+		return
+	}
+	logger.Log("successfully_restored_item :", req.ItemID)
+	return
+}
+
+func (s basicService) GetTrash(ctx context.Context, req api.GetTrashRequest) (resp api.GetTrashResponse) {
+	logger := log.With(s.logger, "method", "GetTrashService")
+	lists, items, st, err := processGetTrashRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process get trash service")
+		return
+	}
+	resp.Lists = lists
+	resp.Items = items
+	logger.Log("successfully_got_trash_for_user :", req.UserID)
+	return
+}
+
+func (s basicService) RestoreList(ctx context.Context, req api.RestoreListRequest) (resp api.RestoreListResponse) {
+	logger := log.With(s.logger, "method", "RestoreListService")
+	st, err := processRestoreListRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process restore list service")
+		return
+	}
+	logger.Log("successfully_restored_list :", req.ListID)
+	return
+}
+
+func (s basicService) GetContributors(ctx context.Context, req api.GetContributorsRequest) (resp api.GetContributorsResponse) {
+	logger := log.With(s.logger, "method", "GetContributorsService")
+	contributors, groups, st, err := processGetContributorsRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process get contributors service")
+		return
+	}
+	resp.Contributors = contributors
+	resp.Groups = groups
+	logger.Log("successfully_got_contributors_of_list :", req.ListID)
+	return
+}
+
+func (s basicService) UpdateContributor(ctx context.Context, req api.UpdateContributorRequest) (resp api.UpdateContributorResponse) {
+	logger := log.With(s.logger, "method", "UpdateContributorService")
+	err := validateUpdateContributorRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for update contributor service")
+		return
+	}
+	st, err := processUpdateContributorRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process update contributor service")
+		return
+	}
+	logger.Log("successfully_updated_contributor :", req.ListID)
+	return
+}
+
+func (s basicService) RevokeContributor(ctx context.Context, req api.RevokeContributorRequest) (resp api.RevokeContributorResponse) {
+	logger := log.With(s.logger, "method", "RevokeContributorService")
+	st, err := processRevokeContributorRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process revoke contributor service")
+		return
+	}
+	logger.Log("successfully_revoked_contributor :", req.ListID)
+	return
+}
+
+func (s basicService) CreateInvite(ctx context.Context, req api.CreateInviteRequest) (resp api.CreateInviteResponse) {
+	logger := log.With(s.logger, "method", "CreateInviteService")
+	err := validateCreateInviteRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for create invite service")
+		return
+	}
+	invite, st, err := processCreateInviteRequest(ctx, s.repo, s.sessions, []byte(s.ConfigObject.InviteSecret), &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process create invite service")
+		return
+	}
+	resp.Invite = invite
+	logger.Log("successfully_created_invite_to_list :", req.ListID)
+	return
+}
+
+func (s basicService) GetInvites(ctx context.Context, req api.GetInvitesRequest) (resp api.GetInvitesResponse) {
+	logger := log.With(s.logger, "method", "GetInvitesService")
+	invites, st, err := processGetInvitesRequest(ctx, s.repo, s.sessions, []byte(s.ConfigObject.InviteSecret), &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process get invites service")
+		return
+	}
+	resp.Invites = invites
+	logger.Log("successfully_got_invites_to_list :", req.ListID)
+	return
+}
+
+func (s basicService) CancelInvite(ctx context.Context, req api.CancelInviteRequest) (resp api.CancelInviteResponse) {
+	logger := log.With(s.logger, "method", "CancelInviteService")
+	st, err := processCancelInviteRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process cancel invite service")
+		return
+	}
+	logger.Log("successfully_cancelled_invite :", req.ListID)
+	return
+}
+
+func (s basicService) AcceptInvite(ctx context.Context, req api.AcceptInviteRequest) (resp api.AcceptInviteResponse) {
+	logger := log.With(s.logger, "method", "AcceptInviteService")
+	err := validateAcceptInviteRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for accept invite service")
+		return
+	}
+	listID, st, err := processAcceptInviteRequest(ctx, s.repo, s.sessions, []byte(s.ConfigObject.InviteSecret), &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process accept invite service")
+		return
+	}
+	resp.ListID = listID
+	logger.Log("successfully_accepted_invite_for_user :", req.UserID)
+	return
+}
+
+func (s basicService) TransferOwnership(ctx context.Context, req api.TransferOwnershipRequest) (resp api.TransferOwnershipResponse) {
+	logger := log.With(s.logger, "method", "TransferOwnershipService")
+	err := validateTransferOwnershipRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for transfer ownership service")
+		return
+	}
+	st, err := processTransferOwnershipRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process transfer ownership service")
+		return
+	}
+	logger.Log("successfully_transferred_list :", req.ListID)
+	return
+}
+
+func (s basicService) CreateGroup(ctx context.Context, req api.CreateGroupRequest) (resp api.CreateGroupResponse) {
+	logger := log.With(s.logger, "method", "CreateGroupService")
+	err := validateCreateGroupRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for create group service")
+		return
+	}
+	group, st, err := processCreateGroupRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process create group service")
+		return
+	}
+	resp.Group = group
+	logger.Log("successfully_created_group_for_user :", req.UserID)
+	return
+}
+
+func (s basicService) GetGroups(ctx context.Context, req api.GetGroupsRequest) (resp api.GetGroupsResponse) {
+	logger := log.With(s.logger, "method", "GetGroupsService")
+	groups, st, err := processGetGroupsRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process get groups service")
+		return
+	}
+	resp.Groups = groups
+	logger.Log("successfully_got_groups_for_user :", req.UserID)
+	return
+}
+
+func (s basicService) GetGroupMembers(ctx context.Context, req api.GetGroupMembersRequest) (resp api.GetGroupMembersResponse) {
+	logger := log.With(s.logger, "method", "GetGroupMembersService")
+	members, st, err := processGetGroupMembersRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process get group members service")
+		return
+	}
+	resp.Members = members
+	logger.Log("successfully_got_members_of_group :", req.GroupID)
+	return
+}
+
+func (s basicService) AddGroupMember(ctx context.Context, req api.AddGroupMemberRequest) (resp api.AddGroupMemberResponse) {
+	logger := log.With(s.logger, "method", "AddGroupMemberService")
+	err := validateAddGroupMemberRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for add group member service")
+		return
+	}
+	st, err := processAddGroupMemberRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process add group member service")
+		return
+	}
+	logger.Log("successfully_added_member_to_group :", req.GroupID)
+	return
+}
+
+func (s basicService) RemoveGroupMember(ctx context.Context, req api.RemoveGroupMemberRequest) (resp api.RemoveGroupMemberResponse) {
+	logger := log.With(s.logger, "method", "RemoveGroupMemberService")
+	st, err := processRemoveGroupMemberRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process remove group member service")
+		return
+	}
+	logger.Log("successfully_removed_member_from_group :", req.GroupID)
+	return
+}
+
+func (s basicService) ShareListWithGroup(ctx context.Context, req api.ShareListWithGroupRequest) (resp api.ShareListWithGroupResponse) {
+	logger := log.With(s.logger, "method", "ShareListWithGroupService")
+	err := validateShareListWithGroupRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for share list with group service")
+		return
+	}
+	st, err := processShareListWithGroupRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process share list with group service")
+		return
+	}
+	logger.Log("successfully_shared_list_with_group :", req.ListID)
+	return
+}
+
+func (s basicService) UnshareListWithGroup(ctx context.Context, req api.UnshareListWithGroupRequest) (resp api.UnshareListWithGroupResponse) {
+	logger := log.With(s.logger, "method", "UnshareListWithGroupService")
+	st, err := processUnshareListWithGroupRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process unshare list with group service")
+		return
+	}
+	logger.Log("successfully_unshared_list_with_group :", req.ListID)
+	return
+}
+
+func (s basicService) CreatePublicLink(ctx context.Context, req api.CreatePublicLinkRequest) (resp api.CreatePublicLinkResponse) {
+	logger := log.With(s.logger, "method", "CreatePublicLinkService")
+	err := validateCreatePublicLinkRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for create public link service")
+		return
+	}
+	link, st, err := processCreatePublicLinkRequest(ctx, s.repo, s.sessions, []byte(s.ConfigObject.InviteSecret), &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process create public link service")
+		return
+	}
+	resp.Link = link
+	logger.Log("successfully_created_public_link_to_list :", req.ListID)
+	return
+}
+
+func (s basicService) GetPublicLinks(ctx context.Context, req api.GetPublicLinksRequest) (resp api.GetPublicLinksResponse) {
+	logger := log.With(s.logger, "method", "GetPublicLinksService")
+	links, st, err := processGetPublicLinksRequest(ctx, s.repo, s.sessions, []byte(s.ConfigObject.InviteSecret), &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process get public links service")
+		return
+	}
+	resp.Links = links
+	logger.Log("successfully_read_public_links_to_list :", req.ListID)
+	return
+}
+
+func (s basicService) RevokePublicLink(ctx context.Context, req api.RevokePublicLinkRequest) (resp api.RevokePublicLinkResponse) {
+	logger := log.With(s.logger, "method", "RevokePublicLinkService")
+	st, err := processRevokePublicLinkRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process revoke public link service")
+		return
+	}
+	logger.Log("successfully_revoked_public_link :", req.LinkID)
+	return
+}
+
+func (s basicService) GetPublicList(ctx context.Context, req api.GetPublicListRequest) (resp api.GetPublicListResponse) {
+	logger := log.With(s.logger, "method", "GetPublicListService")
+	list, items, err := processGetPublicListRequest(ctx, s.repo, []byte(s.ConfigObject.InviteSecret), &req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process get public list service")
+		return
+	}
+	resp.List = list
+	resp.Items = items
+	logger.Log("successfully_read_public_list :", list.ID)
+	return
+}
+
+func (s basicService) BuyPublicItem(ctx context.Context, req api.BuyPublicItemRequest) (resp api.BuyPublicItemResponse) {
+	logger := log.With(s.logger, "method", "BuyPublicItemService")
+	err := validateBuyPublicItemRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for buy public item service")
+		return
+	}
+	err = processBuyPublicItemRequest(ctx, s.repo, []byte(s.ConfigObject.InviteSecret), &req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process buy public item service")
+		return
+	}
+	logger.Log("successfully_bought_item_through_public_link :", req.ItemID)
+	return
+}
+
+func (s basicService) CreateCategory(ctx context.Context, req api.CreateCategoryRequest) (resp api.CreateCategoryResponse) {
+	logger := log.With(s.logger, "method", "CreateCategoryService")
+	err := validateCreateCategoryRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for create category service")
+		return
+	}
+	category, st, err := processCreateCategoryRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process create category service")
+		return
+	}
+	resp.Category = category
+	logger.Log("successfully_created_category :", req.Name)
+	return
+}
+
+func (s basicService) UpdateCategory(ctx context.Context, req api.UpdateCategoryRequest) (resp api.UpdateCategoryResponse) {
+	logger := log.With(s.logger, "method", "UpdateCategoryService")
+	err := validateUpdateCategoryRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for update category service")
+		return
+	}
+	category, st, err := processUpdateCategoryRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process update category service")
+		return
+	}
+	resp.Category = category
+	logger.Log("successfully_updated_category :", req.CategoryID)
+	return
+}
+
+func (s basicService) DeleteCategory(ctx context.Context, req api.DeleteCategoryRequest) (resp api.DeleteCategoryResponse) {
+	logger := log.With(s.logger, "method", "DeleteCategoryService")
+	st, err := processDeleteCategoryRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process delete category service")
+		return
+	}
+	logger.Log("successfully_deleted_category :", req.CategoryID)
+	return
+}
+
+func (s basicService) MergeCategories(ctx context.Context, req api.MergeCategoriesRequest) (resp api.MergeCategoriesResponse) {
+	logger := log.With(s.logger, "method", "MergeCategoriesService")
+	err := validateMergeCategoriesRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for merge categories service")
+		return
+	}
+	category, moved, st, err := processMergeCategoriesRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process merge categories service")
+		return
+	}
+	resp.Category = category
+	resp.MovedItems = moved
+	logger.Log("successfully_merged_categories :", req.CategoryID)
+	return
+}
+
+func (s basicService) CreateCategoryRule(ctx context.Context, req api.CreateCategoryRuleRequest) (resp api.CreateCategoryRuleResponse) {
+	logger := log.With(s.logger, "method", "CreateCategoryRuleService")
+	err := validateCreateCategoryRuleRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for create category rule service")
+		return
+	}
+	rule, st, err := processCreateCategoryRuleRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process create category rule service")
+		return
+	}
+	resp.Rule = rule
+	logger.Log("successfully_added_category_rule :", req.CategoryID)
+	return
+}
+
+func (s basicService) GetCategoryRules(ctx context.Context, req api.GetCategoryRulesRequest) (resp api.GetCategoryRulesResponse) {
+	logger := log.With(s.logger, "method", "GetCategoryRulesService")
+	rules, st, err := processGetCategoryRulesRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process get category rules service")
+		return
+	}
+	resp.Rules = rules
+	logger.Log("successfully_read_category_rules :", req.UserID)
+	return
+}
+
+func (s basicService) DeleteCategoryRule(ctx context.Context, req api.DeleteCategoryRuleRequest) (resp api.DeleteCategoryRuleResponse) {
+	logger := log.With(s.logger, "method", "DeleteCategoryRuleService")
+	st, err := processDeleteCategoryRuleRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process delete category rule service")
+		return
+	}
+	logger.Log("successfully_deleted_category_rule :", req.RuleID)
+	return
+}
+
+func (s basicService) CreateStore(ctx context.Context, req api.CreateStoreRequest) (resp api.CreateStoreResponse) {
+	logger := log.With(s.logger, "method", "CreateStoreService")
+	err := validateCreateStoreRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for create store service")
+		return
+	}
+	shop, st, err := processCreateStoreRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process create store service")
+		return
+	}
+	resp.Store = shop
+	logger.Log("successfully_added_store :", req.UserID)
+	return
+}
+
+func (s basicService) GetStores(ctx context.Context, req api.GetStoresRequest) (resp api.GetStoresResponse) {
+	logger := log.With(s.logger, "method", "GetStoresService")
+	stores, st, err := processGetStoresRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process get stores service")
+		return
+	}
+	resp.Stores = stores
+	logger.Log("successfully_read_stores :", req.UserID)
+	return
+}
+
+func (s basicService) UpdateStore(ctx context.Context, req api.UpdateStoreRequest) (resp api.UpdateStoreResponse) {
+	logger := log.With(s.logger, "method", "UpdateStoreService")
+	err := validateUpdateStoreRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for update store service")
+		return
+	}
+	shop, st, err := processUpdateStoreRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process update store service")
+		return
+	}
+	resp.Store = shop
+	logger.Log("successfully_updated_store :", req.StoreID)
+	return
+}
+
+func (s basicService) DeleteStore(ctx context.Context, req api.DeleteStoreRequest) (resp api.DeleteStoreResponse) {
+	logger := log.With(s.logger, "method", "DeleteStoreService")
+	st, err := processDeleteStoreRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process delete store service")
+		return
+	}
+	logger.Log("successfully_deleted_store :", req.StoreID)
+	return
+}
+
+func (s basicService) ReorderItems(ctx context.Context, req api.ReorderItemsRequest) (resp api.ReorderItemsResponse) {
+	logger := log.With(s.logger, "method", "ReorderItemsService")
+	err := validateReorderItemsRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for reorder items service")
+		return
+	}
+	items, st, err := processReorderItemsRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process reorder items service")
+		return
+	}
+	resp.Items = items
+	logger.Log("successfully_reordered_items :", req.ListID)
+	return
+}
+
+func (s basicService) MoveItems(ctx context.Context, req api.MoveItemsRequest) (resp api.MoveItemsResponse) {
+	logger := log.With(s.logger, "method", "MoveItemsService")
+	err := validateMoveItemsRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for move items service")
+		return
+	}
+	items, st, err := processMoveItemsRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process move items service")
+		return
+	}
+	resp.Items = items
+	logger.Log("successfully_moved_items :", req.ListID)
+	return
+}
+
+func (s basicService) CopyItems(ctx context.Context, req api.CopyItemsRequest) (resp api.CopyItemsResponse) {
+	logger := log.With(s.logger, "method", "CopyItemsService")
+	err := validateCopyItemsRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for copy items service")
+		return
+	}
+	items, st, err := processCopyItemsRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process copy items service")
+		return
+	}
+	resp.Items = items
+	logger.Log("successfully_copied_items :", req.ListID)
+	return
+}
+
+func (s basicService) CloneList(ctx context.Context, req api.CloneListRequest) (resp api.CloneListResponse) {
+	logger := log.With(s.logger, "method", "CloneListService")
+	err := validateCloneListRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for clone list service")
+		return
+	}
+	list, st, err := processCloneListRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process clone list service")
+		return
+	}
+	resp.List = list
+	logger.Log("list cloned successfully for listID :", req.ListID)
+	return
+}
+
+func (s basicService) CreateTemplate(ctx context.Context, req api.CreateTemplateRequest) (resp api.CreateTemplateResponse) {
+	logger := log.With(s.logger, "method", "CreateTemplateService")
+	err := validateCreateTemplateRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for create template service")
+		return
+	}
+	template, st, err := processCreateTemplateRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process create template service")
+		return
+	}
+	resp.Template = template
+	logger.Log("template saved successfully for listID :", req.ListID)
+	return
+}
+
+func (s basicService) GetTemplates(ctx context.Context, req api.GetTemplatesRequest) (resp api.GetTemplatesResponse) {
+	logger := log.With(s.logger, "method", "GetTemplatesService")
+	templates, st, err := processGetTemplatesRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process get templates service")
+		return
+	}
+	resp.Templates = templates
+	logger.Log("templates fetched successfully for userID :", req.UserID)
+	return
+}
+
+func (s basicService) InstantiateTemplate(ctx context.Context, req api.InstantiateTemplateRequest) (resp api.InstantiateTemplateResponse) {
+	logger := log.With(s.logger, "method", "InstantiateTemplateService")
+	err := validateInstantiateTemplateRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for instantiate template service")
+		return
+	}
+	list, st, err := processInstantiateTemplateRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process instantiate template service")
+		return
+	}
+	resp.List = list
+	logger.Log("list started successfully from templateID :", req.TemplateID)
+	return
+}
+
+func (s basicService) CreateRecurrence(ctx context.Context, req api.CreateRecurrenceRequest) (resp api.CreateRecurrenceResponse) {
+	logger := log.With(s.logger, "method", "CreateRecurrenceService")
+	err := validateCreateRecurrenceRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for create recurrence service")
+		return
+	}
+	recurrence, st, err := processCreateRecurrenceRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process create recurrence service")
+		return
+	}
+	resp.Recurrence = recurrence
+	logger.Log("recurrence created successfully for listID :", req.ListID)
+	return
+}
+
+func (s basicService) GetRecurrences(ctx context.Context, req api.GetRecurrencesRequest) (resp api.GetRecurrencesResponse) {
+	logger := log.With(s.logger, "method", "GetRecurrencesService")
+	recurrences, st, err := processGetRecurrencesRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process get recurrences service")
+		return
+	}
+	resp.Recurrences = recurrences
+	logger.Log("recurrences fetched successfully for userID :", req.UserID)
+	return
+}
+
+func (s basicService) PauseRecurrence(ctx context.Context, req api.PauseRecurrenceRequest) (resp api.PauseRecurrenceResponse) {
+	logger := log.With(s.logger, "method", "PauseRecurrenceService")
+	recurrence, st, err := processPauseRecurrenceRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process pause recurrence service")
+		return
+	}
+	resp.Recurrence = recurrence
+	logger.Log("recurrence paused successfully for recurrenceID :", req.RecurrenceID)
+	return
+}
+
+func (s basicService) ResumeRecurrence(ctx context.Context, req api.ResumeRecurrenceRequest) (resp api.ResumeRecurrenceResponse) {
+	logger := log.With(s.logger, "method", "ResumeRecurrenceService")
+	recurrence, st, err := processResumeRecurrenceRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process resume recurrence service")
+		return
+	}
+	resp.Recurrence = recurrence
+	logger.Log("recurrence resumed successfully for recurrenceID :", req.RecurrenceID)
+	return
+}
+
+func (s basicService) DeleteRecurrence(ctx context.Context, req api.DeleteRecurrenceRequest) (resp api.DeleteRecurrenceResponse) {
+	logger := log.With(s.logger, "method", "DeleteRecurrenceService")
+	st, err := processDeleteRecurrenceRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process delete recurrence service")
+		return
+	}
+	logger.Log("recurrence deleted successfully for recurrenceID :", req.RecurrenceID)
+	return
+}
+
+func (s basicService) GetRecurrenceInstances(ctx context.Context, req api.GetRecurrenceInstancesRequest) (resp api.GetRecurrenceInstancesResponse) {
+	logger := log.With(s.logger, "method", "GetRecurrenceInstancesService")
+	instances, st, err := processGetRecurrenceInstancesRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process get recurrence instances service")
+		return
+	}
+	resp.Instances = instances
+	logger.Log("instances fetched successfully for recurrenceID :", req.RecurrenceID)
+	return
+}
+
+func (s basicService) GetDueItems(ctx context.Context, req api.GetDueItemsRequest) (resp api.GetDueItemsResponse) {
+	logger := log.With(s.logger, "method", "GetDueItemsService")
+	err := validateGetDueItemsRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for get due items service")
+		return
+	}
+	overdue, dueSoon, st, err := processGetDueItemsRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process get due items service")
+		return
+	}
+	resp.Overdue = overdue
+	resp.DueSoon = dueSoon
+	logger.Log("due items fetched successfully for userID :", req.UserID)
+	return
+}
+
+func (s basicService) GetReminderPreferences(ctx context.Context, req api.GetReminderPreferencesRequest) (resp api.GetReminderPreferencesResponse) {
+	logger := log.With(s.logger, "method", "GetReminderPreferencesService")
+	preferences, st, err := processGetReminderPreferencesRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process get reminder preferences service")
+		return
+	}
+	resp.Preferences = preferences
+	logger.Log("reminder preferences fetched successfully for userID :", req.UserID)
+	return
+}
+
+func (s basicService) UpdateReminderPreferences(ctx context.Context, req api.UpdateReminderPreferencesRequest) (resp api.UpdateReminderPreferencesResponse) {
+	logger := log.With(s.logger, "method", "UpdateReminderPreferencesService")
+	err := validateUpdateReminderPreferencesRequest(&req)
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "request validation failed for update reminder preferences service")
+		return
+	}
+	preferences, st, err := processUpdateReminderPreferencesRequest(ctx, s.repo, s.sessions, &req)
+	resp.SessionToken = st
+	if err != nil {
+		resp.Err = errors.Wrapf(err, "failed to process update reminder preferences service")
+		return
+	}
+	resp.Preferences = preferences
+	logger.Log("reminder preferences saved successfully for userID :", req.UserID)
+	return
+}
@@ -2,61 +2,56 @@ package service
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
-	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/bcrypt"
 	"shoppinglist/pkg/api"
+	"shoppinglist/pkg/session"
+	"shoppinglist/pkg/store"
+	"sort"
 	"strings"
 	"time"
 )
 
-func checkListEditPermission(db *sqlx.DB, query string, ID1 int64, ID2 int64) error {
-	var accessType string
-	err := db.QueryRow(query, ID1, ID2).Scan(&accessType)
-	//rr := db.QueryRow("select access_type from list_contributer where list=? and user=?", params[0], params[1]).Scan(&accessType)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return errors.New("unauthorised access, user does not have permission to edit the list")
-		}
-		return errors.Wrapf(err, "error checking list access for user")
-	}
-	if strings.Compare(accessType, "edit") != 0 {
-		return errors.New("unauthorised access, user does not have permission to edit the list")
+func processSingupRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.SignupRequest) error {
+	_, err := repo.GetUserByName(ctx, req.UserName)
+	if err == nil {
+		return errors.New(fmt.Sprintf("username %v not available", req.UserName))
 	}
-	return nil
-}
-
-func processSingupRequest(ctx context.Context, db *sqlx.DB, req *api.SignupRequest) error {
-	query := fmt.Sprintf("SELECT id, username FROM users where username='%v'", req.UserName)
-	res, err := db.Query(query)
-	if err != nil {
+	if !store.IsNotFound(err) {
 		return errors.Wrapf(err, "failed to read db for username %v", req.UserName)
 	}
-	defer res.Close()
-	if res.Next() {
-		return errors.New(fmt.Sprintf("username %v not available", req.UserName))
+	user := store.User{
+		UserName:       req.UserName,
+		FullName:       req.FullName,
+		Email:          req.Email,
+		Password:       req.Password,
+		CreatedAt:      req.CreatedAt,
+		UpdatedAt:      req.UpdatedAt,
+		LastLoggedInAt: req.LastLoggedInAt,
+		Status:         req.Status,
 	}
-	_, err = db.Exec("insert Into users (username, full_name, email, password, created_at, updated_at, last_logged_in_at, status) values (?,?,?,?,?,?,?,?)",
-		req.UserName, req.FullName, req.Email, req.Password, req.CreatedAt, req.UpdatedAt, req.LastLoggedInAt, req.Status)
+	err = repo.CreateUser(ctx, &user)
 	if err != nil {
 		return errors.Wrap(err, "failed to insert user in DB")
 	}
 	return nil
 }
 
-func processLoginRequest(ctx context.Context, db *sqlx.DB, req *api.LoginRequest) (sessionToken string, err error) {
+func processLoginRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.LoginRequest) (sessionToken string, err error) {
 	var uc api.UserContext
 
 	// Get the login details of user from DB
-	err = db.QueryRow("SELECT id, username, password FROM users where username = ?", req.UserName).Scan(&uc.UserID, &uc.UserName, &uc.Password)
+	user, err := repo.GetUserByName(ctx, req.UserName)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if store.IsNotFound(err) {
 			return "", errors.New(fmt.Sprintf("unauthorised access, username %v does not exist", req.UserName))
 		}
 		return "", errors.Wrapf(err, "failed to query DB for given user")
 	}
+	uc.UserID = user.ID
+	uc.UserName = user.UserName
+	uc.Password = user.Password
 
 	// compare the password
 	if err = bcrypt.CompareHashAndPassword([]byte(uc.Password), []byte(req.Password)); err != nil {
@@ -65,443 +60,2412 @@ func processLoginRequest(ctx context.Context, db *sqlx.DB, req *api.LoginRequest
 	// user authenticated, remove password from user context
 	uc.Password = ""
 	// update last logged in date of the user
-	_, err = db.Exec("update users set last_logged_in_at=? where id=?", time.Now(), uc.UserID)
+	err = repo.UpdateLastLoggedIn(ctx, uc.UserID, time.Now())
 	if err != nil {
 		return "", errors.Wrap(err, "failed to update the last logged in date in DB")
 	}
 
-	sessionToken, err = api.SetSessionContext(uc)
+	sessionToken, err = sessions.Create(ctx, uc.UserID)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to set the session for username %v", uc.UserName)
+	}
 	return sessionToken, nil
 }
 
-func processLogoutRequest(ctx context.Context, _ *sqlx.DB, req *api.LogoutRequest) error {
-	err := api.DeleteSessionContext(req.SessionToken)
+func processLogoutRequest(ctx context.Context, _ store.Repository, sessions session.Store, req *api.LogoutRequest) error {
+	var err error
+	if req.AllSessions {
+		err = sessions.DeleteAllForUser(ctx, req.UserID)
+	} else {
+		err = sessions.Delete(ctx, req.SessionToken)
+	}
 	if err != nil {
 		return errors.Wrapf(err, "failed to delete session from cache while logging out")
 	}
 	return nil
 }
 
-func processCreateListRequest(ctx context.Context, db *sqlx.DB, req *api.CreateListRequest) (string, error) {
-	// create a new db transaction
-	tx, err := db.Beginx()
+func processCreateListRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.CreateListRequest) (string, error) {
+	req.List.CreatedAt = time.Now()
+	req.List.LastModifiedAt = time.Now()
+	if req.List.Deadline.IsZero() {
+		req.List.Deadline = time.Now().AddDate(1, 0, 0)
+	}
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		// create a new list
+		err := tx.CreateList(ctx, &req.List)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert new list in DB")
+		}
+		// add the current user as a contributor of the list, the owner's access does not expire
+		err = tx.AddContributor(ctx, &store.Contributor{
+			ListID:     req.List.ID,
+			UserID:     req.List.Owner.UserID,
+			AccessType: api.RoleEditor,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to insert new list-user pair in DB, aborting")
+		}
+		return nil
+	})
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to begin transaction")
+		return "", err
 	}
-	// create a new list
-	resp, err := tx.Exec("insert Into list (name, description, owner, created_at, last_modified_at, deadline, status) values (?,?,?,?,?,?,?)",
-		req.List.Name, req.List.Description, req.List.Owner.UserID, time.Now(), time.Now(), time.Now().AddDate(1, 0, 0), req.List.Status)
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
 	if err != nil {
-		tx.Rollback()
-		return "", errors.Wrap(err, "failed to insert new list in DB")
+		return req.SessionToken, nil
 	}
-	lid, err := resp.LastInsertId()
+	return sessionToken, nil
+}
+
+func processGetListsRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.GetListsRequest) ([]api.List, string, error) {
+	lists, err := userLists(ctx, repo, req.UserID, false)
 	if err != nil {
-		tx.Rollback()
-		return "", errors.Wrapf(err, "failed to get the id of created list, aborting")
+		return lists, "", err
 	}
-	// add the current user as a contributor of the list
-	_, err = tx.Exec("insert into list_contributer (list, user, access_type, valid_until) values (?,?,?,?)",
-		lid, req.List.Owner.UserID, api.Edit, time.Now().AddDate(1, 0, 0))
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
 	if err != nil {
-		tx.Rollback()
-		return "", errors.Wrap(err, "failed to insert new list-user pair in DB, aborting")
+		sessionToken = req.SessionToken
 	}
-	err = tx.Commit()
+	return lists, sessionToken, nil
+}
+
+// userLists returns the lists or the templates user owns or holds a grant on
+func userLists(ctx context.Context, repo store.Repository, userID int64, templates bool) ([]api.List, error) {
+	// read lists associated with current user
+	lists, err := repo.GetListsForUser(ctx, userID)
 	if err != nil {
-		tx.Rollback()
-		return "", errors.Wrapf(err, "failed to commit db transaction while creating list, aborting")
+		return lists, errors.Wrapf(err, "failed to query DB for gives user's lists")
 	}
-	var uc api.UserContext
-	uc.UserID = req.List.Owner.UserID
-	uc.SessionToken = req.SessionToken
-	sessionToken, err := api.RefreshSessionContext(uc)
+	// deleted lists are only shown in the trash
+	lists = filterLists(lists, func(list api.List) bool {
+		return strings.Compare(list.Status, api.Deleted) != 0 && list.Template == templates
+	})
+	for i := range lists {
+		lists[i].CreatedByMe = false
+		if lists[i].Owner.UserID == userID {
+			lists[i].CreatedByMe = true
+			lists[i].AccessType = api.RoleOwner
+		}
+	}
+	return lists, nil
+}
+
+func processUpdateListRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.UpdateListRequest) (string, error) {
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		list, err := tx.GetList(ctx, req.ListID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return errors.New(fmt.Sprintf("list %v does not exist", req.ListID))
+			}
+			return errors.Wrapf(err, "failed to read list details")
+		}
+		if req.Name != nil {
+			list.Name = *req.Name
+		}
+		if req.Description != nil {
+			list.Description = *req.Description
+		}
+		if req.Deadline != nil {
+			list.Deadline = *req.Deadline
+		}
+		if req.StoreID != nil {
+			list.StoreID = *req.StoreID
+		}
+		if req.Status != nil && *req.Status != list.Status {
+			list.Status = *req.Status
+			list.DeletedAt = time.Time{}
+			if strings.Compare(list.Status, api.Deleted) == 0 {
+				list.DeletedAt = time.Now()
+			}
+		}
+		list.LastModifiedAt = time.Now()
+		err = tx.UpdateList(ctx, &list)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update list:%v", req.ListID)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
 	if err != nil {
 		return req.SessionToken, nil
 	}
 	return sessionToken, nil
 }
 
-func processGetListsRequest(ctx context.Context, db *sqlx.DB, req *api.GetListsRequest) ([]api.List, string, error) {
-	var lists []api.List
+func processCreateItemRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.CreateItemRequest) (string, error) {
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		//check the list status
+		list, err := tx.GetList(ctx, req.Item.ListID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return errors.New("the mentioned list does not exist")
+			}
+			return errors.Wrapf(err, "error checking list status")
+		}
+		if strings.Compare(list.Status, api.Todo) != 0 {
+			return errors.New(fmt.Sprintf("list status:%v should be %v", list.Status, api.Todo))
+		}
 
-	// read lists associated with current user
-	query := "select l.id, l.name, l.description, l.owner, l.created_at, l.last_modified_at, l.deadline, " +
-		"l.status, lc.access_type, u.username from " +
-		"(select id, name, description, owner, created_at, last_modified_at, deadline, status from list) l " +
-		"JOIN (select list, access_type from list_contributer where user=?) lc " +
-		"JOIN (select id, username from users) u ON l.id=lc.list and u.id=l.owner"
-	resp, err := db.Query(query, req.UserID)
-	if err != nil {
-		return lists, "", errors.Wrapf(err, "failed to query DB for gives user's lists")
-	}
-	defer resp.Close()
-	for resp.Next() {
-		var list api.List
-		resp.Scan(&list.ID, &list.Name, &list.Description, &list.Owner.UserID, &list.CreatedAt, &list.LastModifiedAt,
-			&list.Deadline, &list.Status, &list.AccessType, &list.Owner.UserName)
-		list.CreatedByMe = false
-		if list.Owner.UserID == req.UserID {
-			list.CreatedByMe = true
+		if req.Merge {
+			merged, err := mergeItem(ctx, tx, req)
+			if err != nil || merged {
+				return err
+			}
+		}
+
+		// without a category the item goes where the user put it before or where the rules say
+		chosen := req.Item.Category.ID != 0 || strings.TrimSpace(req.Item.Category.Name) != ""
+		if !chosen {
+			req.Item.Category, err = categoriseItem(ctx, tx, req.Item.CreatedBy.UserID, req.Item.Title)
+			if err != nil {
+				return err
+			}
+		}
+		// a category given by name is added to our DB if it does not already exist
+		req.Item.Category, err = resolveCategory(ctx, tx, req.Item.Category, req.Item.CreatedBy.UserID)
+		if err != nil {
+			return err
+		}
+		if chosen {
+			err = learnCategory(ctx, tx, req.Item.CreatedBy.UserID, req.Item.Title, req.Item.Category.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		// new items go to the end of the list
+		items, err := tx.GetListItems(ctx, req.Item.ListID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read items of list")
+		}
+		req.Item.Position = nextPosition(items)
+
+		// insert the new item
+		req.Item.Status = api.Todo
+		req.Item.LastModifiedBy.UserID = req.Item.CreatedBy.UserID
+		req.Item.CreatedAt = time.Now()
+		req.Item.LastModifiedAt = time.Now()
+		// an item without a deadline of its own is due with its list
+		if req.Item.Deadline.IsZero() {
+			req.Item.Deadline = list.Deadline
+		}
+		err = tx.CreateItem(ctx, &req.Item)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add new item")
 		}
-		lists = append(lists, list)
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
 
 	// Refresh user session
-	var uc api.UserContext
-	uc.UserID = req.UserID
-	uc.SessionToken = req.SessionToken
-	sessionToken, err := api.RefreshSessionContext(uc)
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
 	if err != nil {
-		sessionToken = req.SessionToken
+		return req.SessionToken, nil
 	}
-	return lists, sessionToken, nil
+	return sessionToken, nil
 }
 
-func processCreateItemRequest(ctx context.Context, db *sqlx.DB, req *api.CreateItemRequest) (string, error) {
-	// check user permission to edit the list
-	query := "select access_type from list_contributer where list=? and user=?"
-	err := checkListEditPermission(db, query, req.Item.ListID, req.Item.CreatedBy.UserID)
+func processUpdateItemRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.UpdateItemRequest) (string, error) {
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		item, err := tx.GetItem(ctx, req.ItemID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return errors.New(fmt.Sprintf("item %v does not exist", req.ItemID))
+			}
+			return errors.Wrapf(err, "failed to read item details")
+		}
+		list, err := tx.GetList(ctx, item.ListID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read list status")
+		}
+		if strings.Compare(list.Status, api.Deleted) == 0 {
+			return errors.New("items of a deleted list can not be edited")
+		}
+
+		if req.Title != nil {
+			item.Title = *req.Title
+		}
+		if req.Description != nil {
+			item.Description = *req.Description
+		}
+		if req.Deadline != nil {
+			item.Deadline = *req.Deadline
+		}
+		if req.Quantity != nil {
+			item.Quantity = *req.Quantity
+		}
+		if req.Unit != nil {
+			item.Unit = *req.Unit
+		}
+		if req.Category != nil {
+			item.Category, err = resolveCategory(ctx, tx, *req.Category, req.UserID)
+			if err != nil {
+				return err
+			}
+			err = learnCategory(ctx, tx, req.UserID, item.Title, item.Category.ID)
+			if err != nil {
+				return err
+			}
+		}
+		item.LastModifiedBy.UserID = req.UserID
+		item.LastModifiedAt = time.Now()
+		err = tx.UpdateItem(ctx, &item)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update item:%v", req.ItemID)
+		}
+		return nil
+	})
 	if err != nil {
 		return "", err
 	}
 
-	// begin a transaction
-	tx, err := db.Beginx()
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to begin transaction")
+		return req.SessionToken, nil
 	}
-	//check the list status
-	var listStatus string
-	err = tx.Get(&listStatus, "select status from list where id=?", req.Item.ListID)
-	if err != nil {
-		tx.Rollback()
-		if err == sql.ErrNoRows {
-			return "", errors.New("the mentioned list does not exist")
-		}
-		return "", errors.Wrapf(err, "error checking list status")
+	return sessionToken, nil
+}
+
+// resolveCategory returns the stored category given by id or, without an id, by
+// name. Whether the user may use a category given by id is checked by the
+// authorisation middleware, a category they name for the first time is added
+// to our DB as their own.
+func resolveCategory(ctx context.Context, tx store.Repository, category api.Category, userID int64) (api.Category, error) {
+	if category.ID != 0 {
+		return getCategory(ctx, tx, category.ID)
+	}
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return category, errors.New("category of the item is not given")
 	}
-	if strings.Compare(listStatus, api.Todo) != 0 {
-		tx.Rollback()
-		return "", errors.New(fmt.Sprintf("list status:%v should be %v", listStatus, api.Todo))
+	stored, found, err := findVisibleCategory(ctx, tx, userID, category.Name)
+	if err != nil || found {
+		return stored, err
+	}
+	category.OwnerID = userID
+	category.GroupID = 0
+	err = tx.CreateCategory(ctx, &category)
+	if err != nil {
+		return category, errors.Wrapf(err, "failed to add new category in DB")
 	}
+	return category, nil
+}
 
-	// Check if item category already exists in our DB
-	// add it to DB if does not already exist
-	if req.Item.Category.ID == 0 {
-		// this is a new category, add it to our DB
-		resp, err := tx.Exec("insert into category (name, type) values (?,?)", req.Item.Category.Name, req.Item.Category.Type)
-		if err != nil {
-			tx.Rollback()
-			return "", errors.Wrapf(err, "failed to add new category in DB")
+// getCategory returns the stored category with given id
+func getCategory(ctx context.Context, repo store.Repository, categoryID int64) (api.Category, error) {
+	category, err := repo.GetCategory(ctx, categoryID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return category, errors.New(fmt.Sprintf("category %v does not exist", categoryID))
 		}
-		req.Item.Category.ID, _ = resp.LastInsertId()
+		return category, errors.Wrapf(err, "failed to read category details")
 	}
+	return category, nil
+}
 
-	// insert the new item
-	query = "insert into item (list, title, description, status, category, created_by, last_modified_by, " +
-		"created_at, last_modified_at, deadline) values (?,?,?,?,?,?,?,?,?,?)"
-	_, err = tx.Exec(query, req.Item.ListID, req.Item.Title, req.Item.Description, api.Todo, req.Item.Category.ID, req.Item.CreatedBy.UserID,
-		req.Item.CreatedBy.UserID, time.Now(), time.Now(), time.Now().AddDate(1, 0, 0))
+// findVisibleCategory returns the category with given name among the ones user
+// sees, names are compared regardless of their case. The user's own category
+// comes before the one of a group, which comes before a system category.
+func findVisibleCategory(ctx context.Context, repo store.Repository, userID int64, name string) (api.Category, bool, error) {
+	categories, err := repo.GetVisibleCategories(ctx, userID)
 	if err != nil {
-		tx.Rollback()
-		return "", errors.Wrapf(err, "failed to add new item")
+		return api.Category{}, false, errors.Wrapf(err, "failed to read categories of user")
 	}
-	err = tx.Commit()
-	if err != nil {
-		return "", errors.Wrapf(err, "failed to commit transaction for creating item in list")
+	var (
+		found api.Category
+		rank  int
+	)
+	for _, category := range categories {
+		if !strings.EqualFold(category.Name, name) {
+			continue
+		}
+		r := 1
+		if category.OwnerID != 0 {
+			r = 3
+		} else if category.GroupID != 0 {
+			r = 2
+		}
+		if r > rank {
+			found, rank = category, r
+		}
 	}
+	return found, rank > 0, nil
+}
 
-	// Refresh user session
-	var uc api.UserContext
-	uc.UserID = req.Item.CreatedBy.UserID
-	uc.SessionToken = req.SessionToken
-	sessionToken, err := api.RefreshSessionContext(uc)
+// mergeItem adds the quantity of the requested item to a todo item of the list with
+// the same title and a compatible unit. It reports false if there is no such item.
+func mergeItem(ctx context.Context, tx store.Repository, req *api.CreateItemRequest) (bool, error) {
+	items, err := tx.GetListItems(ctx, req.Item.ListID)
 	if err != nil {
-		return req.SessionToken, nil
+		return false, errors.Wrapf(err, "failed to read items of list to merge with")
 	}
-	return sessionToken, nil
+	quantity, unit := itemQuantity(req.Item)
+	for _, item := range items {
+		if item.Status != api.Todo || !sameItemTitle(item.Title, req.Item.Title) {
+			continue
+		}
+		existingQuantity, existingUnit := itemQuantity(item)
+		sum, err := addQuantities(existingQuantity, existingUnit, quantity, unit)
+		if err != nil {
+			// units measure different things, keep looking
+			continue
+		}
+		item.Quantity = sum
+		item.Unit = existingUnit
+		// the merged item is due when the earlier of the two is
+		if !req.Item.Deadline.IsZero() && req.Item.Deadline.Before(item.Deadline) {
+			item.Deadline = req.Item.Deadline
+		}
+		item.LastModifiedBy.UserID = req.Item.CreatedBy.UserID
+		item.LastModifiedAt = time.Now()
+		err = tx.UpdateItem(ctx, &item)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to merge item into item:%v", item.ID)
+		}
+		req.Item = item
+		return true, nil
+	}
+	return false, nil
 }
 
-func processGetListItemsRequest(ctx context.Context, db *sqlx.DB, req *api.GetListItemsRequest) ([]api.Item, string, error) {
+func processGetListItemsRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.GetListItemsRequest) ([]api.Item, []api.ItemSection, string, error) {
 	var items []api.Item
 
-	// begin a transaction
-	tx, err := db.Beginx()
+	// read items from give list, deleted items are only shown in the trash
+	items, err := repo.GetListItems(ctx, req.ListID)
 	if err != nil {
-		return items, "", errors.Wrapf(err, "failed to begin a transaction for get list")
+		return items, nil, "", errors.Wrapf(err, "failed to read items for given list")
 	}
-	// check if current user have read permission for given list
-	var id int64
-	err = tx.Get(&id, "select id from list_contributer where user=? and list=?", req.UserID, req.ListID)
-	if err != nil {
-		tx.Rollback()
-		if err == sql.ErrNoRows {
-			return items, "", errors.New("current user does not have read access for list")
+	items = filterItems(items, func(item api.Item) bool {
+		return strings.Compare(item.Status, api.Deleted) != 0
+	})
+
+	var sections []api.ItemSection
+	if strings.Compare(req.View, api.ShoppingView) == 0 {
+		layout, err := listLayout(ctx, repo, req.ListID)
+		if err != nil {
+			return items, nil, "", err
 		}
-		return items, "", errors.Wrapf(err, "failed to check list-users connection")
+		items, sections = shoppingSections(items, layout)
 	}
 
-	// read items from give list
-	query := "select id, list, title, description, status, category, created_by, last_modified_by, bought_by, created_at," +
-		" last_modified_at, bought_at, deadline from item where list=?"
-	resp, err := db.Query(query, req.ListID)
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
 	if err != nil {
-		tx.Rollback()
-		return items, "", errors.Wrapf(err, "failed to read items for given list")
+		sessionToken = req.SessionToken
 	}
-	defer resp.Close()
-	for resp.Next() {
-		var item api.Item
-		var boughtBy, boughtAt interface{}
-		resp.Scan(&item.ID, &item.ListID, &item.Title, &item.Description, &item.Status, &item.Category.ID, &item.CreatedBy.UserID, &item.LastModifiedBy.UserID,
-			&boughtBy, &item.CreatedAt, &item.LastModifiedAt, &boughtAt, &item.Deadline)
-		if boughtBy != nil {
-			item.BoughtBy.UserID = boughtBy.(int64)
-		}
-		if boughtAt != nil {
-			item.BoughtAt = boughtAt.(time.Time)
-		}
-		err = tx.Get(&item.CreatedBy.UserName, "select username from users where id=?", item.CreatedBy.UserID)
+	return items, sections, sessionToken, nil
+}
+
+func processBuyItemRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.BuyItemRequest) (string, error) {
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		item, err := tx.GetItem(ctx, req.ItemID)
 		if err != nil {
-			tx.Rollback()
-			return items, "", errors.Wrapf(err, "failed to read username off item creator")
+			if store.IsNotFound(err) {
+				return errors.New(fmt.Sprintf("item %v does not exist", req.ItemID))
+			}
+			return errors.Wrapf(err, "failed to read item details")
 		}
-		err = tx.Get(&item.LastModifiedBy.UserName, "select username from users where id=?", item.LastModifiedBy.UserID)
+		// check list status and item status
+		list, err := tx.GetList(ctx, item.ListID)
 		if err != nil {
-			tx.Rollback()
-			return items, "", errors.Wrapf(err, "failed to read username off latest item modifier")
+			if store.IsNotFound(err) {
+				return errors.New("the mentioned list does not exist")
+			}
+			return errors.Wrapf(err, "failed to read list status")
+		}
+		if strings.Compare(list.Status, api.Todo) != 0 {
+			return errors.New(fmt.Sprintf("list is in %v state, need in todo state", list.Status))
+		}
+		if list.Template {
+			return errors.New("items of a template can not be bought")
 		}
-		err = tx.Get(&item.Category, "select id, name, type from category where id=?", item.Category.ID)
+
+		// mark item as bought
+		buyer, err := tx.GetUserByName(ctx, req.UserName)
 		if err != nil {
-			tx.Rollback()
-			return items, "", errors.Wrapf(err, "failed to read category details of item")
+			if store.IsNotFound(err) {
+				return errors.New(fmt.Sprintf("given buyer username %v is not a registered user", req.UserName))
+			}
+			return errors.Wrapf(err, "failed to read user details for buyer")
 		}
-		if item.BoughtBy.UserID == 0 {
-			items = append(items, item)
-			continue
+		err = applyItemTransition(&item, itemBuy, req.UserID)
+		if err != nil {
+			return err
 		}
-		err = tx.Get(&item.BoughtBy.UserName, "select username from users where id=?", item.BoughtBy.UserID)
+		item.BoughtBy.UserID = buyer.ID
+		item.BoughtAt = time.Now()
+		err = tx.UpdateItem(ctx, &item)
 		if err != nil {
-			tx.Rollback()
-			return items, "", errors.Wrapf(err, "failed to read username off item buyer")
+			return errors.Wrapf(err, "failed to mark item as bought in DB")
 		}
-		items = append(items, item)
+		return touchList(ctx, tx, list, item.LastModifiedAt)
+	})
+	if err != nil {
+		return "", err
 	}
-	tx.Commit()
+
 	// Refresh user session
-	var uc api.UserContext
-	uc.UserID = req.UserID
-	uc.SessionToken = req.SessionToken
-	sessionToken, err := api.RefreshSessionContext(uc)
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
 	if err != nil {
-		sessionToken = req.SessionToken
+		return req.SessionToken, nil
 	}
-	return items, sessionToken, nil
+	return sessionToken, nil
 }
 
-func processBuyItemRequest(ctx context.Context, db *sqlx.DB, req *api.BuyItemRequest) (string, error) {
-	// check if current user had write access to item list
-	var (
-		listAccessType string
-		itemStatus     string
-		listID         int64
-	)
-	err := db.QueryRow("select lc.access_type, i.status, i.list from list_contributer lc, item i "+
-		"where i.id=? and lc.user=? and lc.list=i.list", req.ItemID, req.UserID).Scan(&listAccessType, &itemStatus, &listID)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return "", errors.New("unauthorised access, user does not have permission to edit the list item belongs to")
+func processShareListRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.ShareListRequest) (string, error) {
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		list, err := getListWithRole(ctx, tx, req.ListID, req.UserID)
+		if err != nil {
+			return err
+		}
+		err = authoriseGrant(list.AccessType, req.AccessType)
+		if err != nil {
+			return err
 		}
-		return "", errors.Wrapf(err, "failed to read user permission to edit list")
-	}
-	if strings.Compare(listAccessType, api.Edit) != 0 {
-		return "", errors.New("unauthorised access, user have read only permission for list item belongs to")
-	}
 
-	// begin a db transaction
-	tx, err := db.Beginx()
+		// share the list
+		user, err := tx.GetUserByName(ctx, req.UserName)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return errors.New(fmt.Sprintf("user %v is not registered", req.UserName))
+			}
+			return errors.Wrapf(err, "failed to read user details")
+		}
+		// re-sharing replaces the access the user holds, only the owner can take away the manager role
+		existing, err := tx.GetContributor(ctx, list.ID, user.ID)
+		if err == nil {
+			err = authoriseGrant(list.AccessType, existing.AccessType)
+			if err != nil {
+				return err
+			}
+		} else if !store.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to check list-users connection")
+		}
+		return grantAccess(ctx, tx, list, user.ID, req.AccessType, req.ValidUntil)
+	})
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to begin a db transaction for buy item")
+		return "", err
 	}
 
-	// check list status and item status
-	var listStatus string
-	err = tx.Get(&listStatus, "select status from list where id=?", listID)
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
 	if err != nil {
-		tx.Rollback()
-		if err == sql.ErrNoRows {
-			return "", errors.New("the mentioned list does not exist")
-		}
-		return "", errors.Wrapf(err, "failed to read list status")
-	}
-	if strings.Compare(listStatus, api.Todo) != 0 {
-		tx.Rollback()
-		return "", errors.New(fmt.Sprintf("list is in %v state, need in todo state", itemStatus))
-	}
-	if strings.Compare(itemStatus, api.Todo) != 0 {
-		tx.Rollback()
-		return "", errors.New(fmt.Sprintf("item is in %v state, need in todo state", itemStatus))
+		return req.SessionToken, nil
 	}
+	return sessionToken, nil
+}
 
-	// mark item as bought
-	var boughtBy api.User
-	boughtBy.UserName = req.UserName
-	err = tx.Get(&boughtBy.UserID, "select id from users where username=?", req.UserName)
+func processGetAllCategoriesRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.GetAllCategoriesRequest) ([]api.Category, string, error) {
+	var categories []api.Category
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
 	if err != nil {
-		tx.Rollback()
-		if err == sql.ErrNoRows {
-			return "", errors.New(fmt.Sprintf("given buyer username %v is not a registered user", req.UserName))
-		}
-		return "", errors.Wrapf(err, "failed to read user details for buyer")
+		return categories, req.SessionToken, nil
 	}
-	_, err = tx.Exec("update item set status=?, last_modified_by=?, bought_by=?, last_modified_at=?, bought_at=? where id=?",
-		api.Bought, req.UserID, boughtBy.UserID, time.Now(), time.Now(), req.ItemID)
+
+	categories, err = repo.GetVisibleCategories(ctx, req.UserID)
 	if err != nil {
-		tx.Rollback()
-		return "", errors.Wrapf(err, "failed to mark item as bought in DB")
+		return categories, sessionToken, errors.Wrapf(err, "failed to read categories from system")
 	}
-	err = tx.Commit()
+	return categories, sessionToken, nil
+}
+
+func processDeleteListRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.DeleteListRequest) (string, error) {
+	// mark the list as deleted, it stays in the trash until it is purged
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		list, err := tx.GetList(ctx, req.ListID)
+		if err != nil {
+			return err
+		}
+		if strings.Compare(list.Status, api.Deleted) == 0 {
+			return errors.New("list is already deleted")
+		}
+		list.Status = api.Deleted
+		list.LastModifiedAt = time.Now()
+		list.DeletedAt = list.LastModifiedAt
+		return tx.UpdateList(ctx, &list)
+	})
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to commit transacton for buy item")
+		return "", errors.Wrapf(err, "failed to mark list:%v as deleted", req.ListID)
 	}
-	// TODO update list's last_modified_at field after the item deletion
 
 	// Refresh user session
-	var uc api.UserContext
-	uc.UserID = req.UserID
-	uc.SessionToken = req.SessionToken
-	sessionToken, err := api.RefreshSessionContext(uc)
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
 	if err != nil {
 		return req.SessionToken, nil
 	}
 	return sessionToken, nil
 }
 
-func processShareListRequest(ctx context.Context, db *sqlx.DB, req *api.ShareListRequest) (string, error) {
-	tx, err := db.Beginx()
+func processDeleteItemRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.DeleteItemRequest) (string, error) {
+	// mark the item as deleted
+	err := changeItemState(ctx, repo, req.ItemID, req.UserID, itemDelete)
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to start db transaction for share list")
+		return "", errors.Wrapf(err, "failed to mark item:%v as deleted", req.ItemID)
 	}
-	// check if the current user is owner of the list to be shared
-	var owner int64
-	err = tx.Get(&owner, "select owner from list where id=?", req.ListID)
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
 	if err != nil {
-		tx.Rollback()
-		if err == sql.ErrNoRows {
-			return "", errors.New(fmt.Sprintf("list %v does not exist", req.ListID))
-		}
-		return "", errors.Wrapf(err, "failed to read list details")
-	}
-	if owner != req.UserID {
-		tx.Rollback()
-		return "", errors.New("unauthorised access, only list owner can share the list")
+		return req.SessionToken, nil
 	}
+	return sessionToken, nil
+}
 
-	// share the list
-	var uid int64
-	err = tx.Get(&uid, "select id from users where username=?", req.UserName)
+func processUnbuyItemRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.UnbuyItemRequest) (string, error) {
+	// move the item back to todo
+	err := changeItemState(ctx, repo, req.ItemID, req.UserID, itemUnbuy)
 	if err != nil {
-		tx.Rollback()
-		if err == sql.ErrNoRows {
-			return "", errors.New(fmt.Sprintf("user %v is not registered", req.UserName))
-		}
-		return "", errors.Wrapf(err, "failed to read user details")
+		return "", errors.Wrapf(err, "failed to mark item:%v as not bought", req.ItemID)
 	}
-	_, err = tx.Exec("insert into list_contributer (list, user, access_type, valid_until) values (?,?,?,?)",
-		req.ListID, uid, req.AccessType, time.Now().AddDate(1, 0, 0))
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
 	if err != nil {
-		tx.Rollback()
-		return "", errors.Wrapf(err, "failed to make an entry in list_contributor table")
+		return req.SessionToken, nil
 	}
-	err = tx.Commit()
+	return sessionToken, nil
+}
+
+func processRestoreItemRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.RestoreItemRequest) (string, error) {
+	// move the item back to todo
+	err := changeItemState(ctx, repo, req.ItemID, req.UserID, itemRestore)
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to commit db transaction for share list")
+		return "", errors.Wrapf(err, "failed to restore item:%v", req.ItemID)
 	}
 
 	// Refresh user session
-	var uc api.UserContext
-	uc.UserID = req.UserID
-	uc.SessionToken = req.SessionToken
-	sessionToken, err := api.RefreshSessionContext(uc)
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
 	if err != nil {
 		return req.SessionToken, nil
 	}
 	return sessionToken, nil
 }
 
-func processGetAllCategoriesRequest(ctx context.Context, db *sqlx.DB, req *api.GetAllCategoriesRequest) ([]api.Category, string, error) {
-	var categories []api.Category
-	// Refresh user session
-	var uc api.UserContext
-	uc.UserID = req.UserID
-	uc.SessionToken = req.SessionToken
-	sessionToken, err := api.RefreshSessionContext(uc)
+// changeItemState applies given transition to an item on behalf of a user
+func changeItemState(ctx context.Context, repo store.Repository, itemID int64, userID int64, transition string) error {
+	return repo.Tx(ctx, func(tx store.Repository) error {
+		// the state is checked against the item as it is stored now
+		item, err := tx.GetItem(ctx, itemID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return errors.New(fmt.Sprintf("item %v does not exist", itemID))
+			}
+			return errors.Wrapf(err, "failed to read item details")
+		}
+		list, err := tx.GetList(ctx, item.ListID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read list status")
+		}
+		if strings.Compare(list.Status, api.Deleted) == 0 {
+			return errors.New("items of a deleted list can not be changed")
+		}
+		err = applyItemTransition(&item, transition, userID)
+		if err != nil {
+			return err
+		}
+		err = tx.UpdateItem(ctx, &item)
+		if err != nil {
+			return errors.Wrapf(err, "failed to %v item:%v", transition, itemID)
+		}
+		return touchList(ctx, tx, list, item.LastModifiedAt)
+	})
+}
+
+// touchList records that list changed at given time, such as when one of its
+// items was bought, deleted or restored
+func touchList(ctx context.Context, tx store.Repository, list api.List, at time.Time) error {
+	list.LastModifiedAt = at
+	err := tx.UpdateList(ctx, &list)
 	if err != nil {
-		return categories, req.SessionToken, nil
+		return errors.Wrapf(err, "failed to update list:%v", list.ID)
 	}
+	return nil
+}
 
-	resp, err := db.Query("select id, name, type from category")
+func processGetTrashRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.GetTrashRequest) ([]api.List, []api.Item, string, error) {
+	var (
+		lists []api.List
+		items []api.Item
+	)
+	userLists, err := repo.GetListsForUser(ctx, req.UserID)
 	if err != nil {
-		return categories, sessionToken, errors.Wrapf(err, "failed to read categories from system")
+		return lists, items, "", errors.Wrapf(err, "failed to query DB for given user's lists")
 	}
-	defer resp.Close()
-	for resp.Next() {
-		var category api.Category
-		resp.Scan(&category.ID, &category.Name, &category.Type)
-		categories = append(categories, category)
+	for _, list := range userLists {
+		role, err := listRole(ctx, repo, list, req.UserID)
+		if err != nil {
+			return lists, items, "", err
+		}
+		// only what user is allowed to restore is shown
+		if strings.Compare(list.Status, api.Deleted) == 0 {
+			if !api.RoleAllows(role, requiredRoles[actionDeleteList]) {
+				continue
+			}
+			list.CreatedByMe = list.Owner.UserID == req.UserID
+			list.AccessType = role
+			lists = append(lists, list)
+			continue
+		}
+		if !api.RoleAllows(role, requiredRoles[actionEditItems]) {
+			continue
+		}
+		// the items of a deleted list go with the list itself
+		listItems, err := repo.GetListItems(ctx, list.ID)
+		if err != nil {
+			return lists, items, "", errors.Wrapf(err, "failed to read items for list:%v", list.ID)
+		}
+		items = append(items, filterItems(listItems, func(item api.Item) bool {
+			return strings.Compare(item.Status, api.Deleted) == 0
+		})...)
 	}
-	return categories, sessionToken, nil
-}
 
-func processDeleteListRequest(ctx context.Context, db *sqlx.DB, req *api.DeleteListRequest) (string, error) {
 	// Refresh user session
-	var uc api.UserContext
-	uc.UserID = req.UserID
-	uc.SessionToken = req.SessionToken
-	sessionToken, err := api.RefreshSessionContext(uc)
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
 	if err != nil {
-		return req.SessionToken, nil
+		sessionToken = req.SessionToken
 	}
+	return lists, items, sessionToken, nil
+}
 
-	// check if user has edit permission for list
-	query := "select access_type from list_contributer where list=? and user=?"
-	err = checkListEditPermission(db, query, req.ListID, req.UserID)
+func processRestoreListRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.RestoreListRequest) (string, error) {
+	// move the list out of the trash
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		list, err := tx.GetList(ctx, req.ListID)
+		if err != nil {
+			return err
+		}
+		if strings.Compare(list.Status, api.Deleted) != 0 {
+			return errors.New("only deleted lists can be restored")
+		}
+		list.Status = api.Todo
+		list.LastModifiedAt = time.Now()
+		list.DeletedAt = time.Time{}
+		return tx.UpdateList(ctx, &list)
+	})
 	if err != nil {
-		return sessionToken, err
+		return "", errors.Wrapf(err, "failed to restore list:%v", req.ListID)
 	}
 
-	// mark the list as deleted
-	_, err = db.Exec("update list set status=? where id=?", api.Deleted, req.ListID)
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
 	if err != nil {
-		return sessionToken, errors.Wrapf(err, "failed to mark list:%v as deleted", req.ListID)
+		return req.SessionToken, nil
 	}
-
 	return sessionToken, nil
 }
 
-func processDeleteItemRequest(ctx context.Context, db *sqlx.DB, req *api.DeleteItemRequest) (string, error) {
-	// Refresh user session
-	var uc api.UserContext
-	uc.UserID = req.UserID
-	uc.SessionToken = req.SessionToken
-	sessionToken, err := api.RefreshSessionContext(uc)
+// filterLists returns the lists keep reports true for
+func filterLists(lists []api.List, keep func(api.List) bool) []api.List {
+	var kept []api.List
+	for _, list := range lists {
+		if keep(list) {
+			kept = append(kept, list)
+		}
+	}
+	return kept
+}
+
+// filterItems returns the items keep reports true for
+func filterItems(items []api.Item, keep func(api.Item) bool) []api.Item {
+	var kept []api.Item
+	for _, item := range items {
+		if keep(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+func processGetContributorsRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.GetContributorsRequest) ([]api.Contributor, []api.GroupAccess, string, error) {
+	var (
+		contributors []api.Contributor
+		groups       []api.GroupAccess
+	)
+	list, err := repo.GetList(ctx, req.ListID)
 	if err != nil {
-		return req.SessionToken, nil
+		return contributors, groups, "", errors.Wrapf(err, "failed to read details of list:%v", req.ListID)
 	}
 
-	// check if user has edit permissions for list
-	query := "select access_type from list_contributer where user=? and list in (select list from item where id=?)"
-	err = checkListEditPermission(db, query, req.UserID, req.ItemID)
+	stored, err := repo.GetListContributors(ctx, req.ListID)
 	if err != nil {
-		return sessionToken, err
+		return contributors, groups, "", errors.Wrapf(err, "failed to read contributors of list:%v", req.ListID)
+	}
+	for _, c := range stored {
+		user, err := repo.GetUserByID(ctx, c.UserID)
+		if err != nil {
+			return contributors, groups, "", errors.Wrapf(err, "failed to read details of contributor:%v", c.UserID)
+		}
+		role := api.NormaliseRole(c.AccessType)
+		if c.UserID == list.Owner.UserID {
+			role = api.RoleOwner
+		}
+		contributors = append(contributors, api.Contributor{
+			UserID:     c.UserID,
+			UserName:   user.UserName,
+			AccessType: role,
+			ValidUntil: c.ValidUntil,
+		})
 	}
 
-	// mark the item as deleted
-	_, err = db.Exec("update item set status=? where id=?", api.Deleted, req.ItemID)
+	// the list can also be shared with groups
+	listGroups, err := repo.GetListGroups(ctx, req.ListID)
 	if err != nil {
-		return sessionToken, errors.Wrapf(err, "failed to mark item:%v as deleted", req.ItemID)
+		return contributors, groups, "", errors.Wrapf(err, "failed to read groups of list:%v", req.ListID)
+	}
+	for _, lg := range listGroups {
+		group, err := repo.GetGroup(ctx, lg.GroupID)
+		if err != nil {
+			return contributors, groups, "", errors.Wrapf(err, "failed to read details of group:%v", lg.GroupID)
+		}
+		groups = append(groups, api.GroupAccess{GroupID: group.ID, Name: group.Name, AccessType: api.NormaliseRole(lg.AccessType)})
 	}
 
-	return sessionToken, nil
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return contributors, groups, sessionToken, nil
+}
+
+func processUpdateContributorRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.UpdateContributorRequest) (string, error) {
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		contributor, err := getListContributor(ctx, tx, req.ListID, req.UserID, req.ContributorID)
+		if err != nil {
+			return err
+		}
+		list, err := tx.GetList(ctx, req.ListID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read list details")
+		}
+		role, err := listRole(ctx, tx, list, req.UserID)
+		if err != nil {
+			return err
+		}
+		err = authoriseGrant(role, req.AccessType)
+		if err != nil {
+			return err
+		}
+		contributor.AccessType = req.AccessType
+		return tx.UpdateContributor(ctx, &contributor)
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to change access of user:%v on list:%v", req.ContributorID, req.ListID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		return req.SessionToken, nil
+	}
+	return sessionToken, nil
+}
+
+func processRevokeContributorRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.RevokeContributorRequest) (string, error) {
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		contributor, err := getListContributor(ctx, tx, req.ListID, req.UserID, req.ContributorID)
+		if err != nil {
+			return err
+		}
+		return tx.RemoveContributor(ctx, contributor.ID)
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to revoke access of user:%v on list:%v", req.ContributorID, req.ListID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		return req.SessionToken, nil
+	}
+	return sessionToken, nil
+}
+
+// grantAccess shares list with given user, sharing again with a contributor
+// updates their access
+func grantAccess(ctx context.Context, tx store.Repository, list api.List, userID int64, accessType string, validUntil time.Time) error {
+	if userID == list.Owner.UserID {
+		return errors.New("list can not be shared with its owner")
+	}
+	contributor, err := tx.GetContributor(ctx, list.ID, userID)
+	if err == nil {
+		contributor.AccessType = accessType
+		contributor.ValidUntil = validUntil
+		err = tx.UpdateContributor(ctx, &contributor)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update the entry in list_contributor table")
+		}
+		return nil
+	}
+	if !store.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to check list-users connection")
+	}
+	err = tx.AddContributor(ctx, &store.Contributor{
+		ListID:     list.ID,
+		UserID:     userID,
+		AccessType: accessType,
+		ValidUntil: validUntil,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to make an entry in list_contributor table")
+	}
+	return nil
+}
+
+// getListContributor returns the access contributorID has on a list userID
+// shares, the owner's own access can not be managed and only the owner can
+// manage the managers
+func getListContributor(ctx context.Context, repo store.Repository, listID int64, userID int64, contributorID int64) (store.Contributor, error) {
+	list, err := getListWithRole(ctx, repo, listID, userID)
+	if err != nil {
+		return store.Contributor{}, err
+	}
+	if contributorID == list.Owner.UserID {
+		return store.Contributor{}, errors.New("access of the list owner can not be changed")
+	}
+	contributor, err := repo.GetContributor(ctx, listID, contributorID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return contributor, errors.New(fmt.Sprintf("list %v is not shared with user %v", listID, contributorID))
+		}
+		return contributor, errors.Wrapf(err, "failed to check list-users connection")
+	}
+	err = authoriseGrant(list.AccessType, contributor.AccessType)
+	if err != nil {
+		return contributor, err
+	}
+	return contributor, nil
+}
+
+func processCreateInviteRequest(ctx context.Context, repo store.Repository, sessions session.Store, secret []byte, req *api.CreateInviteRequest) (api.Invite, string, error) {
+	list, err := getListWithRole(ctx, repo, req.ListID, req.UserID)
+	if err != nil {
+		return api.Invite{}, "", err
+	}
+	err = authoriseGrant(list.AccessType, req.AccessType)
+	if err != nil {
+		return api.Invite{}, "", err
+	}
+	if strings.Compare(list.Status, api.Deleted) == 0 {
+		return api.Invite{}, "", errors.New("users can not be invited to a deleted list")
+	}
+
+	invite := store.Invite{
+		ListID:     req.ListID,
+		AccessType: req.AccessType,
+		Status:     api.InvitePending,
+		CreatedBy:  req.UserID,
+		CreatedAt:  time.Now(),
+		ExpiresAt:  req.ExpiresAt,
+	}
+	if invite.ExpiresAt.IsZero() {
+		invite.ExpiresAt = invite.CreatedAt.Add(defaultInviteTTL)
+	}
+	// the token signs the expiry with second precision
+	invite.ExpiresAt = invite.ExpiresAt.Truncate(time.Second)
+	err = repo.CreateInvite(ctx, &invite)
+	if err != nil {
+		return api.Invite{}, "", errors.Wrapf(err, "failed to create invitation to list:%v", req.ListID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return toAPIInvite(secret, invite), sessionToken, nil
+}
+
+func processGetInvitesRequest(ctx context.Context, repo store.Repository, sessions session.Store, secret []byte, req *api.GetInvitesRequest) ([]api.Invite, string, error) {
+	var invites []api.Invite
+	stored, err := repo.GetListInvites(ctx, req.ListID)
+	if err != nil {
+		return invites, "", errors.Wrapf(err, "failed to read invitations to list:%v", req.ListID)
+	}
+	now := time.Now()
+	for _, invite := range stored {
+		// only the invitations that can still be accepted are pending
+		if strings.Compare(invite.Status, api.InvitePending) != 0 || !now.Before(invite.ExpiresAt) {
+			continue
+		}
+		invites = append(invites, toAPIInvite(secret, invite))
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return invites, sessionToken, nil
+}
+
+func processCancelInviteRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.CancelInviteRequest) (string, error) {
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		list, err := getListWithRole(ctx, tx, req.ListID, req.UserID)
+		if err != nil {
+			return err
+		}
+		invite, err := tx.GetInvite(ctx, req.InviteID)
+		if err != nil || invite.ListID != req.ListID {
+			if err == nil || store.IsNotFound(err) {
+				return errors.New(fmt.Sprintf("invitation %v to list %v does not exist", req.InviteID, req.ListID))
+			}
+			return errors.Wrapf(err, "failed to read invitation details")
+		}
+		if strings.Compare(invite.Status, api.InvitePending) != 0 {
+			return errors.New(fmt.Sprintf("invitation is already %v", invite.Status))
+		}
+		err = authoriseGrant(list.AccessType, invite.AccessType)
+		if err != nil {
+			return err
+		}
+		invite.Status = api.InviteCancelled
+		return tx.UpdateInvite(ctx, &invite)
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to cancel invitation:%v", req.InviteID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		return req.SessionToken, nil
+	}
+	return sessionToken, nil
+}
+
+func processAcceptInviteRequest(ctx context.Context, repo store.Repository, sessions session.Store, secret []byte, req *api.AcceptInviteRequest) (int64, string, error) {
+	inviteID, err := inviteIDFromToken(req.Token)
+	if err != nil {
+		return 0, "", err
+	}
+
+	var listID int64
+	err = repo.Tx(ctx, func(tx store.Repository) error {
+		invite, err := tx.GetInvite(ctx, inviteID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return errors.New("unauthorised access, invalid invitation token")
+			}
+			return errors.Wrapf(err, "failed to read invitation details")
+		}
+		err = verifyInviteToken(secret, invite, req.Token)
+		if err != nil {
+			return err
+		}
+		if strings.Compare(invite.Status, api.InvitePending) != 0 {
+			return errors.New(fmt.Sprintf("invitation is already %v", invite.Status))
+		}
+		if !time.Now().Before(invite.ExpiresAt) {
+			return errors.New("invitation has expired")
+		}
+		list, err := tx.GetList(ctx, invite.ListID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read list details")
+		}
+		if strings.Compare(list.Status, api.Deleted) == 0 {
+			return errors.New("list of the invitation has been deleted")
+		}
+		// the owner already holds every right, the invitation is left for the one it was sent to
+		if list.Owner.UserID == req.UserID {
+			return errors.New("list owner can not accept an invitation to the list they own")
+		}
+
+		// an invitation never weakens access the user already holds, a grant at
+		// least as strong as the invited role is kept with its expiry
+		existing, err := tx.GetContributor(ctx, list.ID, req.UserID)
+		if err != nil && !store.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to check list-users connection")
+		}
+		if err != nil || existing.Expired(time.Now()) || !api.RoleAllows(api.NormaliseRole(existing.AccessType), invite.AccessType) {
+			err = grantAccess(ctx, tx, list, req.UserID, invite.AccessType, time.Time{})
+			if err != nil {
+				return err
+			}
+		}
+
+		// the invitation is used up once it is accepted
+		invite.Status = api.InviteAccepted
+		invite.AcceptedBy = req.UserID
+		invite.AcceptedAt = time.Now()
+		err = tx.UpdateInvite(ctx, &invite)
+		if err != nil {
+			return errors.Wrapf(err, "failed to mark invitation as accepted")
+		}
+		listID = list.ID
+		return nil
+	})
+	if err != nil {
+		return 0, "", errors.Wrapf(err, "failed to accept invitation")
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return listID, sessionToken, nil
+}
+
+func processTransferOwnershipRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.TransferOwnershipRequest) (string, error) {
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		list, err := tx.GetList(ctx, req.ListID)
+		if err != nil {
+			return err
+		}
+		if strings.Compare(list.Status, api.Deleted) == 0 {
+			return errors.New("ownership of a deleted list can not be transferred")
+		}
+		user, err := tx.GetUserByName(ctx, req.UserName)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return errors.New(fmt.Sprintf("user %v is not registered", req.UserName))
+			}
+			return errors.Wrapf(err, "failed to read user details")
+		}
+		if user.ID == list.Owner.UserID {
+			return errors.New(fmt.Sprintf("user %v already owns the list", req.UserName))
+		}
+
+		// only a contributor who can edit the items can take over the list
+		role, err := listRole(ctx, tx, list, user.ID)
+		if err != nil {
+			return err
+		}
+		if role == "" {
+			return errors.New(fmt.Sprintf("list is not shared with user %v", req.UserName))
+		}
+		if !api.RoleAllows(role, api.RoleEditor) {
+			return errors.New(fmt.Sprintf("user %v can not edit the items of the list", req.UserName))
+		}
+		// the access of an owner does not expire or depend on their groups
+		err = grantAccess(ctx, tx, list, user.ID, api.RoleEditor, time.Time{})
+		if err != nil {
+			return err
+		}
+
+		transfer := store.OwnershipTransfer{ListID: list.ID, FromUserID: list.Owner.UserID, ToUserID: user.ID, TransferredAt: time.Now()}
+		err = tx.CreateOwnershipTransfer(ctx, &transfer)
+		if err != nil {
+			return errors.Wrapf(err, "failed to record transfer of the list")
+		}
+		list.Owner.UserID = user.ID
+		list.LastModifiedAt = transfer.TransferredAt
+		err = tx.UpdateList(ctx, &list)
+		if err != nil {
+			return errors.Wrapf(err, "failed to change owner of the list")
+		}
+
+		// the old owner stays on the list as an editor
+		return grantAccess(ctx, tx, list, req.UserID, api.RoleEditor, time.Time{})
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to transfer ownership of list:%v", req.ListID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		return req.SessionToken, nil
+	}
+	return sessionToken, nil
+}
+
+// getGroupMember returns the membership of user in a group, only members can see a group
+func getGroupMember(ctx context.Context, repo store.Repository, groupID int64, userID int64) (store.GroupMember, error) {
+	member, err := repo.GetGroupMember(ctx, groupID, userID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return member, errors.New(fmt.Sprintf("unauthorised access, user is not a member of group %v", groupID))
+		}
+		return member, errors.Wrapf(err, "failed to check group membership")
+	}
+	return member, nil
+}
+
+// checkGroupKeepsAdmin makes sure a group is left with an admin when member
+// stops being one
+func checkGroupKeepsAdmin(ctx context.Context, repo store.Repository, member store.GroupMember) error {
+	if strings.Compare(member.Role, api.GroupAdminRole) != 0 {
+		return nil
+	}
+	members, err := repo.GetGroupMembers(ctx, member.GroupID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read group members")
+	}
+	for _, m := range members {
+		if m.ID != member.ID && strings.Compare(m.Role, api.GroupAdminRole) == 0 {
+			return nil
+		}
+	}
+	return errors.New("the last admin of a group can not be removed")
+}
+
+func processCreateGroupRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.CreateGroupRequest) (api.Group, string, error) {
+	group := store.Group{
+		Name:      strings.TrimSpace(req.Name),
+		CreatedBy: req.UserID,
+		CreatedAt: time.Now(),
+	}
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		err := tx.CreateGroup(ctx, &group)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create group")
+		}
+		// the creator of the group administers it
+		err = tx.AddGroupMember(ctx, &store.GroupMember{GroupID: group.ID, UserID: req.UserID, Role: api.GroupAdminRole})
+		if err != nil {
+			return errors.Wrapf(err, "failed to add creator to the group")
+		}
+		return nil
+	})
+	if err != nil {
+		return api.Group{}, "", err
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return api.Group{ID: group.ID, Name: group.Name, Role: api.GroupAdminRole, CreatedAt: group.CreatedAt}, sessionToken, nil
+}
+
+func processGetGroupsRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.GetGroupsRequest) ([]api.Group, string, error) {
+	var groups []api.Group
+	memberships, err := repo.GetUserGroups(ctx, req.UserID)
+	if err != nil {
+		return groups, "", errors.Wrapf(err, "failed to read groups of user")
+	}
+	for _, member := range memberships {
+		group, err := repo.GetGroup(ctx, member.GroupID)
+		if err != nil {
+			return groups, "", errors.Wrapf(err, "failed to read details of group:%v", member.GroupID)
+		}
+		groups = append(groups, api.Group{ID: group.ID, Name: group.Name, Role: member.Role, CreatedAt: group.CreatedAt})
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return groups, sessionToken, nil
+}
+
+func processGetGroupMembersRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.GetGroupMembersRequest) ([]api.GroupMember, string, error) {
+	var members []api.GroupMember
+	stored, err := repo.GetGroupMembers(ctx, req.GroupID)
+	if err != nil {
+		return members, "", errors.Wrapf(err, "failed to read members of group:%v", req.GroupID)
+	}
+	for _, member := range stored {
+		user, err := repo.GetUserByID(ctx, member.UserID)
+		if err != nil {
+			return members, "", errors.Wrapf(err, "failed to read details of member:%v", member.UserID)
+		}
+		members = append(members, api.GroupMember{UserID: user.ID, UserName: user.UserName, Role: member.Role})
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return members, sessionToken, nil
+}
+
+func processAddGroupMemberRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.AddGroupMemberRequest) (string, error) {
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		user, err := tx.GetUserByName(ctx, req.UserName)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return errors.New(fmt.Sprintf("user %v is not registered", req.UserName))
+			}
+			return errors.Wrapf(err, "failed to read user details")
+		}
+
+		// adding a member again changes their role
+		member, err := tx.GetGroupMember(ctx, req.GroupID, user.ID)
+		if err == nil {
+			if strings.Compare(req.Role, api.GroupAdminRole) != 0 {
+				err = checkGroupKeepsAdmin(ctx, tx, member)
+				if err != nil {
+					return err
+				}
+			}
+			member.Role = req.Role
+			return tx.UpdateGroupMember(ctx, &member)
+		}
+		if !store.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to check group membership")
+		}
+		return tx.AddGroupMember(ctx, &store.GroupMember{GroupID: req.GroupID, UserID: user.ID, Role: req.Role})
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to add %v to group:%v", req.UserName, req.GroupID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		return req.SessionToken, nil
+	}
+	return sessionToken, nil
+}
+
+func processRemoveGroupMemberRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.RemoveGroupMemberRequest) (string, error) {
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		member, err := tx.GetGroupMember(ctx, req.GroupID, req.MemberID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return errors.New(fmt.Sprintf("user %v is not a member of group %v", req.MemberID, req.GroupID))
+			}
+			return errors.Wrapf(err, "failed to check group membership")
+		}
+		err = checkGroupKeepsAdmin(ctx, tx, member)
+		if err != nil {
+			return err
+		}
+		return tx.RemoveGroupMember(ctx, member.ID)
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to remove user:%v from group:%v", req.MemberID, req.GroupID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		return req.SessionToken, nil
+	}
+	return sessionToken, nil
+}
+
+func processShareListWithGroupRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.ShareListWithGroupRequest) (string, error) {
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		list, err := getListWithRole(ctx, tx, req.ListID, req.UserID)
+		if err != nil {
+			return err
+		}
+		err = authoriseGrant(list.AccessType, req.AccessType)
+		if err != nil {
+			return err
+		}
+		// lists can only be shared with the groups of the user sharing them
+		_, err = getGroupMember(ctx, tx, req.GroupID, req.UserID)
+		if err != nil {
+			return err
+		}
+
+		// sharing again with a group updates its access
+		listGroup, err := tx.GetListGroup(ctx, req.ListID, req.GroupID)
+		if err == nil {
+			err = authoriseGrant(list.AccessType, listGroup.AccessType)
+			if err != nil {
+				return err
+			}
+			listGroup.AccessType = req.AccessType
+			return tx.UpdateListGroup(ctx, &listGroup)
+		}
+		if !store.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to check list-group connection")
+		}
+		return tx.AddListGroup(ctx, &store.ListGroup{ListID: req.ListID, GroupID: req.GroupID, AccessType: req.AccessType})
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to share list:%v with group:%v", req.ListID, req.GroupID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		return req.SessionToken, nil
+	}
+	return sessionToken, nil
+}
+
+func processUnshareListWithGroupRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.UnshareListWithGroupRequest) (string, error) {
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		list, err := getListWithRole(ctx, tx, req.ListID, req.UserID)
+		if err != nil {
+			return err
+		}
+		listGroup, err := tx.GetListGroup(ctx, req.ListID, req.GroupID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return errors.New(fmt.Sprintf("list %v is not shared with group %v", req.ListID, req.GroupID))
+			}
+			return errors.Wrapf(err, "failed to check list-group connection")
+		}
+		err = authoriseGrant(list.AccessType, listGroup.AccessType)
+		if err != nil {
+			return err
+		}
+		return tx.RemoveListGroup(ctx, listGroup.ID)
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to stop sharing list:%v with group:%v", req.ListID, req.GroupID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		return req.SessionToken, nil
+	}
+	return sessionToken, nil
+}
+
+func processCreatePublicLinkRequest(ctx context.Context, repo store.Repository, sessions session.Store, secret []byte, req *api.CreatePublicLinkRequest) (api.PublicLink, string, error) {
+	list, err := repo.GetList(ctx, req.ListID)
+	if err != nil {
+		return api.PublicLink{}, "", errors.Wrapf(err, "failed to read details of list:%v", req.ListID)
+	}
+	if strings.Compare(list.Status, api.Deleted) == 0 {
+		return api.PublicLink{}, "", errors.New("a deleted list can not be published")
+	}
+
+	link := store.PublicLink{
+		ListID:     req.ListID,
+		AccessType: req.AccessType,
+		CreatedBy:  req.UserID,
+		CreatedAt:  time.Now(),
+		ExpiresAt:  req.ExpiresAt,
+	}
+	if link.ExpiresAt.IsZero() {
+		link.ExpiresAt = link.CreatedAt.Add(defaultPublicLinkTTL)
+	}
+	// the token signs the expiry with second precision
+	link.ExpiresAt = link.ExpiresAt.Truncate(time.Second)
+	err = repo.CreatePublicLink(ctx, &link)
+	if err != nil {
+		return api.PublicLink{}, "", errors.Wrapf(err, "failed to create public link to list:%v", req.ListID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return toAPIPublicLink(secret, link), sessionToken, nil
+}
+
+func processGetPublicLinksRequest(ctx context.Context, repo store.Repository, sessions session.Store, secret []byte, req *api.GetPublicLinksRequest) ([]api.PublicLink, string, error) {
+	var links []api.PublicLink
+	stored, err := repo.GetListPublicLinks(ctx, req.ListID)
+	if err != nil {
+		return links, "", errors.Wrapf(err, "failed to read public links to list:%v", req.ListID)
+	}
+	now := time.Now()
+	for _, link := range stored {
+		if !link.RevokedAt.IsZero() || !now.Before(link.ExpiresAt) {
+			continue
+		}
+		links = append(links, toAPIPublicLink(secret, link))
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return links, sessionToken, nil
+}
+
+func processRevokePublicLinkRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.RevokePublicLinkRequest) (string, error) {
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		link, err := tx.GetPublicLink(ctx, req.LinkID)
+		if err != nil || link.ListID != req.ListID {
+			if err == nil || store.IsNotFound(err) {
+				return errors.New(fmt.Sprintf("public link %v to list %v does not exist", req.LinkID, req.ListID))
+			}
+			return errors.Wrapf(err, "failed to read public link details")
+		}
+		if !link.RevokedAt.IsZero() {
+			return errors.New("public link is already revoked")
+		}
+		link.RevokedAt = time.Now()
+		return tx.UpdatePublicLink(ctx, &link)
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to revoke public link:%v", req.LinkID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		return req.SessionToken, nil
+	}
+	return sessionToken, nil
+}
+
+// processGetPublicListRequest returns the list a public link was created for
+// with its items, there is no session to refresh
+func processGetPublicListRequest(ctx context.Context, repo store.Repository, secret []byte, req *api.GetPublicListRequest) (api.List, []api.Item, error) {
+	var items []api.Item
+	link, err := getPublicLink(ctx, repo, secret, req.Token)
+	if err != nil {
+		return api.List{}, items, err
+	}
+	list, err := repo.GetList(ctx, link.ListID)
+	if err != nil {
+		return list, items, errors.Wrapf(err, "failed to read list details")
+	}
+	if strings.Compare(list.Status, api.Deleted) == 0 {
+		return api.List{}, items, errors.New("list of the public link has been deleted")
+	}
+	list.AccessType = link.AccessType
+
+	items, err = repo.GetListItems(ctx, list.ID)
+	if err != nil {
+		return list, items, errors.Wrapf(err, "failed to read items for given list")
+	}
+	items = filterItems(items, func(item api.Item) bool {
+		return strings.Compare(item.Status, api.Deleted) != 0
+	})
+	return list, items, nil
+}
+
+// processBuyPublicItemRequest marks an item bought through a public link on
+// behalf of the user who created the link
+func processBuyPublicItemRequest(ctx context.Context, repo store.Repository, secret []byte, req *api.BuyPublicItemRequest) error {
+	link, err := getPublicLink(ctx, repo, secret, req.Token)
+	if err != nil {
+		return err
+	}
+	if strings.Compare(link.AccessType, api.RoleShopper) != 0 {
+		return errors.New("unauthorised access, public link does not allow buying items")
+	}
+
+	return repo.Tx(ctx, func(tx store.Repository) error {
+		item, err := tx.GetItem(ctx, req.ItemID)
+		if err != nil || item.ListID != link.ListID {
+			if err == nil || store.IsNotFound(err) {
+				return errors.New(fmt.Sprintf("item %v is not on the list of the public link", req.ItemID))
+			}
+			return errors.Wrapf(err, "failed to read item details")
+		}
+		list, err := tx.GetList(ctx, link.ListID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read list status")
+		}
+		if strings.Compare(list.Status, api.Todo) != 0 {
+			return errors.New(fmt.Sprintf("list is in %v state, need in todo state", list.Status))
+		}
+		// the change is made through the link of its creator, but the buyer is not
+		// a user of ours and is left unknown
+		err = applyItemTransition(&item, itemBuy, link.CreatedBy)
+		if err != nil {
+			return err
+		}
+		item.BoughtBy = api.User{}
+		item.BoughtAt = item.LastModifiedAt
+		err = tx.UpdateItem(ctx, &item)
+		if err != nil {
+			return errors.Wrapf(err, "failed to mark item as bought in DB")
+		}
+		return touchList(ctx, tx, list, item.LastModifiedAt)
+	})
+}
+
+func processCreateCategoryRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.CreateCategoryRequest) (api.Category, string, error) {
+	// a group category belongs to the group alone
+	category := api.Category{Name: req.Name, Type: req.Type, OwnerID: req.UserID}
+	if req.GroupID != 0 {
+		category.OwnerID = 0
+		category.GroupID = req.GroupID
+	}
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		existing, found, err := findVisibleCategory(ctx, tx, req.UserID, category.Name)
+		if err != nil {
+			return err
+		}
+		if found {
+			return errors.New(fmt.Sprintf("category %v already exists as %v", category.Name, existing.ID))
+		}
+		return tx.CreateCategory(ctx, &category)
+	})
+	if err != nil {
+		return category, "", errors.Wrapf(err, "failed to create category %v", req.Name)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return category, sessionToken, nil
+}
+
+func processUpdateCategoryRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.UpdateCategoryRequest) (api.Category, string, error) {
+	var category api.Category
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		var err error
+		category, err = getCategory(ctx, tx, req.CategoryID)
+		if err != nil {
+			return err
+		}
+		if req.Name != nil {
+			// renaming can change the case of a name but not take the name of another category
+			existing, found, err := findVisibleCategory(ctx, tx, req.UserID, *req.Name)
+			if err != nil {
+				return err
+			}
+			if found && existing.ID != category.ID {
+				return errors.New(fmt.Sprintf("category %v already exists as %v", *req.Name, existing.ID))
+			}
+			category.Name = *req.Name
+		}
+		if req.Type != nil {
+			category.Type = *req.Type
+		}
+		return tx.UpdateCategory(ctx, &category)
+	})
+	if err != nil {
+		return category, "", errors.Wrapf(err, "failed to update category:%v", req.CategoryID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return category, sessionToken, nil
+}
+
+func processDeleteCategoryRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.DeleteCategoryRequest) (string, error) {
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		count, err := tx.CountCategoryItems(ctx, req.CategoryID)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New(fmt.Sprintf("category is used by %v items, merge it into another category instead", count))
+		}
+		return tx.DeleteCategory(ctx, req.CategoryID)
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to delete category:%v", req.CategoryID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		return req.SessionToken, nil
+	}
+	return sessionToken, nil
+}
+
+// processMergeCategoriesRequest moves the items of the duplicate categories to
+// the category of the request and deletes the duplicates
+func processMergeCategoriesRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.MergeCategoriesRequest) (api.Category, int64, string, error) {
+	var (
+		category api.Category
+		moved    int64
+	)
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		var err error
+		category, err = getCategory(ctx, tx, req.CategoryID)
+		if err != nil {
+			return err
+		}
+		for _, duplicateID := range req.DuplicateIDs {
+			count, err := tx.MoveCategoryItems(ctx, duplicateID, category.ID)
+			if err != nil {
+				return err
+			}
+			moved += count
+			err = tx.MoveCategoryRules(ctx, duplicateID, category.ID)
+			if err != nil {
+				return err
+			}
+			err = tx.DeleteCategory(ctx, duplicateID)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return category, 0, "", errors.Wrapf(err, "failed to merge categories into category:%v", req.CategoryID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return category, moved, sessionToken, nil
+}
+
+func processCreateCategoryRuleRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.CreateCategoryRuleRequest) (api.CategoryRule, string, error) {
+	var rule api.CategoryRule
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		category, err := getCategory(ctx, tx, req.CategoryID)
+		if err != nil {
+			return err
+		}
+		stored := store.CategoryRule{
+			UserID:     req.UserID,
+			Pattern:    req.Pattern,
+			Regexp:     req.Regexp,
+			CategoryID: category.ID,
+			CreatedAt:  time.Now(),
+		}
+		err = tx.CreateCategoryRule(ctx, &stored)
+		if err != nil {
+			return err
+		}
+		rule = api.CategoryRule{ID: stored.ID, Pattern: stored.Pattern, Regexp: stored.Regexp, Category: category}
+		return nil
+	})
+	if err != nil {
+		return rule, "", errors.Wrapf(err, "failed to add category rule")
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return rule, sessionToken, nil
+}
+
+func processGetCategoryRulesRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.GetCategoryRulesRequest) ([]api.CategoryRule, string, error) {
+	var rules []api.CategoryRule
+	stored, err := repo.GetUserCategoryRules(ctx, req.UserID)
+	if err != nil {
+		return rules, "", errors.Wrapf(err, "failed to read category rules of user")
+	}
+	for _, rule := range stored {
+		category, err := repo.GetCategory(ctx, rule.CategoryID)
+		if err != nil {
+			return rules, "", errors.Wrapf(err, "failed to read category of rule:%v", rule.ID)
+		}
+		rules = append(rules, api.CategoryRule{ID: rule.ID, Pattern: rule.Pattern, Regexp: rule.Regexp, Category: category})
+	}
+	for _, rule := range defaultCategoryRules {
+		// system categories not in our DB yet are given by name
+		category, found, err := findSystemCategory(ctx, repo, req.UserID, rule.Category.Name)
+		if err != nil {
+			return rules, "", err
+		}
+		if found {
+			rule.Category = category
+		}
+		rules = append(rules, rule)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return rules, sessionToken, nil
+}
+
+func processDeleteCategoryRuleRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.DeleteCategoryRuleRequest) (string, error) {
+	err := repo.DeleteCategoryRule(ctx, req.RuleID)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to delete category rule:%v", req.RuleID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		return req.SessionToken, nil
+	}
+	return sessionToken, nil
+}
+
+func processCreateStoreRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.CreateStoreRequest) (api.Store, string, error) {
+	shop := api.Store{Name: req.Name, OwnerID: req.UserID, CreatedAt: time.Now()}
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		err := tx.CreateStore(ctx, &shop)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add new store")
+		}
+		err = setStoreLayout(ctx, tx, shop.ID, req.CategoryIDs)
+		if err != nil {
+			return err
+		}
+		shop, err = tx.GetStore(ctx, shop.ID)
+		return err
+	})
+	if err != nil {
+		return shop, "", err
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return shop, sessionToken, nil
+}
+
+func processGetStoresRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.GetStoresRequest) ([]api.Store, string, error) {
+	stores, err := repo.GetUserStores(ctx, req.UserID)
+	if err != nil {
+		return stores, "", errors.Wrapf(err, "failed to read stores of user")
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return stores, sessionToken, nil
+}
+
+func processUpdateStoreRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.UpdateStoreRequest) (api.Store, string, error) {
+	var shop api.Store
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		var err error
+		shop, err = tx.GetStore(ctx, req.StoreID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return errors.New(fmt.Sprintf("store %v does not exist", req.StoreID))
+			}
+			return errors.Wrapf(err, "failed to read store details")
+		}
+		if req.Name != nil {
+			shop.Name = *req.Name
+			err = tx.UpdateStore(ctx, &shop)
+			if err != nil {
+				return errors.Wrapf(err, "failed to update store:%v", req.StoreID)
+			}
+		}
+		if req.CategoryIDs != nil {
+			err = setStoreLayout(ctx, tx, shop.ID, *req.CategoryIDs)
+			if err != nil {
+				return err
+			}
+		}
+		shop, err = tx.GetStore(ctx, shop.ID)
+		return err
+	})
+	if err != nil {
+		return shop, "", err
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return shop, sessionToken, nil
+}
+
+func processDeleteStoreRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.DeleteStoreRequest) (string, error) {
+	err := repo.DeleteStore(ctx, req.StoreID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return "", errors.New(fmt.Sprintf("store %v does not exist", req.StoreID))
+		}
+		return "", errors.Wrapf(err, "failed to delete store:%v", req.StoreID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		return req.SessionToken, nil
+	}
+	return sessionToken, nil
+}
+
+func processReorderItemsRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.ReorderItemsRequest) ([]api.Item, string, error) {
+	var items []api.Item
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		list, err := tx.GetList(ctx, req.ListID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read list status")
+		}
+		if strings.Compare(list.Status, api.Deleted) == 0 {
+			return errors.New("items of a deleted list can not be reordered")
+		}
+		items, err = tx.GetListItems(ctx, req.ListID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read items for given list")
+		}
+
+		var changed []api.Item
+		switch {
+		case len(req.Order) != 0:
+			changed, err = orderItems(items, req.Order)
+		case req.BeforeID != 0:
+			changed, err = moveItem(items, req.ItemID, req.BeforeID, false)
+		default:
+			changed, err = moveItem(items, req.ItemID, req.AfterID, true)
+		}
+		if err != nil {
+			return err
+		}
+		for i := range changed {
+			err = tx.UpdateItem(ctx, &changed[i])
+			if err != nil {
+				return errors.Wrapf(err, "failed to move item:%v", changed[i].ID)
+			}
+		}
+
+		items, err = tx.GetListItems(ctx, req.ListID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read items for given list")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	items = filterItems(items, func(item api.Item) bool {
+		return strings.Compare(item.Status, api.Deleted) != 0
+	})
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return items, sessionToken, nil
+}
+
+func processMoveItemsRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.MoveItemsRequest) ([]api.Item, string, error) {
+	var moved []api.Item
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		items, position, err := transferItems(ctx, tx, req.ListID, req.ToListID, req.ItemIDs)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			item.ListID = req.ToListID
+			item.Position = position
+			position += positionGap
+			item.LastModifiedBy.UserID = req.UserID
+			item.LastModifiedAt = time.Now()
+			err = tx.UpdateItem(ctx, &item)
+			if err != nil {
+				return errors.Wrapf(err, "failed to move item:%v", item.ID)
+			}
+			moved = append(moved, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to move items to list:%v", req.ToListID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return moved, sessionToken, nil
+}
+
+func processCopyItemsRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.CopyItemsRequest) ([]api.Item, string, error) {
+	var copies []api.Item
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		items, position, err := transferItems(ctx, tx, req.ListID, req.ToListID, req.ItemIDs)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			copied, err := copyItem(ctx, tx, item, req.ToListID, position, req.UserID)
+			if err != nil {
+				return err
+			}
+			position += positionGap
+			copies = append(copies, copied)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to copy items to list:%v", req.ToListID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return copies, sessionToken, nil
+}
+
+// copyItem adds a copy of item to list listID at given position as a new item
+// of user still to be bought. A category the user does not see is swapped for
+// one of the same name they do.
+func copyItem(ctx context.Context, tx store.Repository, item api.Item, listID int64, position int64, userID int64) (api.Item, error) {
+	category, usable, err := usableCategory(ctx, tx, item.Category.ID, userID)
+	if err != nil {
+		return item, err
+	}
+	if !usable {
+		category, err = resolveCategory(ctx, tx, api.Category{Name: item.Category.Name, Type: item.Category.Type}, userID)
+		if err != nil {
+			return item, err
+		}
+	}
+	copied := api.Item{
+		ListID:         listID,
+		Title:          item.Title,
+		Description:    item.Description,
+		Quantity:       item.Quantity,
+		Unit:           item.Unit,
+		Status:         api.Todo,
+		Category:       category,
+		CreatedBy:      api.User{UserID: userID},
+		LastModifiedBy: api.User{UserID: userID},
+		CreatedAt:      time.Now(),
+		LastModifiedAt: time.Now(),
+		Deadline:       item.Deadline,
+		Position:       position,
+	}
+	err = tx.CreateItem(ctx, &copied)
+	if err != nil {
+		return copied, errors.Wrapf(err, "failed to copy item:%v", item.ID)
+	}
+	// read the copy back for the names of its users
+	copied, err = tx.GetItem(ctx, copied.ID)
+	if err != nil {
+		return copied, errors.Wrapf(err, "failed to read copy of item:%v", item.ID)
+	}
+	return copied, nil
+}
+
+// cloneList adds a list of user with the items of source that are not deleted,
+// all of them still to be bought and due by deadline, a year from now when it
+// is zero. The clone keeps the store of source only if the store belongs to user.
+func cloneList(ctx context.Context, tx store.Repository, source api.List, name string, template bool, deadline time.Time, userID int64) (api.List, error) {
+	if deadline.IsZero() {
+		deadline = time.Now().AddDate(1, 0, 0)
+	}
+	list := api.List{
+		Name:           name,
+		Description:    source.Description,
+		Owner:          api.User{UserID: userID},
+		CreatedAt:      time.Now(),
+		LastModifiedAt: time.Now(),
+		Deadline:       deadline,
+		Status:         api.Todo,
+		Template:       template,
+	}
+	if source.StoreID != 0 {
+		shop, err := tx.GetStore(ctx, source.StoreID)
+		if err == nil && shop.OwnerID == userID {
+			list.StoreID = shop.ID
+		}
+	}
+	err := tx.CreateList(ctx, &list)
+	if err != nil {
+		return list, errors.Wrap(err, "failed to insert new list in DB")
+	}
+	// the owner's access does not expire
+	err = tx.AddContributor(ctx, &store.Contributor{ListID: list.ID, UserID: userID, AccessType: api.RoleEditor})
+	if err != nil {
+		return list, errors.Wrap(err, "failed to insert new list-user pair in DB, aborting")
+	}
+
+	items, err := tx.GetListItems(ctx, source.ID)
+	if err != nil {
+		return list, errors.Wrapf(err, "failed to read items of list:%v", source.ID)
+	}
+	var position int64
+	for _, item := range items {
+		if strings.Compare(item.Status, api.Deleted) == 0 {
+			continue
+		}
+		position += positionGap
+		item.Deadline = list.Deadline
+		_, err = copyItem(ctx, tx, item, list.ID, position, userID)
+		if err != nil {
+			return list, err
+		}
+	}
+	list.AccessType = api.RoleOwner
+	list.CreatedByMe = true
+	return list, nil
+}
+
+// transferItems reads the items to move or copy from list fromID to list toID
+// and the position the first of them takes at the end of list toID. Only items
+// that are not deleted can leave a list and only a list to do can take them.
+func transferItems(ctx context.Context, tx store.Repository, fromID int64, toID int64, itemIDs []int64) ([]api.Item, int64, error) {
+	from, err := tx.GetList(ctx, fromID)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to read list status")
+	}
+	if strings.Compare(from.Status, api.Deleted) == 0 {
+		return nil, 0, errors.New("items of a deleted list can not be moved")
+	}
+	to, err := tx.GetList(ctx, toID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return nil, 0, errors.New(fmt.Sprintf("list %v does not exist", toID))
+		}
+		return nil, 0, errors.Wrapf(err, "failed to read list status")
+	}
+	if strings.Compare(to.Status, api.Todo) != 0 {
+		return nil, 0, errors.New(fmt.Sprintf("list status:%v should be %v", to.Status, api.Todo))
+	}
+
+	var items []api.Item
+	for _, id := range itemIDs {
+		item, err := tx.GetItem(ctx, id)
+		if err != nil && !store.IsNotFound(err) {
+			return nil, 0, errors.Wrapf(err, "failed to read item details")
+		}
+		if err != nil || item.ListID != fromID {
+			return nil, 0, errors.New(fmt.Sprintf("item %v is not on list %v", id, fromID))
+		}
+		if strings.Compare(item.Status, api.Deleted) == 0 {
+			return nil, 0, errors.New(fmt.Sprintf("item %v is deleted", id))
+		}
+		items = append(items, item)
+	}
+	toItems, err := tx.GetListItems(ctx, toID)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to read items of list")
+	}
+	return items, nextPosition(toItems), nil
+}
+
+func processCloneListRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.CloneListRequest) (api.List, string, error) {
+	var list api.List
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		source, err := tx.GetList(ctx, req.ListID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read list details")
+		}
+		if req.Name == "" {
+			req.Name = source.Name
+		}
+		list, err = cloneList(ctx, tx, source, req.Name, source.Template, time.Time{}, req.UserID)
+		return err
+	})
+	if err != nil {
+		return list, "", errors.Wrapf(err, "failed to clone list:%v", req.ListID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return list, sessionToken, nil
+}
+
+func processCreateTemplateRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.CreateTemplateRequest) (api.List, string, error) {
+	var template api.List
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		source, err := tx.GetList(ctx, req.ListID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read list details")
+		}
+		template, err = cloneList(ctx, tx, source, req.Name, true, time.Time{}, req.UserID)
+		return err
+	})
+	if err != nil {
+		return template, "", errors.Wrapf(err, "failed to save list:%v as template", req.ListID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return template, sessionToken, nil
+}
+
+func processGetTemplatesRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.GetTemplatesRequest) ([]api.List, string, error) {
+	templates, err := userLists(ctx, repo, req.UserID, true)
+	if err != nil {
+		return templates, "", err
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return templates, sessionToken, nil
+}
+
+func processInstantiateTemplateRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.InstantiateTemplateRequest) (api.List, string, error) {
+	var list api.List
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		template, err := tx.GetList(ctx, req.TemplateID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read template details")
+		}
+		if !template.Template {
+			return errors.New(fmt.Sprintf("list %v is not a template", req.TemplateID))
+		}
+		if strings.Compare(template.Status, api.Deleted) == 0 {
+			return errors.New("a deleted template can not be used")
+		}
+		if req.Name == "" {
+			req.Name = template.Name
+		}
+		var deadline time.Time
+		if req.Deadline != nil {
+			deadline = *req.Deadline
+		}
+		list, err = cloneList(ctx, tx, template, req.Name, false, deadline, req.UserID)
+		return err
+	})
+	if err != nil {
+		return list, "", errors.Wrapf(err, "failed to start list from template:%v", req.TemplateID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return list, sessionToken, nil
+}
+
+func processCreateRecurrenceRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.CreateRecurrenceRequest) (api.Recurrence, string, error) {
+	recurrence := api.Recurrence{
+		OwnerID:      req.UserID,
+		SourceListID: req.ListID,
+		Name:         req.Name,
+		Frequency:    req.Frequency,
+		Weekday:      req.Weekday,
+		Day:          req.Day,
+		CreatedAt:    time.Now(),
+	}
+	recurrence.NextRunAt = firstRun(recurrence, recurrence.CreatedAt)
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		source, err := tx.GetList(ctx, req.ListID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read list details")
+		}
+		if strings.Compare(source.Status, api.Deleted) == 0 {
+			return errors.New("a deleted list can not recur")
+		}
+		err = tx.CreateRecurrence(ctx, &recurrence)
+		if err != nil {
+			return errors.Wrapf(err, "failed to insert new recurrence in DB")
+		}
+		return nil
+	})
+	if err != nil {
+		return recurrence, "", err
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return recurrence, sessionToken, nil
+}
+
+func processGetRecurrencesRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.GetRecurrencesRequest) ([]api.Recurrence, string, error) {
+	recurrences, err := repo.GetUserRecurrences(ctx, req.UserID)
+	if err != nil {
+		return recurrences, "", errors.Wrapf(err, "failed to read recurrences of user")
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return recurrences, sessionToken, nil
+}
+
+// setRecurrencePaused pauses or resumes a recurrence, a resumed recurrence
+// skips the runs it missed while paused
+func setRecurrencePaused(ctx context.Context, repo store.Repository, recurrenceID int64, paused bool) (api.Recurrence, error) {
+	var recurrence api.Recurrence
+	err := repo.Tx(ctx, func(tx store.Repository) error {
+		var err error
+		recurrence, err = tx.GetRecurrence(ctx, recurrenceID)
+		if err != nil {
+			if store.IsNotFound(err) {
+				return errors.New(fmt.Sprintf("recurrence %v does not exist", recurrenceID))
+			}
+			return errors.Wrapf(err, "failed to read recurrence details")
+		}
+		if recurrence.Paused == paused {
+			return nil
+		}
+		recurrence.Paused = paused
+		if !paused {
+			recurrence.NextRunAt = nextRunAfter(recurrence, time.Now())
+		}
+		err = tx.UpdateRecurrence(ctx, &recurrence)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update recurrence:%v", recurrenceID)
+		}
+		return nil
+	})
+	return recurrence, err
+}
+
+func processPauseRecurrenceRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.PauseRecurrenceRequest) (api.Recurrence, string, error) {
+	recurrence, err := setRecurrencePaused(ctx, repo, req.RecurrenceID, true)
+	if err != nil {
+		return recurrence, "", err
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return recurrence, sessionToken, nil
+}
+
+func processResumeRecurrenceRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.ResumeRecurrenceRequest) (api.Recurrence, string, error) {
+	recurrence, err := setRecurrencePaused(ctx, repo, req.RecurrenceID, false)
+	if err != nil {
+		return recurrence, "", err
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return recurrence, sessionToken, nil
+}
+
+func processDeleteRecurrenceRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.DeleteRecurrenceRequest) (string, error) {
+	err := repo.DeleteRecurrence(ctx, req.RecurrenceID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return "", errors.New(fmt.Sprintf("recurrence %v does not exist", req.RecurrenceID))
+		}
+		return "", errors.Wrapf(err, "failed to delete recurrence:%v", req.RecurrenceID)
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		return req.SessionToken, nil
+	}
+	return sessionToken, nil
+}
+
+func processGetRecurrenceInstancesRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.GetRecurrenceInstancesRequest) ([]api.RecurrenceInstance, string, error) {
+	instances, err := repo.GetRecurrenceInstances(ctx, req.RecurrenceID)
+	if err != nil {
+		return instances, "", errors.Wrapf(err, "failed to read instances of recurrence:%v", req.RecurrenceID)
+	}
+	if instances == nil {
+		instances = []api.RecurrenceInstance{}
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return instances, sessionToken, nil
+}
+
+func processGetDueItemsRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.GetDueItemsRequest) ([]api.Item, []api.Item, string, error) {
+	overdue, dueSoon := []api.Item{}, []api.Item{}
+	within, err := dueWithin(req.Within)
+	if err != nil {
+		return overdue, dueSoon, "", err
+	}
+	now := time.Now()
+	lists, err := userLists(ctx, repo, req.UserID, false)
+	if err != nil {
+		return overdue, dueSoon, "", err
+	}
+	for _, list := range lists {
+		// nothing is left to buy on a bought list
+		if strings.Compare(list.Status, api.Todo) != 0 {
+			continue
+		}
+		items, err := repo.GetListItems(ctx, list.ID)
+		if err != nil {
+			return overdue, dueSoon, "", errors.Wrapf(err, "failed to read items for list:%v", list.ID)
+		}
+		for _, item := range items {
+			if strings.Compare(item.Status, api.Todo) != 0 {
+				continue
+			}
+			switch {
+			case item.Deadline.Before(now):
+				overdue = append(overdue, item)
+			case !item.Deadline.After(now.Add(within)):
+				dueSoon = append(dueSoon, item)
+			}
+		}
+	}
+	sortByDeadline := func(items []api.Item) {
+		sort.SliceStable(items, func(i, j int) bool { return items[i].Deadline.Before(items[j].Deadline) })
+	}
+	sortByDeadline(overdue)
+	sortByDeadline(dueSoon)
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return overdue, dueSoon, sessionToken, nil
+}
+
+func processGetReminderPreferencesRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.GetReminderPreferencesRequest) (api.ReminderPreferences, string, error) {
+	preferences, err := reminderPreferences(ctx, repo, req.UserID)
+	if err != nil {
+		return api.ReminderPreferences{}, "", err
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return apiReminderPreferences(preferences), sessionToken, nil
+}
+
+func processUpdateReminderPreferencesRequest(ctx context.Context, repo store.Repository, sessions session.Store, req *api.UpdateReminderPreferencesRequest) (api.ReminderPreferences, string, error) {
+	// fields left out take their default
+	preferences := defaultReminderPreferences(req.UserID)
+	if req.LeadTime != "" {
+		leadTime, err := time.ParseDuration(req.LeadTime)
+		if err != nil {
+			return api.ReminderPreferences{}, "", errors.Wrapf(err, "invalid lead time")
+		}
+		preferences.LeadTime = leadTime
+	}
+	if req.TimeZone != "" {
+		preferences.TimeZone = req.TimeZone
+	}
+	if req.Channel != "" {
+		preferences.Channel = req.Channel
+	}
+	preferences.QuietFrom = req.QuietFrom
+	preferences.QuietUntil = req.QuietUntil
+	err := repo.SaveReminderPreferences(ctx, &preferences)
+	if err != nil {
+		return api.ReminderPreferences{}, "", errors.Wrapf(err, "failed to save reminder preferences")
+	}
+
+	// Refresh user session
+	sessionToken, err := sessions.Refresh(ctx, req.SessionToken)
+	if err != nil {
+		sessionToken = req.SessionToken
+	}
+	return apiReminderPreferences(preferences), sessionToken, nil
 }
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"context"
+	"shoppinglist/pkg/api"
+	"shoppinglist/pkg/session"
+	"shoppinglist/pkg/store"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fixture is an in-memory repository with registered users
+type fixture struct {
+	ctx      context.Context
+	repo     store.Repository
+	sessions session.Store
+	users    map[string]int64
+}
+
+func newFixture(t *testing.T, userNames ...string) *fixture {
+	f := &fixture{
+		ctx:      context.Background(),
+		repo:     store.NewMemory(),
+		sessions: session.NewMemory(session.DefaultTTL),
+		users:    make(map[string]int64),
+	}
+	for _, name := range userNames {
+		user := store.User{UserName: name, Email: name + "@example.com", Password: "pw", Status: "active"}
+		err := f.repo.CreateUser(f.ctx, &user)
+		if err != nil {
+			t.Fatalf("failed to add user %v: %v", name, err)
+		}
+		f.users[name] = user.ID
+	}
+	return f
+}
+
+// createList adds a list of owner the way the service does
+func (f *fixture) createList(t *testing.T, owner string) int64 {
+	req := api.CreateListRequest{List: api.List{Name: "weekly", Owner: api.User{UserID: f.users[owner]}, Status: api.Todo}}
+	_, err := processCreateListRequest(f.ctx, f.repo, f.sessions, &req)
+	if err != nil {
+		t.Fatalf("failed to create list: %v", err)
+	}
+	return req.List.ID
+}
+
+// createItem adds an item titled title to list on behalf of user
+func (f *fixture) createItem(t *testing.T, listID int64, user string, title string) int64 {
+	req := api.CreateItemRequest{Item: api.Item{ListID: listID, Title: title, CreatedBy: api.User{UserID: f.users[user]}}}
+	_, err := processCreateItemRequest(f.ctx, f.repo, f.sessions, &req)
+	if err != nil {
+		t.Fatalf("failed to add item %v: %v", title, err)
+	}
+	return req.Item.ID
+}
+
+// share shares list with user as accessType on behalf of sharer
+func (f *fixture) share(listID int64, sharer string, user string, accessType string, validUntil time.Time) error {
+	_, err := processShareListRequest(f.ctx, f.repo, f.sessions, &api.ShareListRequest{
+		UserID:     f.users[sharer],
+		ListID:     listID,
+		UserName:   user,
+		AccessType: accessType,
+		ValidUntil: validUntil,
+	})
+	return err
+}
+
+// access returns the access user holds on list
+func (f *fixture) access(t *testing.T, listID int64, user string) store.Contributor {
+	contributor, err := f.repo.GetContributor(f.ctx, listID, f.users[user])
+	if err != nil {
+		t.Fatalf("failed to read access of %v: %v", user, err)
+	}
+	return contributor
+}
+
+func TestShareListManagerCanNotDemoteManager(t *testing.T) {
+	f := newFixture(t, "alice", "bob", "carol", "dave")
+	listID := f.createList(t, "alice")
+	for _, manager := range []string{"bob", "carol"} {
+		if err := f.share(listID, "alice", manager, api.RoleManager, time.Time{}); err != nil {
+			t.Fatalf("owner failed to share with %v: %v", manager, err)
+		}
+	}
+
+	err := f.share(listID, "bob", "carol", api.RoleViewer, time.Time{})
+	if err == nil || !strings.Contains(err.Error(), "unauthorised access") {
+		t.Fatalf("manager demoted another manager, got error %v", err)
+	}
+	err = f.share(listID, "bob", "carol", api.RoleShopper, time.Now().Add(time.Hour))
+	if err == nil || !strings.Contains(err.Error(), "unauthorised access") {
+		t.Fatalf("manager limited the access of another manager, got error %v", err)
+	}
+	if c := f.access(t, listID, "carol"); c.AccessType != api.RoleManager || !c.ValidUntil.IsZero() {
+		t.Fatalf("access of carol changed to %v until %v", c.AccessType, c.ValidUntil)
+	}
+
+	// managers still re-share with the roles below them
+	if err := f.share(listID, "bob", "dave", api.RoleEditor, time.Time{}); err != nil {
+		t.Fatalf("manager failed to share: %v", err)
+	}
+	if err := f.share(listID, "bob", "dave", api.RoleViewer, time.Time{}); err != nil {
+		t.Fatalf("manager failed to change an editor to viewer: %v", err)
+	}
+	// and the owner can demote a manager
+	if err := f.share(listID, "alice", "carol", api.RoleViewer, time.Time{}); err != nil {
+		t.Fatalf("owner failed to demote a manager: %v", err)
+	}
+}
+
+func TestTransferOwnershipIsRecorded(t *testing.T) {
+	f := newFixture(t, "alice", "bob")
+	listID := f.createList(t, "alice")
+	if err := f.share(listID, "alice", "bob", api.RoleEditor, time.Time{}); err != nil {
+		t.Fatalf("failed to share with bob: %v", err)
+	}
+	_, err := processTransferOwnershipRequest(f.ctx, f.repo, f.sessions, &api.TransferOwnershipRequest{UserID: f.users["alice"], ListID: listID, UserName: "bob"})
+	if err != nil {
+		t.Fatalf("failed to transfer ownership: %v", err)
+	}
+
+	list, err := f.repo.GetList(f.ctx, listID)
+	if err != nil {
+		t.Fatalf("failed to read list: %v", err)
+	}
+	if list.Owner.UserID != f.users["bob"] {
+		t.Fatalf("list is owned by user %v after transfer", list.Owner.UserID)
+	}
+	transfers, err := f.repo.GetListOwnershipTransfers(f.ctx, listID)
+	if err != nil {
+		t.Fatalf("failed to read ownership transfers: %v", err)
+	}
+	if len(transfers) != 1 {
+		t.Fatalf("recorded %v ownership transfers, expected 1", len(transfers))
+	}
+	transfer := transfers[0]
+	if transfer.FromUserID != f.users["alice"] || transfer.ToUserID != f.users["bob"] || !transfer.TransferredAt.Equal(list.LastModifiedAt) {
+		t.Fatalf("transfer recorded as %+v", transfer)
+	}
+}
+
+// invite returns a token inviting to list as accessType on behalf of inviter
+func (f *fixture) invite(t *testing.T, secret []byte, listID int64, inviter string, accessType string) string {
+	invite, _, err := processCreateInviteRequest(f.ctx, f.repo, f.sessions, secret, &api.CreateInviteRequest{
+		UserID:     f.users[inviter],
+		ListID:     listID,
+		AccessType: accessType,
+	})
+	if err != nil {
+		t.Fatalf("failed to invite as %v: %v", accessType, err)
+	}
+	return invite.Token
+}
+
+func TestAcceptInviteKeepsStrongerAccess(t *testing.T) {
+	secret := []byte("secret")
+	f := newFixture(t, "alice", "bob", "carol")
+	listID := f.createList(t, "alice")
+	validUntil := time.Now().Add(time.Hour).Truncate(time.Second)
+	if err := f.share(listID, "alice", "bob", api.RoleManager, time.Time{}); err != nil {
+		t.Fatalf("failed to share with bob: %v", err)
+	}
+	if err := f.share(listID, "alice", "carol", api.RoleViewer, validUntil); err != nil {
+		t.Fatalf("failed to share with carol: %v", err)
+	}
+
+	accept := func(user string, token string) {
+		_, _, err := processAcceptInviteRequest(f.ctx, f.repo, f.sessions, secret, &api.AcceptInviteRequest{UserID: f.users[user], Token: token})
+		if err != nil {
+			t.Fatalf("%v failed to accept invitation: %v", user, err)
+		}
+	}
+
+	// a manager accepting an old viewer invitation stays manager
+	accept("bob", f.invite(t, secret, listID, "alice", api.RoleViewer))
+	if c := f.access(t, listID, "bob"); c.AccessType != api.RoleManager {
+		t.Fatalf("accepting a viewer invitation changed manager to %v", c.AccessType)
+	}
+	// a time-limited viewer accepting another viewer invitation keeps the expiry
+	accept("carol", f.invite(t, secret, listID, "alice", api.RoleViewer))
+	if c := f.access(t, listID, "carol"); c.AccessType != api.RoleViewer || !c.ValidUntil.Equal(validUntil) {
+		t.Fatalf("accepting a viewer invitation changed access to %v until %v", c.AccessType, c.ValidUntil)
+	}
+	// a stronger invitation upgrades the access
+	accept("carol", f.invite(t, secret, listID, "alice", api.RoleEditor))
+	if c := f.access(t, listID, "carol"); c.AccessType != api.RoleEditor {
+		t.Fatalf("accepting an editor invitation left access at %v", c.AccessType)
+	}
+}
+
+// meetingReads is a repository where a read item is handed out once every
+// reader read it or a while passed, so no reader writes before the others read
+type meetingReads struct {
+	store.Repository
+	readers *sync.WaitGroup
+}
+
+func (r meetingReads) GetItem(ctx context.Context, itemID int64) (api.Item, error) {
+	item, err := r.Repository.GetItem(ctx, itemID)
+	r.readers.Done()
+	met := make(chan struct{})
+	go func() {
+		r.readers.Wait()
+		close(met)
+	}()
+	select {
+	case <-met:
+	case <-time.After(50 * time.Millisecond):
+	}
+	return item, err
+}
+
+func (r meetingReads) Tx(ctx context.Context, fn func(store.Repository) error) error {
+	return r.Repository.Tx(ctx, func(tx store.Repository) error {
+		return fn(meetingReads{tx, r.readers})
+	})
+}
+
+func TestUpdateItemConcurrentChangesAreKept(t *testing.T) {
+	f := newFixture(t, "alice")
+	listID := f.createList(t, "alice")
+	itemID := f.createItem(t, listID, "alice", "milk")
+	userID := f.users["alice"]
+	title, quantity := "oat milk", float64(2)
+	reqs := []*api.UpdateItemRequest{
+		{UserID: userID, ItemID: itemID, Title: &title},
+		{UserID: userID, ItemID: itemID, Quantity: &quantity},
+	}
+	readers := &sync.WaitGroup{}
+	readers.Add(len(reqs))
+	repo := meetingReads{f.repo, readers}
+	var wg sync.WaitGroup
+	for _, req := range reqs {
+		wg.Add(1)
+		go func(req *api.UpdateItemRequest) {
+			defer wg.Done()
+			if _, err := processUpdateItemRequest(f.ctx, repo, f.sessions, req); err != nil {
+				t.Errorf("failed to update item: %v", err)
+			}
+		}(req)
+	}
+	wg.Wait()
+
+	item, err := f.repo.GetItem(f.ctx, itemID)
+	if err != nil {
+		t.Fatalf("failed to read item: %v", err)
+	}
+	if item.Title != title || item.Quantity != quantity {
+		t.Fatalf("concurrent updates overwrote each other, item is %q x %v", item.Title, item.Quantity)
+	}
+}
+
+func TestBuyAndDeleteItemConcurrently(t *testing.T) {
+	f := newFixture(t, "alice")
+	listID := f.createList(t, "alice")
+	itemID := f.createItem(t, listID, "alice", "milk")
+	before, err := f.repo.GetList(f.ctx, listID)
+	if err != nil {
+		t.Fatalf("failed to read list: %v", err)
+	}
+	userID := f.users["alice"]
+	readers := &sync.WaitGroup{}
+	readers.Add(2)
+	repo := meetingReads{f.repo, readers}
+
+	var wg sync.WaitGroup
+	var buyErr, deleteErr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, buyErr = processBuyItemRequest(f.ctx, repo, f.sessions, &api.BuyItemRequest{UserID: userID, ItemID: itemID, UserName: "alice"})
+	}()
+	go func() {
+		defer wg.Done()
+		_, deleteErr = processDeleteItemRequest(f.ctx, repo, f.sessions, &api.DeleteItemRequest{UserID: userID, ItemID: itemID})
+	}()
+	wg.Wait()
+
+	// either the item was bought and then deleted, or deleted and then
+	// could not be bought, never both changes on the same stale item
+	item, err := f.repo.GetItem(f.ctx, itemID)
+	if err != nil {
+		t.Fatalf("failed to read item: %v", err)
+	}
+	if deleteErr != nil || item.Status != api.Deleted {
+		t.Fatalf("item is %v after delete with error %v", item.Status, deleteErr)
+	}
+	if buyErr == nil && item.BoughtAt.IsZero() {
+		t.Fatal("deleting the item lost that it was bought")
+	}
+	after, err := f.repo.GetList(f.ctx, listID)
+	if err != nil {
+		t.Fatalf("failed to read list: %v", err)
+	}
+	if !after.LastModifiedAt.After(before.LastModifiedAt) {
+		t.Fatalf("list was last modified at %v, before the item changed", after.LastModifiedAt)
+	}
+}
+
+func TestCreateItemMergesSameItem(t *testing.T) {
+	f := newFixture(t, "alice")
+	listID := f.createList(t, "alice")
+	add := func(title string, quantity float64, unit string) api.Item {
+		req := api.CreateItemRequest{
+			Item:  api.Item{ListID: listID, Title: title, Quantity: quantity, Unit: unit, CreatedBy: api.User{UserID: f.users["alice"]}},
+			Merge: true,
+		}
+		if _, err := processCreateItemRequest(f.ctx, f.repo, f.sessions, &req); err != nil {
+			t.Fatalf("failed to add %v: %v", title, err)
+		}
+		return req.Item
+	}
+
+	milk := add("milk", 1, api.UnitLiter)
+	merged := add(" Milk ", 500, api.UnitMilliliter)
+	if merged.ID != milk.ID || merged.Quantity != 1.5 || merged.Unit != api.UnitLiter {
+		t.Fatalf("expected 1.5 l on item %v, got %v %v on item %v", milk.ID, merged.Quantity, merged.Unit, merged.ID)
+	}
+	// a quantity that can not be added up is kept apart
+	if powder := add("milk", 1, api.UnitKilogram); powder.ID == milk.ID {
+		t.Fatal("merged a mass into a volume")
+	}
+	// a bought item is not bought again
+	if _, err := processBuyItemRequest(f.ctx, f.repo, f.sessions, &api.BuyItemRequest{UserID: f.users["alice"], ItemID: milk.ID, UserName: "alice"}); err != nil {
+		t.Fatalf("failed to buy milk: %v", err)
+	}
+	if again := add("milk", 1, api.UnitLiter); again.ID == milk.ID {
+		t.Fatal("merged into a bought item")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/go-kit/kit/log"
 	"github.com/pkg/errors"
 	"shoppinglist/pkg/api"
+	"strings"
 )
 
 // Middleware describes a service (as opposed to endpoint) middleware.
@@ -88,6 +89,17 @@ func (mw loggingMiddleware) GetLists(ctx context.Context, req api.GetListsReques
 	return mw.next.GetLists(ctx, req)
 }
 
+func (mw loggingMiddleware) UpdateList(ctx context.Context, req api.UpdateListRequest) (resp api.UpdateListResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "UpdateList", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input UpdateList list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.UpdateList(ctx, req)
+}
+
 func (mw loggingMiddleware) CreateItem(ctx context.Context, req api.CreateItemRequest) (resp api.CreateItemResponse) {
 	defer func() {
 		if resp.Err == nil {
@@ -99,6 +111,17 @@ func (mw loggingMiddleware) CreateItem(ctx context.Context, req api.CreateItemRe
 	return mw.next.CreateItem(ctx, req)
 }
 
+func (mw loggingMiddleware) UpdateItem(ctx context.Context, req api.UpdateItemRequest) (resp api.UpdateItemResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "UpdateItem", "item_id", req.ItemID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input UpdateItem item_id :", req.ItemID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.UpdateItem(ctx, req)
+}
+
 func (mw loggingMiddleware) GetListItems(ctx context.Context, req api.GetListItemsRequest) (resp api.GetListItemsResponse) {
 	defer func() {
 		if resp.Err == nil {
@@ -164,3 +187,1123 @@ func (mw loggingMiddleware) DeleteItem(ctx context.Context, req api.DeleteItemRe
 	}()
 	return mw.next.DeleteItem(ctx, req)
 }
+
+func (mw loggingMiddleware) UnbuyItem(ctx context.Context, req api.UnbuyItemRequest) (resp api.UnbuyItemResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "UnbuyItem", "item_id", req.ItemID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input UnbuyItem item_id :", req.ItemID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.UnbuyItem(ctx, req)
+}
+
+func (mw loggingMiddleware) RestoreItem(ctx context.Context, req api.RestoreItemRequest) (resp api.RestoreItemResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "RestoreItem", "item_id", req.ItemID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input RestoreItem item_id :", req.ItemID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.RestoreItem(ctx, req)
+}
+
+func (mw loggingMiddleware) GetTrash(ctx context.Context, req api.GetTrashRequest) (resp api.GetTrashResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "GetTrash", "user_id", req.UserID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input GetTrash user_id :", req.UserID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.GetTrash(ctx, req)
+}
+
+func (mw loggingMiddleware) RestoreList(ctx context.Context, req api.RestoreListRequest) (resp api.RestoreListResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "RestoreList", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input RestoreList list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.RestoreList(ctx, req)
+}
+
+func (mw loggingMiddleware) GetContributors(ctx context.Context, req api.GetContributorsRequest) (resp api.GetContributorsResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "GetContributors", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input GetContributors list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.GetContributors(ctx, req)
+}
+
+func (mw loggingMiddleware) UpdateContributor(ctx context.Context, req api.UpdateContributorRequest) (resp api.UpdateContributorResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "UpdateContributor", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input UpdateContributor list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.UpdateContributor(ctx, req)
+}
+
+func (mw loggingMiddleware) RevokeContributor(ctx context.Context, req api.RevokeContributorRequest) (resp api.RevokeContributorResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "RevokeContributor", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input RevokeContributor list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.RevokeContributor(ctx, req)
+}
+
+func (mw loggingMiddleware) CreateInvite(ctx context.Context, req api.CreateInviteRequest) (resp api.CreateInviteResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "CreateInvite", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input CreateInvite list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.CreateInvite(ctx, req)
+}
+
+func (mw loggingMiddleware) GetInvites(ctx context.Context, req api.GetInvitesRequest) (resp api.GetInvitesResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "GetInvites", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input GetInvites list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.GetInvites(ctx, req)
+}
+
+func (mw loggingMiddleware) CancelInvite(ctx context.Context, req api.CancelInviteRequest) (resp api.CancelInviteResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "CancelInvite", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input CancelInvite list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.CancelInvite(ctx, req)
+}
+
+func (mw loggingMiddleware) AcceptInvite(ctx context.Context, req api.AcceptInviteRequest) (resp api.AcceptInviteResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "AcceptInvite", "user_id", req.UserID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input AcceptInvite user_id :", req.UserID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.AcceptInvite(ctx, req)
+}
+
+func (mw loggingMiddleware) TransferOwnership(ctx context.Context, req api.TransferOwnershipRequest) (resp api.TransferOwnershipResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "TransferOwnership", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input TransferOwnership list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.TransferOwnership(ctx, req)
+}
+
+func (mw loggingMiddleware) CreateGroup(ctx context.Context, req api.CreateGroupRequest) (resp api.CreateGroupResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "CreateGroup", "user_id", req.UserID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input CreateGroup user_id :", req.UserID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.CreateGroup(ctx, req)
+}
+
+func (mw loggingMiddleware) GetGroups(ctx context.Context, req api.GetGroupsRequest) (resp api.GetGroupsResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "GetGroups", "user_id", req.UserID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input GetGroups user_id :", req.UserID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.GetGroups(ctx, req)
+}
+
+func (mw loggingMiddleware) GetGroupMembers(ctx context.Context, req api.GetGroupMembersRequest) (resp api.GetGroupMembersResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "GetGroupMembers", "group_id", req.GroupID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input GetGroupMembers group_id :", req.GroupID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.GetGroupMembers(ctx, req)
+}
+
+func (mw loggingMiddleware) AddGroupMember(ctx context.Context, req api.AddGroupMemberRequest) (resp api.AddGroupMemberResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "AddGroupMember", "group_id", req.GroupID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input AddGroupMember group_id :", req.GroupID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.AddGroupMember(ctx, req)
+}
+
+func (mw loggingMiddleware) RemoveGroupMember(ctx context.Context, req api.RemoveGroupMemberRequest) (resp api.RemoveGroupMemberResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "RemoveGroupMember", "group_id", req.GroupID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input RemoveGroupMember group_id :", req.GroupID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.RemoveGroupMember(ctx, req)
+}
+
+func (mw loggingMiddleware) ShareListWithGroup(ctx context.Context, req api.ShareListWithGroupRequest) (resp api.ShareListWithGroupResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "ShareListWithGroup", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input ShareListWithGroup list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.ShareListWithGroup(ctx, req)
+}
+
+func (mw loggingMiddleware) UnshareListWithGroup(ctx context.Context, req api.UnshareListWithGroupRequest) (resp api.UnshareListWithGroupResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "UnshareListWithGroup", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input UnshareListWithGroup list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.UnshareListWithGroup(ctx, req)
+}
+
+func (mw loggingMiddleware) CreatePublicLink(ctx context.Context, req api.CreatePublicLinkRequest) (resp api.CreatePublicLinkResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "CreatePublicLink", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input CreatePublicLink list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.CreatePublicLink(ctx, req)
+}
+
+func (mw loggingMiddleware) GetPublicLinks(ctx context.Context, req api.GetPublicLinksRequest) (resp api.GetPublicLinksResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "GetPublicLinks", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input GetPublicLinks list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.GetPublicLinks(ctx, req)
+}
+
+func (mw loggingMiddleware) RevokePublicLink(ctx context.Context, req api.RevokePublicLinkRequest) (resp api.RevokePublicLinkResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "RevokePublicLink", "link_id", req.LinkID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input RevokePublicLink link_id :", req.LinkID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.RevokePublicLink(ctx, req)
+}
+
+func (mw loggingMiddleware) GetPublicList(ctx context.Context, req api.GetPublicListRequest) (resp api.GetPublicListResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "GetPublicList", "list_id", resp.List.ID, "resp", len(resp.Items))
+		} else {
+			mw.logger.Log("failed for input GetPublicList :", "error : ", resp.Err)
+		}
+	}()
+	return mw.next.GetPublicList(ctx, req)
+}
+
+func (mw loggingMiddleware) BuyPublicItem(ctx context.Context, req api.BuyPublicItemRequest) (resp api.BuyPublicItemResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "BuyPublicItem", "item_id", req.ItemID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input BuyPublicItem item_id :", req.ItemID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.BuyPublicItem(ctx, req)
+}
+
+func (mw loggingMiddleware) CreateCategory(ctx context.Context, req api.CreateCategoryRequest) (resp api.CreateCategoryResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "CreateCategory", "name", req.Name, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input CreateCategory name :", req.Name, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.CreateCategory(ctx, req)
+}
+
+func (mw loggingMiddleware) UpdateCategory(ctx context.Context, req api.UpdateCategoryRequest) (resp api.UpdateCategoryResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "UpdateCategory", "category_id", req.CategoryID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input UpdateCategory category_id :", req.CategoryID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.UpdateCategory(ctx, req)
+}
+
+func (mw loggingMiddleware) DeleteCategory(ctx context.Context, req api.DeleteCategoryRequest) (resp api.DeleteCategoryResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "DeleteCategory", "category_id", req.CategoryID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input DeleteCategory category_id :", req.CategoryID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.DeleteCategory(ctx, req)
+}
+
+func (mw loggingMiddleware) MergeCategories(ctx context.Context, req api.MergeCategoriesRequest) (resp api.MergeCategoriesResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "MergeCategories", "category_id", req.CategoryID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input MergeCategories category_id :", req.CategoryID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.MergeCategories(ctx, req)
+}
+
+func (mw loggingMiddleware) CreateCategoryRule(ctx context.Context, req api.CreateCategoryRuleRequest) (resp api.CreateCategoryRuleResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "CreateCategoryRule", "category_id", req.CategoryID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input CreateCategoryRule category_id :", req.CategoryID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.CreateCategoryRule(ctx, req)
+}
+
+func (mw loggingMiddleware) GetCategoryRules(ctx context.Context, req api.GetCategoryRulesRequest) (resp api.GetCategoryRulesResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "GetCategoryRules", "user_id", req.UserID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input GetCategoryRules user_id :", req.UserID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.GetCategoryRules(ctx, req)
+}
+
+func (mw loggingMiddleware) DeleteCategoryRule(ctx context.Context, req api.DeleteCategoryRuleRequest) (resp api.DeleteCategoryRuleResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "DeleteCategoryRule", "rule_id", req.RuleID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input DeleteCategoryRule rule_id :", req.RuleID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.DeleteCategoryRule(ctx, req)
+}
+
+func (mw loggingMiddleware) CreateStore(ctx context.Context, req api.CreateStoreRequest) (resp api.CreateStoreResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "CreateStore", "user_id", req.UserID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input CreateStore user_id :", req.UserID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.CreateStore(ctx, req)
+}
+
+func (mw loggingMiddleware) GetStores(ctx context.Context, req api.GetStoresRequest) (resp api.GetStoresResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "GetStores", "user_id", req.UserID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input GetStores user_id :", req.UserID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.GetStores(ctx, req)
+}
+
+func (mw loggingMiddleware) UpdateStore(ctx context.Context, req api.UpdateStoreRequest) (resp api.UpdateStoreResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "UpdateStore", "store_id", req.StoreID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input UpdateStore store_id :", req.StoreID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.UpdateStore(ctx, req)
+}
+
+func (mw loggingMiddleware) DeleteStore(ctx context.Context, req api.DeleteStoreRequest) (resp api.DeleteStoreResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "DeleteStore", "store_id", req.StoreID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input DeleteStore store_id :", req.StoreID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.DeleteStore(ctx, req)
+}
+
+func (mw loggingMiddleware) ReorderItems(ctx context.Context, req api.ReorderItemsRequest) (resp api.ReorderItemsResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "ReorderItems", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input ReorderItems list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.ReorderItems(ctx, req)
+}
+
+func (mw loggingMiddleware) MoveItems(ctx context.Context, req api.MoveItemsRequest) (resp api.MoveItemsResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "MoveItems", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input MoveItems list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.MoveItems(ctx, req)
+}
+
+func (mw loggingMiddleware) CopyItems(ctx context.Context, req api.CopyItemsRequest) (resp api.CopyItemsResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "CopyItems", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input CopyItems list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.CopyItems(ctx, req)
+}
+
+func (mw loggingMiddleware) CloneList(ctx context.Context, req api.CloneListRequest) (resp api.CloneListResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "CloneList", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input CloneList list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.CloneList(ctx, req)
+}
+
+func (mw loggingMiddleware) CreateTemplate(ctx context.Context, req api.CreateTemplateRequest) (resp api.CreateTemplateResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "CreateTemplate", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input CreateTemplate list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.CreateTemplate(ctx, req)
+}
+
+func (mw loggingMiddleware) GetTemplates(ctx context.Context, req api.GetTemplatesRequest) (resp api.GetTemplatesResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "GetTemplates", "user_id", req.UserID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input GetTemplates user_id :", req.UserID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.GetTemplates(ctx, req)
+}
+
+func (mw loggingMiddleware) InstantiateTemplate(ctx context.Context, req api.InstantiateTemplateRequest) (resp api.InstantiateTemplateResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "InstantiateTemplate", "template_id", req.TemplateID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input InstantiateTemplate template_id :", req.TemplateID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.InstantiateTemplate(ctx, req)
+}
+
+func (mw loggingMiddleware) CreateRecurrence(ctx context.Context, req api.CreateRecurrenceRequest) (resp api.CreateRecurrenceResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "CreateRecurrence", "list_id", req.ListID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input CreateRecurrence list_id :", req.ListID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.CreateRecurrence(ctx, req)
+}
+
+func (mw loggingMiddleware) GetRecurrences(ctx context.Context, req api.GetRecurrencesRequest) (resp api.GetRecurrencesResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "GetRecurrences", "user_id", req.UserID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input GetRecurrences user_id :", req.UserID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.GetRecurrences(ctx, req)
+}
+
+func (mw loggingMiddleware) PauseRecurrence(ctx context.Context, req api.PauseRecurrenceRequest) (resp api.PauseRecurrenceResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "PauseRecurrence", "recurrence_id", req.RecurrenceID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input PauseRecurrence recurrence_id :", req.RecurrenceID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.PauseRecurrence(ctx, req)
+}
+
+func (mw loggingMiddleware) ResumeRecurrence(ctx context.Context, req api.ResumeRecurrenceRequest) (resp api.ResumeRecurrenceResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "ResumeRecurrence", "recurrence_id", req.RecurrenceID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input ResumeRecurrence recurrence_id :", req.RecurrenceID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.ResumeRecurrence(ctx, req)
+}
+
+func (mw loggingMiddleware) DeleteRecurrence(ctx context.Context, req api.DeleteRecurrenceRequest) (resp api.DeleteRecurrenceResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "DeleteRecurrence", "recurrence_id", req.RecurrenceID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input DeleteRecurrence recurrence_id :", req.RecurrenceID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.DeleteRecurrence(ctx, req)
+}
+
+func (mw loggingMiddleware) GetRecurrenceInstances(ctx context.Context, req api.GetRecurrenceInstancesRequest) (resp api.GetRecurrenceInstancesResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "GetRecurrenceInstances", "recurrence_id", req.RecurrenceID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input GetRecurrenceInstances recurrence_id :", req.RecurrenceID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.GetRecurrenceInstances(ctx, req)
+}
+
+func (mw loggingMiddleware) GetDueItems(ctx context.Context, req api.GetDueItemsRequest) (resp api.GetDueItemsResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "GetDueItems", "user_id", req.UserID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input GetDueItems user_id :", req.UserID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.GetDueItems(ctx, req)
+}
+
+func (mw loggingMiddleware) GetReminderPreferences(ctx context.Context, req api.GetReminderPreferencesRequest) (resp api.GetReminderPreferencesResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "GetReminderPreferences", "user_id", req.UserID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input GetReminderPreferences user_id :", req.UserID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.GetReminderPreferences(ctx, req)
+}
+
+func (mw loggingMiddleware) UpdateReminderPreferences(ctx context.Context, req api.UpdateReminderPreferencesRequest) (resp api.UpdateReminderPreferencesResponse) {
+	defer func() {
+		if resp.Err == nil {
+			mw.logger.Log("method", "UpdateReminderPreferences", "user_id", req.UserID, "resp", resp)
+		} else {
+			mw.logger.Log("failed for input UpdateReminderPreferences user_id :", req.UserID, "error : ", resp.Err)
+		}
+	}()
+	return mw.next.UpdateReminderPreferences(ctx, req)
+}
+
+// AuthorisationMiddleware takes a policy and a logger as dependencies and
+// returns a ServiceMiddleware that asks the policy before every action taken
+// on a list or group. Denied requests are logged and never reach the service.
+func AuthorisationMiddleware(policy Policy, logger log.Logger) Middleware {
+	return func(next Service) Service {
+		return authorisationMiddleware{policy, logger, next}
+	}
+}
+
+type authorisationMiddleware struct {
+	policy Policy
+	logger log.Logger
+	next   Service
+}
+
+// can asks the policy whether user may take action on resource
+func (mw authorisationMiddleware) can(ctx context.Context, method string, userID int64, action string, resource Resource) error {
+	err := mw.policy.Can(ctx, userID, action, resource)
+	if err != nil {
+		mw.logger.Log("method", method, "user", userID, "action", action, "resource", resource, "denied", err)
+	}
+	return err
+}
+
+// canUseCategories asks the policy whether user may use each of the categories
+func (mw authorisationMiddleware) canUseCategories(ctx context.Context, method string, userID int64, categoryIDs []int64) error {
+	for _, categoryID := range categoryIDs {
+		err := mw.can(ctx, method, userID, actionUseCategory, Resource{CategoryID: categoryID})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mw authorisationMiddleware) Ping(ctx context.Context, req api.PingRequest) (resp api.PingResponse) {
+	// not taken on a list or group
+	return mw.next.Ping(ctx, req)
+}
+
+func (mw authorisationMiddleware) Signup(ctx context.Context, req api.SignupRequest) (resp api.SignupResponse) {
+	// not taken on a list or group
+	return mw.next.Signup(ctx, req)
+}
+
+func (mw authorisationMiddleware) Login(ctx context.Context, req api.LoginRequest) (resp api.LoginResponse) {
+	// not taken on a list or group
+	return mw.next.Login(ctx, req)
+}
+
+func (mw authorisationMiddleware) Logout(ctx context.Context, req api.LogoutRequest) (resp api.LogoutResponse) {
+	// not taken on a list or group
+	return mw.next.Logout(ctx, req)
+}
+
+func (mw authorisationMiddleware) CreateList(ctx context.Context, req api.CreateListRequest) (resp api.CreateListResponse) {
+	// lists are only linked to the stores of their owner
+	if req.List.StoreID != 0 {
+		resp.Err = mw.can(ctx, "CreateList", req.List.Owner.UserID, actionManageStore, Resource{StoreID: req.List.StoreID})
+		if resp.Err != nil {
+			return
+		}
+	}
+	return mw.next.CreateList(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetLists(ctx context.Context, req api.GetListsRequest) (resp api.GetListsResponse) {
+	// not taken on a list or group
+	return mw.next.GetLists(ctx, req)
+}
+
+func (mw authorisationMiddleware) UpdateList(ctx context.Context, req api.UpdateListRequest) (resp api.UpdateListResponse) {
+	resp.Err = mw.can(ctx, "UpdateList", req.UserID, actionEditList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	// moving the list to the trash deletes it
+	if req.Status != nil && strings.Compare(*req.Status, api.Deleted) == 0 {
+		resp.Err = mw.can(ctx, "UpdateList", req.UserID, actionDeleteList, Resource{ListID: req.ListID})
+		if resp.Err != nil {
+			return
+		}
+	}
+	// users link lists to their own stores
+	if req.StoreID != nil && *req.StoreID != 0 {
+		resp.Err = mw.can(ctx, "UpdateList", req.UserID, actionManageStore, Resource{StoreID: *req.StoreID})
+		if resp.Err != nil {
+			return
+		}
+	}
+	return mw.next.UpdateList(ctx, req)
+}
+
+func (mw authorisationMiddleware) CreateItem(ctx context.Context, req api.CreateItemRequest) (resp api.CreateItemResponse) {
+	resp.Err = mw.can(ctx, "CreateItem", req.Item.CreatedBy.UserID, actionEditItems, Resource{ListID: req.Item.ListID})
+	if resp.Err != nil {
+		return
+	}
+	// a category given by name is found among or added to the ones the user sees
+	if req.Item.Category.ID != 0 {
+		resp.Err = mw.can(ctx, "CreateItem", req.Item.CreatedBy.UserID, actionUseCategory, Resource{CategoryID: req.Item.Category.ID})
+		if resp.Err != nil {
+			return
+		}
+	}
+	return mw.next.CreateItem(ctx, req)
+}
+
+func (mw authorisationMiddleware) UpdateItem(ctx context.Context, req api.UpdateItemRequest) (resp api.UpdateItemResponse) {
+	resp.Err = mw.can(ctx, "UpdateItem", req.UserID, actionEditItems, Resource{ItemID: req.ItemID})
+	if resp.Err != nil {
+		return
+	}
+	if req.Category != nil && req.Category.ID != 0 {
+		resp.Err = mw.can(ctx, "UpdateItem", req.UserID, actionUseCategory, Resource{CategoryID: req.Category.ID})
+		if resp.Err != nil {
+			return
+		}
+	}
+	return mw.next.UpdateItem(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetListItems(ctx context.Context, req api.GetListItemsRequest) (resp api.GetListItemsResponse) {
+	resp.Err = mw.can(ctx, "GetListItems", req.UserID, actionViewList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.GetListItems(ctx, req)
+}
+
+func (mw authorisationMiddleware) BuyItem(ctx context.Context, req api.BuyItemRequest) (resp api.BuyItemResponse) {
+	resp.Err = mw.can(ctx, "BuyItem", req.UserID, actionBuyItems, Resource{ItemID: req.ItemID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.BuyItem(ctx, req)
+}
+
+func (mw authorisationMiddleware) ShareList(ctx context.Context, req api.ShareListRequest) (resp api.ShareListResponse) {
+	resp.Err = mw.can(ctx, "ShareList", req.UserID, actionShareList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.ShareList(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetAllCategories(ctx context.Context, req api.GetAllCategoriesRequest) (resp api.GetAllCategoriesResponse) {
+	// not taken on a list or group
+	return mw.next.GetAllCategories(ctx, req)
+}
+
+func (mw authorisationMiddleware) DeleteList(ctx context.Context, req api.DeleteListRequest) (resp api.DeleteListResponse) {
+	resp.Err = mw.can(ctx, "DeleteList", req.UserID, actionDeleteList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.DeleteList(ctx, req)
+}
+
+func (mw authorisationMiddleware) DeleteItem(ctx context.Context, req api.DeleteItemRequest) (resp api.DeleteItemResponse) {
+	resp.Err = mw.can(ctx, "DeleteItem", req.UserID, actionEditItems, Resource{ItemID: req.ItemID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.DeleteItem(ctx, req)
+}
+
+func (mw authorisationMiddleware) UnbuyItem(ctx context.Context, req api.UnbuyItemRequest) (resp api.UnbuyItemResponse) {
+	resp.Err = mw.can(ctx, "UnbuyItem", req.UserID, actionBuyItems, Resource{ItemID: req.ItemID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.UnbuyItem(ctx, req)
+}
+
+func (mw authorisationMiddleware) RestoreItem(ctx context.Context, req api.RestoreItemRequest) (resp api.RestoreItemResponse) {
+	resp.Err = mw.can(ctx, "RestoreItem", req.UserID, actionEditItems, Resource{ItemID: req.ItemID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.RestoreItem(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetTrash(ctx context.Context, req api.GetTrashRequest) (resp api.GetTrashResponse) {
+	// not taken on a list or group
+	return mw.next.GetTrash(ctx, req)
+}
+
+func (mw authorisationMiddleware) RestoreList(ctx context.Context, req api.RestoreListRequest) (resp api.RestoreListResponse) {
+	resp.Err = mw.can(ctx, "RestoreList", req.UserID, actionDeleteList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.RestoreList(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetContributors(ctx context.Context, req api.GetContributorsRequest) (resp api.GetContributorsResponse) {
+	resp.Err = mw.can(ctx, "GetContributors", req.UserID, actionShareList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.GetContributors(ctx, req)
+}
+
+func (mw authorisationMiddleware) UpdateContributor(ctx context.Context, req api.UpdateContributorRequest) (resp api.UpdateContributorResponse) {
+	resp.Err = mw.can(ctx, "UpdateContributor", req.UserID, actionShareList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.UpdateContributor(ctx, req)
+}
+
+func (mw authorisationMiddleware) RevokeContributor(ctx context.Context, req api.RevokeContributorRequest) (resp api.RevokeContributorResponse) {
+	resp.Err = mw.can(ctx, "RevokeContributor", req.UserID, actionShareList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.RevokeContributor(ctx, req)
+}
+
+func (mw authorisationMiddleware) CreateInvite(ctx context.Context, req api.CreateInviteRequest) (resp api.CreateInviteResponse) {
+	resp.Err = mw.can(ctx, "CreateInvite", req.UserID, actionShareList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.CreateInvite(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetInvites(ctx context.Context, req api.GetInvitesRequest) (resp api.GetInvitesResponse) {
+	resp.Err = mw.can(ctx, "GetInvites", req.UserID, actionShareList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.GetInvites(ctx, req)
+}
+
+func (mw authorisationMiddleware) CancelInvite(ctx context.Context, req api.CancelInviteRequest) (resp api.CancelInviteResponse) {
+	resp.Err = mw.can(ctx, "CancelInvite", req.UserID, actionShareList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.CancelInvite(ctx, req)
+}
+
+func (mw authorisationMiddleware) AcceptInvite(ctx context.Context, req api.AcceptInviteRequest) (resp api.AcceptInviteResponse) {
+	// not taken on a list or group
+	return mw.next.AcceptInvite(ctx, req)
+}
+
+func (mw authorisationMiddleware) TransferOwnership(ctx context.Context, req api.TransferOwnershipRequest) (resp api.TransferOwnershipResponse) {
+	resp.Err = mw.can(ctx, "TransferOwnership", req.UserID, actionTransferList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.TransferOwnership(ctx, req)
+}
+
+func (mw authorisationMiddleware) CreateGroup(ctx context.Context, req api.CreateGroupRequest) (resp api.CreateGroupResponse) {
+	// not taken on a list or group
+	return mw.next.CreateGroup(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetGroups(ctx context.Context, req api.GetGroupsRequest) (resp api.GetGroupsResponse) {
+	// not taken on a list or group
+	return mw.next.GetGroups(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetGroupMembers(ctx context.Context, req api.GetGroupMembersRequest) (resp api.GetGroupMembersResponse) {
+	resp.Err = mw.can(ctx, "GetGroupMembers", req.UserID, actionViewGroup, Resource{GroupID: req.GroupID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.GetGroupMembers(ctx, req)
+}
+
+func (mw authorisationMiddleware) AddGroupMember(ctx context.Context, req api.AddGroupMemberRequest) (resp api.AddGroupMemberResponse) {
+	resp.Err = mw.can(ctx, "AddGroupMember", req.UserID, actionManageGroup, Resource{GroupID: req.GroupID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.AddGroupMember(ctx, req)
+}
+
+func (mw authorisationMiddleware) RemoveGroupMember(ctx context.Context, req api.RemoveGroupMemberRequest) (resp api.RemoveGroupMemberResponse) {
+	resp.Err = mw.can(ctx, "RemoveGroupMember", req.UserID, actionManageGroup, Resource{GroupID: req.GroupID, MemberID: req.MemberID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.RemoveGroupMember(ctx, req)
+}
+
+func (mw authorisationMiddleware) ShareListWithGroup(ctx context.Context, req api.ShareListWithGroupRequest) (resp api.ShareListWithGroupResponse) {
+	resp.Err = mw.can(ctx, "ShareListWithGroup", req.UserID, actionShareList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.ShareListWithGroup(ctx, req)
+}
+
+func (mw authorisationMiddleware) UnshareListWithGroup(ctx context.Context, req api.UnshareListWithGroupRequest) (resp api.UnshareListWithGroupResponse) {
+	resp.Err = mw.can(ctx, "UnshareListWithGroup", req.UserID, actionShareList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.UnshareListWithGroup(ctx, req)
+}
+
+func (mw authorisationMiddleware) CreatePublicLink(ctx context.Context, req api.CreatePublicLinkRequest) (resp api.CreatePublicLinkResponse) {
+	resp.Err = mw.can(ctx, "CreatePublicLink", req.UserID, actionPublishList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.CreatePublicLink(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetPublicLinks(ctx context.Context, req api.GetPublicLinksRequest) (resp api.GetPublicLinksResponse) {
+	resp.Err = mw.can(ctx, "GetPublicLinks", req.UserID, actionPublishList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.GetPublicLinks(ctx, req)
+}
+
+func (mw authorisationMiddleware) RevokePublicLink(ctx context.Context, req api.RevokePublicLinkRequest) (resp api.RevokePublicLinkResponse) {
+	resp.Err = mw.can(ctx, "RevokePublicLink", req.UserID, actionPublishList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.RevokePublicLink(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetPublicList(ctx context.Context, req api.GetPublicListRequest) (resp api.GetPublicListResponse) {
+	// authorised by the public link token rather than a user
+	return mw.next.GetPublicList(ctx, req)
+}
+
+func (mw authorisationMiddleware) BuyPublicItem(ctx context.Context, req api.BuyPublicItemRequest) (resp api.BuyPublicItemResponse) {
+	// authorised by the public link token rather than a user
+	return mw.next.BuyPublicItem(ctx, req)
+}
+
+func (mw authorisationMiddleware) CreateCategory(ctx context.Context, req api.CreateCategoryRequest) (resp api.CreateCategoryResponse) {
+	// any member can add categories to a group
+	if req.GroupID != 0 {
+		resp.Err = mw.can(ctx, "CreateCategory", req.UserID, actionViewGroup, Resource{GroupID: req.GroupID})
+		if resp.Err != nil {
+			return
+		}
+	}
+	return mw.next.CreateCategory(ctx, req)
+}
+
+func (mw authorisationMiddleware) UpdateCategory(ctx context.Context, req api.UpdateCategoryRequest) (resp api.UpdateCategoryResponse) {
+	resp.Err = mw.can(ctx, "UpdateCategory", req.UserID, actionManageCategory, Resource{CategoryID: req.CategoryID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.UpdateCategory(ctx, req)
+}
+
+func (mw authorisationMiddleware) DeleteCategory(ctx context.Context, req api.DeleteCategoryRequest) (resp api.DeleteCategoryResponse) {
+	resp.Err = mw.can(ctx, "DeleteCategory", req.UserID, actionManageCategory, Resource{CategoryID: req.CategoryID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.DeleteCategory(ctx, req)
+}
+
+func (mw authorisationMiddleware) MergeCategories(ctx context.Context, req api.MergeCategoriesRequest) (resp api.MergeCategoriesResponse) {
+	// items can move to any category the user sees, but only out of the ones they manage
+	resp.Err = mw.can(ctx, "MergeCategories", req.UserID, actionUseCategory, Resource{CategoryID: req.CategoryID})
+	if resp.Err != nil {
+		return
+	}
+	for _, duplicateID := range req.DuplicateIDs {
+		resp.Err = mw.can(ctx, "MergeCategories", req.UserID, actionManageCategory, Resource{CategoryID: duplicateID})
+		if resp.Err != nil {
+			return
+		}
+	}
+	return mw.next.MergeCategories(ctx, req)
+}
+
+func (mw authorisationMiddleware) CreateCategoryRule(ctx context.Context, req api.CreateCategoryRuleRequest) (resp api.CreateCategoryRuleResponse) {
+	resp.Err = mw.can(ctx, "CreateCategoryRule", req.UserID, actionUseCategory, Resource{CategoryID: req.CategoryID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.CreateCategoryRule(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetCategoryRules(ctx context.Context, req api.GetCategoryRulesRequest) (resp api.GetCategoryRulesResponse) {
+	// not taken on a list or group
+	return mw.next.GetCategoryRules(ctx, req)
+}
+
+func (mw authorisationMiddleware) DeleteCategoryRule(ctx context.Context, req api.DeleteCategoryRuleRequest) (resp api.DeleteCategoryRuleResponse) {
+	resp.Err = mw.can(ctx, "DeleteCategoryRule", req.UserID, actionManageRule, Resource{RuleID: req.RuleID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.DeleteCategoryRule(ctx, req)
+}
+
+func (mw authorisationMiddleware) CreateStore(ctx context.Context, req api.CreateStoreRequest) (resp api.CreateStoreResponse) {
+	// taken on the stores of the user alone, laid out by categories they can use
+	resp.Err = mw.canUseCategories(ctx, "CreateStore", req.UserID, req.CategoryIDs)
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.CreateStore(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetStores(ctx context.Context, req api.GetStoresRequest) (resp api.GetStoresResponse) {
+	// taken on the stores of the user alone
+	return mw.next.GetStores(ctx, req)
+}
+
+func (mw authorisationMiddleware) UpdateStore(ctx context.Context, req api.UpdateStoreRequest) (resp api.UpdateStoreResponse) {
+	resp.Err = mw.can(ctx, "UpdateStore", req.UserID, actionManageStore, Resource{StoreID: req.StoreID})
+	if resp.Err != nil {
+		return
+	}
+	if req.CategoryIDs != nil {
+		resp.Err = mw.canUseCategories(ctx, "UpdateStore", req.UserID, *req.CategoryIDs)
+		if resp.Err != nil {
+			return
+		}
+	}
+	return mw.next.UpdateStore(ctx, req)
+}
+
+func (mw authorisationMiddleware) DeleteStore(ctx context.Context, req api.DeleteStoreRequest) (resp api.DeleteStoreResponse) {
+	resp.Err = mw.can(ctx, "DeleteStore", req.UserID, actionManageStore, Resource{StoreID: req.StoreID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.DeleteStore(ctx, req)
+}
+
+func (mw authorisationMiddleware) ReorderItems(ctx context.Context, req api.ReorderItemsRequest) (resp api.ReorderItemsResponse) {
+	resp.Err = mw.can(ctx, "ReorderItems", req.UserID, actionEditItems, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.ReorderItems(ctx, req)
+}
+
+func (mw authorisationMiddleware) MoveItems(ctx context.Context, req api.MoveItemsRequest) (resp api.MoveItemsResponse) {
+	// items leave one list and join another, the user edits both
+	for _, listID := range []int64{req.ListID, req.ToListID} {
+		resp.Err = mw.can(ctx, "MoveItems", req.UserID, actionEditItems, Resource{ListID: listID})
+		if resp.Err != nil {
+			return
+		}
+	}
+	return mw.next.MoveItems(ctx, req)
+}
+
+func (mw authorisationMiddleware) CopyItems(ctx context.Context, req api.CopyItemsRequest) (resp api.CopyItemsResponse) {
+	// items leave one list and join another, the user edits both
+	for _, listID := range []int64{req.ListID, req.ToListID} {
+		resp.Err = mw.can(ctx, "CopyItems", req.UserID, actionEditItems, Resource{ListID: listID})
+		if resp.Err != nil {
+			return
+		}
+	}
+	return mw.next.CopyItems(ctx, req)
+}
+
+func (mw authorisationMiddleware) CloneList(ctx context.Context, req api.CloneListRequest) (resp api.CloneListResponse) {
+	resp.Err = mw.can(ctx, "CloneList", req.UserID, actionViewList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.CloneList(ctx, req)
+}
+
+func (mw authorisationMiddleware) CreateTemplate(ctx context.Context, req api.CreateTemplateRequest) (resp api.CreateTemplateResponse) {
+	resp.Err = mw.can(ctx, "CreateTemplate", req.UserID, actionViewList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.CreateTemplate(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetTemplates(ctx context.Context, req api.GetTemplatesRequest) (resp api.GetTemplatesResponse) {
+	// not taken on a list or group
+	return mw.next.GetTemplates(ctx, req)
+}
+
+func (mw authorisationMiddleware) InstantiateTemplate(ctx context.Context, req api.InstantiateTemplateRequest) (resp api.InstantiateTemplateResponse) {
+	resp.Err = mw.can(ctx, "InstantiateTemplate", req.UserID, actionViewList, Resource{ListID: req.TemplateID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.InstantiateTemplate(ctx, req)
+}
+
+func (mw authorisationMiddleware) CreateRecurrence(ctx context.Context, req api.CreateRecurrenceRequest) (resp api.CreateRecurrenceResponse) {
+	resp.Err = mw.can(ctx, "CreateRecurrence", req.UserID, actionViewList, Resource{ListID: req.ListID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.CreateRecurrence(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetRecurrences(ctx context.Context, req api.GetRecurrencesRequest) (resp api.GetRecurrencesResponse) {
+	// not taken on a list or group
+	return mw.next.GetRecurrences(ctx, req)
+}
+
+func (mw authorisationMiddleware) PauseRecurrence(ctx context.Context, req api.PauseRecurrenceRequest) (resp api.PauseRecurrenceResponse) {
+	resp.Err = mw.can(ctx, "PauseRecurrence", req.UserID, actionManageRecurrence, Resource{RecurrenceID: req.RecurrenceID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.PauseRecurrence(ctx, req)
+}
+
+func (mw authorisationMiddleware) ResumeRecurrence(ctx context.Context, req api.ResumeRecurrenceRequest) (resp api.ResumeRecurrenceResponse) {
+	resp.Err = mw.can(ctx, "ResumeRecurrence", req.UserID, actionManageRecurrence, Resource{RecurrenceID: req.RecurrenceID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.ResumeRecurrence(ctx, req)
+}
+
+func (mw authorisationMiddleware) DeleteRecurrence(ctx context.Context, req api.DeleteRecurrenceRequest) (resp api.DeleteRecurrenceResponse) {
+	resp.Err = mw.can(ctx, "DeleteRecurrence", req.UserID, actionManageRecurrence, Resource{RecurrenceID: req.RecurrenceID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.DeleteRecurrence(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetRecurrenceInstances(ctx context.Context, req api.GetRecurrenceInstancesRequest) (resp api.GetRecurrenceInstancesResponse) {
+	resp.Err = mw.can(ctx, "GetRecurrenceInstances", req.UserID, actionManageRecurrence, Resource{RecurrenceID: req.RecurrenceID})
+	if resp.Err != nil {
+		return
+	}
+	return mw.next.GetRecurrenceInstances(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetDueItems(ctx context.Context, req api.GetDueItemsRequest) (resp api.GetDueItemsResponse) {
+	// only the lists user can view are read
+	return mw.next.GetDueItems(ctx, req)
+}
+
+func (mw authorisationMiddleware) GetReminderPreferences(ctx context.Context, req api.GetReminderPreferencesRequest) (resp api.GetReminderPreferencesResponse) {
+	// only the user's own preferences are touched
+	return mw.next.GetReminderPreferences(ctx, req)
+}
+
+func (mw authorisationMiddleware) UpdateReminderPreferences(ctx context.Context, req api.UpdateReminderPreferencesRequest) (resp api.UpdateReminderPreferencesResponse) {
+	// only the user's own preferences are touched
+	return mw.next.UpdateReminderPreferences(ctx, req)
+}
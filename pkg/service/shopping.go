@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"shoppinglist/pkg/api"
+	"shoppinglist/pkg/store"
+	"sort"
+	"strings"
+)
+
+// listLayout returns the categories of the store a list is linked to in aisle
+// order, a list without a store has no layout
+func listLayout(ctx context.Context, repo store.Repository, listID int64) ([]api.Category, error) {
+	list, err := repo.GetList(ctx, listID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return nil, errors.New(fmt.Sprintf("list %v does not exist", listID))
+		}
+		return nil, errors.Wrapf(err, "failed to read list details")
+	}
+	if list.StoreID == 0 {
+		return nil, nil
+	}
+	shop, err := repo.GetStore(ctx, list.StoreID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read store of list")
+	}
+	return shop.Categories, nil
+}
+
+// shoppingSections groups items by category in the order of layout, categories
+// outside the layout follow by name. Bought items sink to the bottom of their
+// category and categories with nothing left to buy to the bottom of the list.
+// It also returns the items in the order of the sections.
+func shoppingSections(items []api.Item, layout []api.Category) ([]api.Item, []api.ItemSection) {
+	aisles := make(map[int64]int)
+	for i, category := range layout {
+		aisles[category.ID] = i + 1
+	}
+	sections := []api.ItemSection{}
+	index := make(map[int64]int)
+	for _, item := range items {
+		i, ok := index[item.Category.ID]
+		if !ok {
+			i = len(sections)
+			index[item.Category.ID] = i
+			sections = append(sections, api.ItemSection{Category: item.Category, Aisle: aisles[item.Category.ID]})
+		}
+		sections[i].Items = append(sections[i].Items, item)
+	}
+
+	bought := func(item api.Item) bool { return strings.Compare(item.Status, api.Bought) == 0 }
+	done := make(map[int64]bool)
+	for _, section := range sections {
+		sort.SliceStable(section.Items, func(i, j int) bool { return !bought(section.Items[i]) && bought(section.Items[j]) })
+		// once sorted the first item is only bought if all of them are
+		done[section.Category.ID] = bought(section.Items[0])
+	}
+	sort.SliceStable(sections, func(i, j int) bool {
+		si, sj := sections[i], sections[j]
+		if done[si.Category.ID] != done[sj.Category.ID] {
+			return !done[si.Category.ID]
+		}
+		if (si.Aisle == 0) != (sj.Aisle == 0) {
+			return si.Aisle != 0
+		}
+		if si.Aisle != sj.Aisle {
+			return si.Aisle < sj.Aisle
+		}
+		return strings.ToLower(si.Category.Name) < strings.ToLower(sj.Category.Name)
+	})
+
+	sorted := make([]api.Item, 0, len(items))
+	for _, section := range sections {
+		sorted = append(sorted, section.Items...)
+	}
+	return sorted, sections
+}
+
+// setStoreLayout lays out the aisles of a store with given categories
+func setStoreLayout(ctx context.Context, tx store.Repository, storeID int64, categoryIDs []int64) error {
+	err := tx.SetStoreLayout(ctx, storeID, categoryIDs)
+	if err != nil {
+		return errors.Wrapf(err, "failed to lay out store:%v", storeID)
+	}
+	return nil
+}
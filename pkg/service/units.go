@@ -0,0 +1,100 @@
+package service
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"math"
+	"shoppinglist/pkg/api"
+	"strings"
+)
+
+// unitAliases maps the spellings accepted from clients to a supported unit
+var unitAliases = map[string]string{
+	"":            api.UnitPieces,
+	"pc":          api.UnitPieces,
+	"pcs":         api.UnitPieces,
+	"piece":       api.UnitPieces,
+	"pieces":      api.UnitPieces,
+	"x":           api.UnitPieces,
+	"g":           api.UnitGram,
+	"gr":          api.UnitGram,
+	"gram":        api.UnitGram,
+	"grams":       api.UnitGram,
+	"kg":          api.UnitKilogram,
+	"kgs":         api.UnitKilogram,
+	"kilo":        api.UnitKilogram,
+	"kilos":       api.UnitKilogram,
+	"kilogram":    api.UnitKilogram,
+	"kilograms":   api.UnitKilogram,
+	"ml":          api.UnitMilliliter,
+	"milliliter":  api.UnitMilliliter,
+	"milliliters": api.UnitMilliliter,
+	"millilitre":  api.UnitMilliliter,
+	"millilitres": api.UnitMilliliter,
+	"l":           api.UnitLiter,
+	"lt":          api.UnitLiter,
+	"ltr":         api.UnitLiter,
+	"liter":       api.UnitLiter,
+	"liters":      api.UnitLiter,
+	"litre":       api.UnitLiter,
+	"litres":      api.UnitLiter,
+	"pack":        api.UnitPack,
+	"packs":       api.UnitPack,
+	"pk":          api.UnitPack,
+	"pkg":         api.UnitPack,
+	"packet":      api.UnitPack,
+	"packets":     api.UnitPack,
+}
+
+// unitBase gives the dimension of a unit and its size in the base unit of that
+// dimension, quantities can only be added up within the same dimension
+var unitBase = map[string]struct {
+	dimension string
+	factor    float64
+}{
+	api.UnitPieces:     {"count", 1},
+	api.UnitGram:       {"mass", 1},
+	api.UnitKilogram:   {"mass", 1000},
+	api.UnitMilliliter: {"volume", 1},
+	api.UnitLiter:      {"volume", 1000},
+	api.UnitPack:       {"pack", 1},
+}
+
+// normaliseUnit returns the supported unit for given client spelling
+func normaliseUnit(unit string) (string, error) {
+	normalised, ok := unitAliases[strings.ToLower(strings.TrimSpace(unit))]
+	if !ok {
+		return "", errors.New(fmt.Sprintf("unknown unit %v", unit))
+	}
+	return normalised, nil
+}
+
+// itemQuantity returns quantity and unit of an item, an item without
+// quantity counts as a single piece
+func itemQuantity(item api.Item) (float64, string) {
+	quantity, unit := item.Quantity, item.Unit
+	if quantity == 0 {
+		quantity = 1
+	}
+	if unit == "" {
+		unit = api.UnitPieces
+	}
+	return quantity, unit
+}
+
+// addQuantities adds quantity q2 in unit u2 to quantity q1 in unit u1, the
+// result is expressed in u1. It fails if the units measure different things.
+func addQuantities(q1 float64, u1 string, q2 float64, u2 string) (float64, error) {
+	b1, b2 := unitBase[u1], unitBase[u2]
+	if b1.dimension == "" || b1.dimension != b2.dimension {
+		return 0, errors.New(fmt.Sprintf("can not add %v to %v", u2, u1))
+	}
+	sum := q1 + q2*b2.factor/b1.factor
+	// drop floating point noise, quantities are stored with 3 decimals
+	return math.Round(sum*1000) / 1000, nil
+}
+
+// sameItemTitle reports whether two item titles name the same thing
+func sameItemTitle(t1 string, t2 string) bool {
+	return strings.EqualFold(strings.TrimSpace(t1), strings.TrimSpace(t2))
+}
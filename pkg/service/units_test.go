@@ -0,0 +1,47 @@
+package service
+
+import (
+	"shoppinglist/pkg/api"
+	"testing"
+)
+
+func TestNormaliseUnit(t *testing.T) {
+	for given, want := range map[string]string{
+		"":        api.UnitPieces,
+		" Kilos ": api.UnitKilogram,
+		"Litres":  api.UnitLiter,
+		"pkg":     api.UnitPack,
+	} {
+		unit, err := normaliseUnit(given)
+		if err != nil || unit != want {
+			t.Errorf("normaliseUnit(%q) = %q, %v, expected %q", given, unit, err, want)
+		}
+	}
+	if _, err := normaliseUnit("bucket"); err == nil {
+		t.Error("accepted an unknown unit")
+	}
+}
+
+func TestAddQuantities(t *testing.T) {
+	tests := []struct {
+		q1      float64
+		u1      string
+		q2      float64
+		u2      string
+		sum     float64
+		addable bool
+	}{
+		{1, api.UnitLiter, 500, api.UnitMilliliter, 1.5, true},
+		{250, api.UnitGram, 1.25, api.UnitKilogram, 1500, true},
+		{0.1, api.UnitKilogram, 0.2, api.UnitKilogram, 0.3, true},
+		{2, api.UnitPieces, 3, api.UnitPieces, 5, true},
+		{1, api.UnitLiter, 1, api.UnitKilogram, 0, false},
+		{1, api.UnitPack, 1, api.UnitPieces, 0, false},
+	}
+	for _, tt := range tests {
+		sum, err := addQuantities(tt.q1, tt.u1, tt.q2, tt.u2)
+		if (err == nil) != tt.addable || sum != tt.sum {
+			t.Errorf("%v %v + %v %v = %v, %v, expected %v", tt.q1, tt.u1, tt.q2, tt.u2, sum, err, tt.sum)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package session
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"sync"
+	"time"
+)
+
+type memSession struct {
+	userID    int64
+	expiresAt time.Time
+}
+
+// Memory is a Store keeping sessions in process memory, for running without redis.
+// Sessions are lost when the process exits.
+type Memory struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	sessions map[string]memSession
+}
+
+// NewMemory returns an empty in-memory Store. Sessions expire after ttl.
+func NewMemory(ttl time.Duration) *Memory {
+	return &Memory{ttl: ttl, sessions: make(map[string]memSession)}
+}
+
+// purge drops expired sessions, callers must hold mu
+func (s *Memory) purge(now time.Time) {
+	for token, sess := range s.sessions {
+		if !now.Before(sess.expiresAt) {
+			delete(s.sessions, token)
+		}
+	}
+}
+
+func (s *Memory) Create(ctx context.Context, userID int64) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.purge(now)
+	token := uuid.New().String()
+	s.sessions[token] = memSession{userID: userID, expiresAt: now.Add(s.ttl)}
+	return token, nil
+}
+
+func (s *Memory) Get(ctx context.Context, token string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[token]
+	if !ok {
+		return 0, ErrNoSession
+	}
+	if !time.Now().Before(sess.expiresAt) {
+		delete(s.sessions, token)
+		return 0, ErrNoSession
+	}
+	return sess.userID, nil
+}
+
+func (s *Memory) Refresh(ctx context.Context, token string) (string, error) {
+	userID, err := s.Get(ctx, token)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to refresh user session")
+	}
+	newToken, err := s.Create(ctx, userID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to refresh user session")
+	}
+	return newToken, s.Delete(ctx, token)
+}
+
+func (s *Memory) Delete(ctx context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, token)
+	return nil
+}
+
+func (s *Memory) DeleteAllForUser(ctx context.Context, userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for token, sess := range s.sessions {
+		if sess.userID == userID {
+			delete(s.sessions, token)
+		}
+	}
+	return nil
+}
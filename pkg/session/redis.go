@@ -0,0 +1,146 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// Redis is a Store keeping sessions in redis. Every request borrows its own
+// connection from the pool, so it can be shared across goroutines.
+type Redis struct {
+	pool *redis.Pool
+	ttl  time.Duration
+}
+
+// NewRedis returns a Store using connections from given pool.
+// Sessions expire after ttl.
+func NewRedis(pool *redis.Pool, ttl time.Duration) *Redis {
+	return &Redis{pool: pool, ttl: ttl}
+}
+
+// NewRedisPool returns a connection pool for the redis instance at given url
+func NewRedisPool(url string) *redis.Pool {
+	return &redis.Pool{
+		MaxIdle:     10,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			return redis.DialURL(url)
+		},
+		TestOnBorrow: func(c redis.Conn, t time.Time) error {
+			if time.Since(t) < time.Minute {
+				return nil
+			}
+			_, err := c.Do("PING")
+			return err
+		},
+	}
+}
+
+// userKey is the redis set holding all session tokens of a user
+func userKey(userID int64) string {
+	return fmt.Sprintf("user_sessions:%d", userID)
+}
+
+func (s *Redis) Create(ctx context.Context, userID int64) (string, error) {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get redis connection")
+	}
+	defer conn.Close()
+
+	token := uuid.New().String()
+	ttl := int64(s.ttl / time.Second)
+	conn.Send("MULTI")
+	conn.Send("SETEX", token, ttl, userID)
+	conn.Send("SADD", userKey(userID), token)
+	conn.Send("EXPIRE", userKey(userID), ttl)
+	_, err = conn.Do("EXEC")
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to set the session for user %v", userID)
+	}
+	return token, nil
+}
+
+func (s *Redis) Get(ctx context.Context, token string) (int64, error) {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get redis connection")
+	}
+	defer conn.Close()
+
+	userID, err := redis.Int64(conn.Do("GET", token))
+	if err != nil {
+		if err == redis.ErrNil {
+			return 0, ErrNoSession
+		}
+		return 0, errors.Wrap(err, "failed to read user id from cache")
+	}
+	return userID, nil
+}
+
+func (s *Redis) Refresh(ctx context.Context, token string) (string, error) {
+	userID, err := s.Get(ctx, token)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to refresh user session")
+	}
+	newToken, err := s.Create(ctx, userID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to refresh user session")
+	}
+	err = s.Delete(ctx, token)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to delete old session while refreshing user session")
+	}
+	return newToken, nil
+}
+
+func (s *Redis) Delete(ctx context.Context, token string) error {
+	userID, err := s.Get(ctx, token)
+	if err != nil {
+		if err == ErrNoSession {
+			return nil
+		}
+		return err
+	}
+
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get redis connection")
+	}
+	defer conn.Close()
+	conn.Send("MULTI")
+	conn.Send("DEL", token)
+	conn.Send("SREM", userKey(userID), token)
+	_, err = conn.Do("EXEC")
+	if err != nil {
+		return errors.Wrap(err, "failed to delete session")
+	}
+	return nil
+}
+
+func (s *Redis) DeleteAllForUser(ctx context.Context, userID int64) error {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get redis connection")
+	}
+	defer conn.Close()
+
+	tokens, err := redis.Strings(conn.Do("SMEMBERS", userKey(userID)))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read sessions of user %v", userID)
+	}
+	conn.Send("MULTI")
+	for _, token := range tokens {
+		conn.Send("DEL", token)
+	}
+	conn.Send("DEL", userKey(userID))
+	_, err = conn.Do("EXEC")
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete sessions of user %v", userID)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package session
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// DefaultTTL is the time a session stays valid after it was created or refreshed
+const DefaultTTL = 120 * time.Second
+
+// ErrNoSession is returned when the session token is unknown or has expired
+var ErrNoSession = errors.New("session does not exist")
+
+// Store keeps the sessions of logged in users. Every session maps an
+// opaque token to the id of the user it was created for.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Create starts a new session for given user and returns its token
+	Create(ctx context.Context, userID int64) (string, error)
+	// Get returns the id of the user owning given session token
+	Get(ctx context.Context, token string) (int64, error)
+	// Refresh replaces given session token with a new one for the same user
+	Refresh(ctx context.Context, token string) (string, error)
+	// Delete ends the session of given token
+	Delete(ctx context.Context, token string) error
+	// DeleteAllForUser ends every session of given user
+	DeleteAllForUser(ctx context.Context, userID int64) error
+}
+
+// compile time assertions for our stores implementing Store.
+var (
+	_ Store = (*Redis)(nil)
+	_ Store = (*Memory)(nil)
+)
@@ -0,0 +1,199 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"github.com/gomodule/redigo/redis"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis keeps the keys and sets of the redis commands Redis uses
+type fakeRedis struct {
+	mu      sync.Mutex
+	values  map[string]string
+	expires map[string]time.Time
+	sets    map[string]map[string]bool
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{values: make(map[string]string), expires: make(map[string]time.Time), sets: make(map[string]map[string]bool)}
+}
+
+// exec runs a single command, callers must hold mu
+func (r *fakeRedis) exec(cmd string, args ...interface{}) (interface{}, error) {
+	key := func(i int) string { return fmt.Sprint(args[i]) }
+	switch cmd {
+	case "":
+		return nil, nil
+	case "SETEX":
+		var seconds int64
+		fmt.Sscan(key(1), &seconds)
+		r.values[key(0)] = key(2)
+		r.expires[key(0)] = time.Now().Add(time.Duration(seconds) * time.Second)
+		return "OK", nil
+	case "GET":
+		value, ok := r.values[key(0)]
+		if !ok || !time.Now().Before(r.expires[key(0)]) {
+			return nil, nil
+		}
+		return []byte(value), nil
+	case "SADD":
+		if r.sets[key(0)] == nil {
+			r.sets[key(0)] = make(map[string]bool)
+		}
+		r.sets[key(0)][key(1)] = true
+		return int64(1), nil
+	case "SREM":
+		delete(r.sets[key(0)], key(1))
+		return int64(1), nil
+	case "SMEMBERS":
+		var members []interface{}
+		for member := range r.sets[key(0)] {
+			members = append(members, []byte(member))
+		}
+		return members, nil
+	case "EXPIRE":
+		return int64(1), nil
+	case "DEL":
+		delete(r.values, key(0))
+		delete(r.sets, key(0))
+		return int64(1), nil
+	}
+	return nil, fmt.Errorf("unsupported command %v", cmd)
+}
+
+// fakeConn is a connection to fakeRedis queueing the commands of a transaction
+type fakeConn struct {
+	redis  *fakeRedis
+	queued [][]interface{}
+	multi  bool
+}
+
+func (c *fakeConn) Close() error { return nil }
+func (c *fakeConn) Err() error   { return nil }
+func (c *fakeConn) Flush() error { return nil }
+
+func (c *fakeConn) Receive() (interface{}, error) {
+	return nil, fmt.Errorf("receive is not supported")
+}
+
+func (c *fakeConn) Send(cmd string, args ...interface{}) error {
+	switch {
+	case cmd == "MULTI":
+		c.multi = true
+	case cmd == "DISCARD":
+		c.multi, c.queued = false, nil
+	case c.multi:
+		c.queued = append(c.queued, append([]interface{}{cmd}, args...))
+	default:
+		c.redis.mu.Lock()
+		defer c.redis.mu.Unlock()
+		_, err := c.redis.exec(cmd, args...)
+		return err
+	}
+	return nil
+}
+
+func (c *fakeConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	c.redis.mu.Lock()
+	defer c.redis.mu.Unlock()
+	if cmd != "EXEC" {
+		return c.redis.exec(cmd, args...)
+	}
+	var replies []interface{}
+	for _, queued := range c.queued {
+		reply, err := c.redis.exec(queued[0].(string), queued[1:]...)
+		if err != nil {
+			return nil, err
+		}
+		replies = append(replies, reply)
+	}
+	c.multi, c.queued = false, nil
+	return replies, nil
+}
+
+func newFakeRedisStore(ttl time.Duration) *Redis {
+	r := newFakeRedis()
+	pool := &redis.Pool{Dial: func() (redis.Conn, error) { return &fakeConn{redis: r}, nil }}
+	return NewRedis(pool, ttl)
+}
+
+// testStore checks the behaviour every Store has
+func testStore(t *testing.T, s Store) {
+	ctx := context.Background()
+	token, err := s.Create(ctx, 7)
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	if userID, err := s.Get(ctx, token); err != nil || userID != 7 {
+		t.Fatalf("session belongs to user %v, error %v", userID, err)
+	}
+	if _, err := s.Get(ctx, "unknown"); err != ErrNoSession {
+		t.Fatalf("reading an unknown session returned %v", err)
+	}
+
+	refreshed, err := s.Refresh(ctx, token)
+	if err != nil {
+		t.Fatalf("failed to refresh session: %v", err)
+	}
+	if refreshed == token {
+		t.Fatal("refreshing kept the session token")
+	}
+	if _, err := s.Get(ctx, token); err != ErrNoSession {
+		t.Fatalf("refreshed session token still works, error %v", err)
+	}
+	if userID, err := s.Get(ctx, refreshed); err != nil || userID != 7 {
+		t.Fatalf("refreshed session belongs to user %v, error %v", userID, err)
+	}
+
+	if err := s.Delete(ctx, refreshed); err != nil {
+		t.Fatalf("failed to delete session: %v", err)
+	}
+	if _, err := s.Get(ctx, refreshed); err != ErrNoSession {
+		t.Fatalf("deleted session still works, error %v", err)
+	}
+	if err := s.Delete(ctx, refreshed); err != nil {
+		t.Fatalf("deleting an ended session failed: %v", err)
+	}
+
+	first, _ := s.Create(ctx, 7)
+	second, _ := s.Create(ctx, 7)
+	other, _ := s.Create(ctx, 8)
+	if err := s.DeleteAllForUser(ctx, 7); err != nil {
+		t.Fatalf("failed to delete sessions of user: %v", err)
+	}
+	for _, ended := range []string{first, second} {
+		if _, err := s.Get(ctx, ended); err != ErrNoSession {
+			t.Fatalf("session of a logged out user still works, error %v", err)
+		}
+	}
+	if userID, err := s.Get(ctx, other); err != nil || userID != 8 {
+		t.Fatalf("session of another user ended, user %v error %v", userID, err)
+	}
+}
+
+func TestMemory(t *testing.T) {
+	testStore(t, NewMemory(DefaultTTL))
+}
+
+func TestRedis(t *testing.T) {
+	testStore(t, newFakeRedisStore(DefaultTTL))
+}
+
+func TestMemorySessionsExpire(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemory(20 * time.Millisecond)
+	token, err := s.Create(ctx, 7)
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, err := s.Get(ctx, token); err != ErrNoSession {
+		t.Fatalf("expired session still works, error %v", err)
+	}
+	if _, err := s.Refresh(ctx, token); err == nil {
+		t.Fatal("refreshed an expired session")
+	}
+}
@@ -0,0 +1,1199 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"shoppinglist/pkg/api"
+	"sort"
+	"sync"
+	"time"
+)
+
+// memData holds the tables of the in-memory repository
+type memData struct {
+	lastID       int64
+	users        map[int64]User
+	lists        map[int64]api.List
+	contributors map[int64]Contributor
+	transfers    map[int64]OwnershipTransfer
+	invites      map[int64]Invite
+	publicLinks  map[int64]PublicLink
+	groups       map[int64]Group
+	groupMembers map[int64]GroupMember
+	listGroups   map[int64]ListGroup
+	items        map[int64]api.Item
+	categories   map[int64]api.Category
+	rules        map[int64]CategoryRule
+	choices      map[choiceKey]CategoryChoice
+	stores       map[int64]api.Store
+	// layouts holds the category ids of each store in aisle order
+	layouts     map[int64][]int64
+	recurrences map[int64]api.Recurrence
+	instances   map[int64]api.RecurrenceInstance
+	preferences map[int64]ReminderPreferences
+	reminders   map[int64]Reminder
+}
+
+// choiceKey identifies the choice of a user for a title
+type choiceKey struct {
+	userID int64
+	title  string
+}
+
+func newMemData() *memData {
+	return &memData{
+		users:        make(map[int64]User),
+		lists:        make(map[int64]api.List),
+		contributors: make(map[int64]Contributor),
+		transfers:    make(map[int64]OwnershipTransfer),
+		invites:      make(map[int64]Invite),
+		publicLinks:  make(map[int64]PublicLink),
+		groups:       make(map[int64]Group),
+		groupMembers: make(map[int64]GroupMember),
+		listGroups:   make(map[int64]ListGroup),
+		items:        make(map[int64]api.Item),
+		categories:   make(map[int64]api.Category),
+		rules:        make(map[int64]CategoryRule),
+		choices:      make(map[choiceKey]CategoryChoice),
+		stores:       make(map[int64]api.Store),
+		layouts:      make(map[int64][]int64),
+		recurrences:  make(map[int64]api.Recurrence),
+		instances:    make(map[int64]api.RecurrenceInstance),
+		preferences:  make(map[int64]ReminderPreferences),
+		reminders:    make(map[int64]Reminder),
+	}
+}
+
+// clone returns a copy of the tables, used to roll back a failed transaction
+func (d *memData) clone() *memData {
+	c := newMemData()
+	c.lastID = d.lastID
+	for k, v := range d.users {
+		c.users[k] = v
+	}
+	for k, v := range d.lists {
+		c.lists[k] = v
+	}
+	for k, v := range d.contributors {
+		c.contributors[k] = v
+	}
+	for k, v := range d.transfers {
+		c.transfers[k] = v
+	}
+	for k, v := range d.invites {
+		c.invites[k] = v
+	}
+	for k, v := range d.publicLinks {
+		c.publicLinks[k] = v
+	}
+	for k, v := range d.groups {
+		c.groups[k] = v
+	}
+	for k, v := range d.groupMembers {
+		c.groupMembers[k] = v
+	}
+	for k, v := range d.listGroups {
+		c.listGroups[k] = v
+	}
+	for k, v := range d.items {
+		c.items[k] = v
+	}
+	for k, v := range d.categories {
+		c.categories[k] = v
+	}
+	for k, v := range d.rules {
+		c.rules[k] = v
+	}
+	for k, v := range d.choices {
+		c.choices[k] = v
+	}
+	for k, v := range d.stores {
+		c.stores[k] = v
+	}
+	for k, v := range d.layouts {
+		c.layouts[k] = append([]int64(nil), v...)
+	}
+	for k, v := range d.recurrences {
+		c.recurrences[k] = v
+	}
+	for k, v := range d.instances {
+		c.instances[k] = v
+	}
+	for k, v := range d.preferences {
+		c.preferences[k] = v
+	}
+	for k, v := range d.reminders {
+		c.reminders[k] = v
+	}
+	return c
+}
+
+func (d *memData) nextID() int64 {
+	d.lastID++
+	return d.lastID
+}
+
+// Memory is a Repository that keeps all the records in process memory.
+// It is meant for tests and local demos, nothing is persisted.
+type Memory struct {
+	mu   *sync.Mutex
+	data *memData
+	// inTx is set on the repository handed to a transaction, which already holds mu
+	inTx bool
+}
+
+// NewMemory returns an empty in-memory Repository
+func NewMemory() *Memory {
+	return &Memory{mu: &sync.Mutex{}, data: newMemData()}
+}
+
+func (m *Memory) lock() func() {
+	if m.inTx {
+		return func() {}
+	}
+	m.mu.Lock()
+	return m.mu.Unlock
+}
+
+func (m *Memory) Tx(ctx context.Context, fn func(Repository) error) error {
+	if m.inTx {
+		return fn(m)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snapshot := m.data.clone()
+	err := fn(&Memory{mu: m.mu, data: m.data, inTx: true})
+	if err != nil {
+		*m.data = *snapshot
+		return err
+	}
+	return nil
+}
+
+func (m *Memory) CreateUser(ctx context.Context, user *User) error {
+	defer m.lock()()
+	for _, u := range m.data.users {
+		if u.UserName == user.UserName {
+			return errors.New(fmt.Sprintf("duplicate username %v", user.UserName))
+		}
+	}
+	user.ID = m.data.nextID()
+	m.data.users[user.ID] = *user
+	return nil
+}
+
+func (m *Memory) GetUserByID(ctx context.Context, userID int64) (User, error) {
+	defer m.lock()()
+	user, ok := m.data.users[userID]
+	if !ok {
+		return user, errors.Wrapf(ErrNotFound, "user %v", userID)
+	}
+	return user, nil
+}
+
+func (m *Memory) GetUserByName(ctx context.Context, userName string) (User, error) {
+	defer m.lock()()
+	for _, u := range m.data.users {
+		if u.UserName == userName {
+			return u, nil
+		}
+	}
+	return User{}, errors.Wrapf(ErrNotFound, "user %v", userName)
+}
+
+func (m *Memory) UpdateLastLoggedIn(ctx context.Context, userID int64, at time.Time) error {
+	defer m.lock()()
+	user, ok := m.data.users[userID]
+	if !ok {
+		return errors.Wrapf(ErrNotFound, "user %v", userID)
+	}
+	user.LastLoggedInAt = at
+	m.data.users[userID] = user
+	return nil
+}
+
+// userName returns the username of given user id, or an empty string for unknown ids
+func (d *memData) userName(userID int64) string {
+	return d.users[userID].UserName
+}
+
+func (d *memData) hydrateList(list api.List) api.List {
+	list.Owner.UserName = d.userName(list.Owner.UserID)
+	return list
+}
+
+func (m *Memory) CreateList(ctx context.Context, list *api.List) error {
+	defer m.lock()()
+	if _, ok := m.data.users[list.Owner.UserID]; !ok {
+		return errors.Wrapf(ErrNotFound, "list owner %v", list.Owner.UserID)
+	}
+	list.ID = m.data.nextID()
+	stored := *list
+	stored.AccessType = ""
+	stored.CreatedByMe = false
+	m.data.lists[list.ID] = stored
+	return nil
+}
+
+func (m *Memory) GetList(ctx context.Context, listID int64) (api.List, error) {
+	defer m.lock()()
+	list, ok := m.data.lists[listID]
+	if !ok {
+		return list, errors.Wrapf(ErrNotFound, "list %v", listID)
+	}
+	return m.data.hydrateList(list), nil
+}
+
+func (m *Memory) GetListsForUser(ctx context.Context, userID int64) ([]api.List, error) {
+	defer m.lock()()
+	access := make(map[int64]string)
+	now := time.Now()
+	for _, c := range m.data.contributors {
+		if c.UserID == userID && !c.Expired(now) {
+			access[c.ListID] = api.StrongerAccess(access[c.ListID], c.AccessType)
+		}
+	}
+	for _, lg := range m.data.listGroups {
+		if m.data.isGroupMember(lg.GroupID, userID) {
+			access[lg.ListID] = api.StrongerAccess(access[lg.ListID], lg.AccessType)
+		}
+	}
+	var lists []api.List
+	for listID, accessType := range access {
+		list, ok := m.data.lists[listID]
+		if !ok {
+			continue
+		}
+		list = m.data.hydrateList(list)
+		list.AccessType = accessType
+		lists = append(lists, list)
+	}
+	sort.Slice(lists, func(i, j int) bool { return lists[i].ID < lists[j].ID })
+	return lists, nil
+}
+
+func (m *Memory) UpdateList(ctx context.Context, list *api.List) error {
+	defer m.lock()()
+	stored, ok := m.data.lists[list.ID]
+	if !ok {
+		return errors.Wrapf(ErrNotFound, "list %v", list.ID)
+	}
+	stored.Name = list.Name
+	stored.Description = list.Description
+	stored.Owner.UserID = list.Owner.UserID
+	stored.LastModifiedAt = list.LastModifiedAt
+	stored.Deadline = list.Deadline
+	stored.Status = list.Status
+	stored.DeletedAt = list.DeletedAt
+	stored.StoreID = list.StoreID
+	m.data.lists[list.ID] = stored
+	return nil
+}
+
+func (m *Memory) PurgeDeletedLists(ctx context.Context, before time.Time) (int64, error) {
+	defer m.lock()()
+	var purged int64
+	for id, list := range m.data.lists {
+		if list.Status != api.Deleted || !list.DeletedAt.Before(before) {
+			continue
+		}
+		for itemID, item := range m.data.items {
+			if item.ListID == id {
+				delete(m.data.items, itemID)
+			}
+		}
+		for contributorID, c := range m.data.contributors {
+			if c.ListID == id {
+				delete(m.data.contributors, contributorID)
+			}
+		}
+		for transferID, transfer := range m.data.transfers {
+			if transfer.ListID == id {
+				delete(m.data.transfers, transferID)
+			}
+		}
+		for inviteID, invite := range m.data.invites {
+			if invite.ListID == id {
+				delete(m.data.invites, inviteID)
+			}
+		}
+		for linkID, link := range m.data.publicLinks {
+			if link.ListID == id {
+				delete(m.data.publicLinks, linkID)
+			}
+		}
+		for listGroupID, lg := range m.data.listGroups {
+			if lg.ListID == id {
+				delete(m.data.listGroups, listGroupID)
+			}
+		}
+		for recurrenceID, recurrence := range m.data.recurrences {
+			if recurrence.SourceListID == id {
+				m.data.deleteRecurrence(recurrenceID)
+			}
+		}
+		for instanceID, instance := range m.data.instances {
+			if instance.ListID == id {
+				instance.ListID = 0
+				m.data.instances[instanceID] = instance
+			}
+		}
+		for reminderID, reminder := range m.data.reminders {
+			if reminder.ListID == id {
+				delete(m.data.reminders, reminderID)
+			}
+		}
+		delete(m.data.lists, id)
+		purged++
+	}
+	return purged, nil
+}
+
+func (m *Memory) AddContributor(ctx context.Context, contributor *Contributor) error {
+	defer m.lock()()
+	if _, ok := m.data.lists[contributor.ListID]; !ok {
+		return errors.Wrapf(ErrNotFound, "list %v", contributor.ListID)
+	}
+	if _, ok := m.data.users[contributor.UserID]; !ok {
+		return errors.Wrapf(ErrNotFound, "user %v", contributor.UserID)
+	}
+	contributor.ID = m.data.nextID()
+	m.data.contributors[contributor.ID] = *contributor
+	return nil
+}
+
+func (m *Memory) GetContributor(ctx context.Context, listID int64, userID int64) (Contributor, error) {
+	defer m.lock()()
+	for _, c := range m.data.contributors {
+		if c.ListID == listID && c.UserID == userID {
+			return c, nil
+		}
+	}
+	return Contributor{}, errors.Wrapf(ErrNotFound, "contributer %v of list %v", userID, listID)
+}
+
+func (m *Memory) GetListContributors(ctx context.Context, listID int64) ([]Contributor, error) {
+	defer m.lock()()
+	var contributors []Contributor
+	for _, c := range m.data.contributors {
+		if c.ListID == listID {
+			contributors = append(contributors, c)
+		}
+	}
+	sort.Slice(contributors, func(i, j int) bool { return contributors[i].ID < contributors[j].ID })
+	return contributors, nil
+}
+
+func (m *Memory) UpdateContributor(ctx context.Context, contributor *Contributor) error {
+	defer m.lock()()
+	stored, ok := m.data.contributors[contributor.ID]
+	if !ok {
+		return errors.Wrapf(ErrNotFound, "contributer %v", contributor.ID)
+	}
+	stored.AccessType = contributor.AccessType
+	stored.ValidUntil = contributor.ValidUntil
+	m.data.contributors[contributor.ID] = stored
+	return nil
+}
+
+func (m *Memory) RemoveContributor(ctx context.Context, contributorID int64) error {
+	defer m.lock()()
+	if _, ok := m.data.contributors[contributorID]; !ok {
+		return errors.Wrapf(ErrNotFound, "contributer %v", contributorID)
+	}
+	delete(m.data.contributors, contributorID)
+	return nil
+}
+
+func (m *Memory) CreateInvite(ctx context.Context, invite *Invite) error {
+	defer m.lock()()
+	if _, ok := m.data.lists[invite.ListID]; !ok {
+		return errors.Wrapf(ErrNotFound, "list %v", invite.ListID)
+	}
+	invite.ID = m.data.nextID()
+	m.data.invites[invite.ID] = *invite
+	return nil
+}
+
+func (m *Memory) GetInvite(ctx context.Context, inviteID int64) (Invite, error) {
+	defer m.lock()()
+	invite, ok := m.data.invites[inviteID]
+	if !ok {
+		return invite, errors.Wrapf(ErrNotFound, "invitation %v", inviteID)
+	}
+	return invite, nil
+}
+
+func (m *Memory) GetListInvites(ctx context.Context, listID int64) ([]Invite, error) {
+	defer m.lock()()
+	var invites []Invite
+	for _, invite := range m.data.invites {
+		if invite.ListID == listID {
+			invites = append(invites, invite)
+		}
+	}
+	sort.Slice(invites, func(i, j int) bool { return invites[i].ID < invites[j].ID })
+	return invites, nil
+}
+
+func (m *Memory) UpdateInvite(ctx context.Context, invite *Invite) error {
+	defer m.lock()()
+	stored, ok := m.data.invites[invite.ID]
+	if !ok {
+		return errors.Wrapf(ErrNotFound, "invitation %v", invite.ID)
+	}
+	stored.Status = invite.Status
+	stored.AcceptedBy = invite.AcceptedBy
+	stored.AcceptedAt = invite.AcceptedAt
+	m.data.invites[invite.ID] = stored
+	return nil
+}
+
+func (m *Memory) CreateOwnershipTransfer(ctx context.Context, transfer *OwnershipTransfer) error {
+	defer m.lock()()
+	if _, ok := m.data.lists[transfer.ListID]; !ok {
+		return errors.Wrapf(ErrNotFound, "list %v", transfer.ListID)
+	}
+	transfer.ID = m.data.nextID()
+	m.data.transfers[transfer.ID] = *transfer
+	return nil
+}
+
+func (m *Memory) GetListOwnershipTransfers(ctx context.Context, listID int64) ([]OwnershipTransfer, error) {
+	defer m.lock()()
+	var transfers []OwnershipTransfer
+	for _, transfer := range m.data.transfers {
+		if transfer.ListID == listID {
+			transfers = append(transfers, transfer)
+		}
+	}
+	sort.Slice(transfers, func(i, j int) bool { return transfers[i].ID < transfers[j].ID })
+	return transfers, nil
+}
+
+func (m *Memory) CreatePublicLink(ctx context.Context, link *PublicLink) error {
+	defer m.lock()()
+	if _, ok := m.data.lists[link.ListID]; !ok {
+		return errors.Wrapf(ErrNotFound, "list %v", link.ListID)
+	}
+	link.ID = m.data.nextID()
+	m.data.publicLinks[link.ID] = *link
+	return nil
+}
+
+func (m *Memory) GetPublicLink(ctx context.Context, linkID int64) (PublicLink, error) {
+	defer m.lock()()
+	link, ok := m.data.publicLinks[linkID]
+	if !ok {
+		return link, errors.Wrapf(ErrNotFound, "public link %v", linkID)
+	}
+	return link, nil
+}
+
+func (m *Memory) GetListPublicLinks(ctx context.Context, listID int64) ([]PublicLink, error) {
+	defer m.lock()()
+	var links []PublicLink
+	for _, link := range m.data.publicLinks {
+		if link.ListID == listID {
+			links = append(links, link)
+		}
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].ID < links[j].ID })
+	return links, nil
+}
+
+func (m *Memory) UpdatePublicLink(ctx context.Context, link *PublicLink) error {
+	defer m.lock()()
+	stored, ok := m.data.publicLinks[link.ID]
+	if !ok {
+		return errors.Wrapf(ErrNotFound, "public link %v", link.ID)
+	}
+	stored.RevokedAt = link.RevokedAt
+	m.data.publicLinks[link.ID] = stored
+	return nil
+}
+
+func (m *Memory) CreateGroup(ctx context.Context, group *Group) error {
+	defer m.lock()()
+	if _, ok := m.data.users[group.CreatedBy]; !ok {
+		return errors.Wrapf(ErrNotFound, "user %v", group.CreatedBy)
+	}
+	group.ID = m.data.nextID()
+	m.data.groups[group.ID] = *group
+	return nil
+}
+
+func (m *Memory) GetGroup(ctx context.Context, groupID int64) (Group, error) {
+	defer m.lock()()
+	group, ok := m.data.groups[groupID]
+	if !ok {
+		return group, errors.Wrapf(ErrNotFound, "group %v", groupID)
+	}
+	return group, nil
+}
+
+func (d *memData) isGroupMember(groupID int64, userID int64) bool {
+	for _, member := range d.groupMembers {
+		if member.GroupID == groupID && member.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *memData) groupMembersWhere(match func(GroupMember) bool) []GroupMember {
+	var members []GroupMember
+	for _, member := range d.groupMembers {
+		if match(member) {
+			members = append(members, member)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+	return members
+}
+
+func (m *Memory) AddGroupMember(ctx context.Context, member *GroupMember) error {
+	defer m.lock()()
+	if _, ok := m.data.groups[member.GroupID]; !ok {
+		return errors.Wrapf(ErrNotFound, "group %v", member.GroupID)
+	}
+	if _, ok := m.data.users[member.UserID]; !ok {
+		return errors.Wrapf(ErrNotFound, "user %v", member.UserID)
+	}
+	member.ID = m.data.nextID()
+	m.data.groupMembers[member.ID] = *member
+	return nil
+}
+
+func (m *Memory) GetGroupMember(ctx context.Context, groupID int64, userID int64) (GroupMember, error) {
+	defer m.lock()()
+	for _, member := range m.data.groupMembers {
+		if member.GroupID == groupID && member.UserID == userID {
+			return member, nil
+		}
+	}
+	return GroupMember{}, errors.Wrapf(ErrNotFound, "member %v of group %v", userID, groupID)
+}
+
+func (m *Memory) GetGroupMembers(ctx context.Context, groupID int64) ([]GroupMember, error) {
+	defer m.lock()()
+	return m.data.groupMembersWhere(func(member GroupMember) bool { return member.GroupID == groupID }), nil
+}
+
+func (m *Memory) GetUserGroups(ctx context.Context, userID int64) ([]GroupMember, error) {
+	defer m.lock()()
+	return m.data.groupMembersWhere(func(member GroupMember) bool { return member.UserID == userID }), nil
+}
+
+func (m *Memory) UpdateGroupMember(ctx context.Context, member *GroupMember) error {
+	defer m.lock()()
+	stored, ok := m.data.groupMembers[member.ID]
+	if !ok {
+		return errors.Wrapf(ErrNotFound, "group member %v", member.ID)
+	}
+	stored.Role = member.Role
+	m.data.groupMembers[member.ID] = stored
+	return nil
+}
+
+func (m *Memory) RemoveGroupMember(ctx context.Context, memberID int64) error {
+	defer m.lock()()
+	if _, ok := m.data.groupMembers[memberID]; !ok {
+		return errors.Wrapf(ErrNotFound, "group member %v", memberID)
+	}
+	delete(m.data.groupMembers, memberID)
+	return nil
+}
+
+func (m *Memory) AddListGroup(ctx context.Context, listGroup *ListGroup) error {
+	defer m.lock()()
+	if _, ok := m.data.lists[listGroup.ListID]; !ok {
+		return errors.Wrapf(ErrNotFound, "list %v", listGroup.ListID)
+	}
+	if _, ok := m.data.groups[listGroup.GroupID]; !ok {
+		return errors.Wrapf(ErrNotFound, "group %v", listGroup.GroupID)
+	}
+	listGroup.ID = m.data.nextID()
+	m.data.listGroups[listGroup.ID] = *listGroup
+	return nil
+}
+
+func (m *Memory) GetListGroup(ctx context.Context, listID int64, groupID int64) (ListGroup, error) {
+	defer m.lock()()
+	for _, lg := range m.data.listGroups {
+		if lg.ListID == listID && lg.GroupID == groupID {
+			return lg, nil
+		}
+	}
+	return ListGroup{}, errors.Wrapf(ErrNotFound, "group %v of list %v", groupID, listID)
+}
+
+func (m *Memory) GetListGroups(ctx context.Context, listID int64) ([]ListGroup, error) {
+	defer m.lock()()
+	var listGroups []ListGroup
+	for _, lg := range m.data.listGroups {
+		if lg.ListID == listID {
+			listGroups = append(listGroups, lg)
+		}
+	}
+	sort.Slice(listGroups, func(i, j int) bool { return listGroups[i].ID < listGroups[j].ID })
+	return listGroups, nil
+}
+
+func (m *Memory) UpdateListGroup(ctx context.Context, listGroup *ListGroup) error {
+	defer m.lock()()
+	stored, ok := m.data.listGroups[listGroup.ID]
+	if !ok {
+		return errors.Wrapf(ErrNotFound, "list group %v", listGroup.ID)
+	}
+	stored.AccessType = listGroup.AccessType
+	m.data.listGroups[listGroup.ID] = stored
+	return nil
+}
+
+func (m *Memory) RemoveListGroup(ctx context.Context, listGroupID int64) error {
+	defer m.lock()()
+	if _, ok := m.data.listGroups[listGroupID]; !ok {
+		return errors.Wrapf(ErrNotFound, "list group %v", listGroupID)
+	}
+	delete(m.data.listGroups, listGroupID)
+	return nil
+}
+
+func (m *Memory) GetGroupAccess(ctx context.Context, listID int64, userID int64) (string, error) {
+	defer m.lock()()
+	accessType := ""
+	for _, lg := range m.data.listGroups {
+		if lg.ListID == listID && m.data.isGroupMember(lg.GroupID, userID) {
+			accessType = api.StrongerAccess(accessType, lg.AccessType)
+		}
+	}
+	if accessType == "" {
+		return "", errors.Wrapf(ErrNotFound, "group access of user %v on list %v", userID, listID)
+	}
+	return accessType, nil
+}
+
+func (d *memData) hydrateItem(item api.Item) api.Item {
+	item.Category = d.categories[item.Category.ID]
+	item.CreatedBy.UserName = d.userName(item.CreatedBy.UserID)
+	item.LastModifiedBy.UserName = d.userName(item.LastModifiedBy.UserID)
+	item.BoughtBy.UserName = d.userName(item.BoughtBy.UserID)
+	return item
+}
+
+func (m *Memory) CreateItem(ctx context.Context, item *api.Item) error {
+	defer m.lock()()
+	if _, ok := m.data.lists[item.ListID]; !ok {
+		return errors.Wrapf(ErrNotFound, "list %v", item.ListID)
+	}
+	if _, ok := m.data.categories[item.Category.ID]; !ok {
+		return errors.Wrapf(ErrNotFound, "category %v", item.Category.ID)
+	}
+	item.ID = m.data.nextID()
+	m.data.items[item.ID] = *item
+	return nil
+}
+
+func (m *Memory) GetItem(ctx context.Context, itemID int64) (api.Item, error) {
+	defer m.lock()()
+	item, ok := m.data.items[itemID]
+	if !ok {
+		return item, errors.Wrapf(ErrNotFound, "item %v", itemID)
+	}
+	return m.data.hydrateItem(item), nil
+}
+
+func (m *Memory) GetListItems(ctx context.Context, listID int64) ([]api.Item, error) {
+	defer m.lock()()
+	var items []api.Item
+	for _, item := range m.data.items {
+		if item.ListID == listID {
+			items = append(items, m.data.hydrateItem(item))
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Position != items[j].Position {
+			return items[i].Position < items[j].Position
+		}
+		return items[i].ID < items[j].ID
+	})
+	return items, nil
+}
+
+func (m *Memory) UpdateItem(ctx context.Context, item *api.Item) error {
+	defer m.lock()()
+	stored, ok := m.data.items[item.ID]
+	if !ok {
+		return errors.Wrapf(ErrNotFound, "item %v", item.ID)
+	}
+	if _, ok := m.data.lists[item.ListID]; !ok {
+		return errors.Wrapf(ErrNotFound, "list %v", item.ListID)
+	}
+	if _, ok := m.data.categories[item.Category.ID]; !ok {
+		return errors.Wrapf(ErrNotFound, "category %v", item.Category.ID)
+	}
+	stored.ListID = item.ListID
+	stored.Title = item.Title
+	stored.Description = item.Description
+	stored.Quantity = item.Quantity
+	stored.Unit = item.Unit
+	stored.Status = item.Status
+	stored.Category.ID = item.Category.ID
+	stored.Position = item.Position
+	stored.LastModifiedBy.UserID = item.LastModifiedBy.UserID
+	stored.BoughtBy.UserID = item.BoughtBy.UserID
+	stored.LastModifiedAt = item.LastModifiedAt
+	stored.BoughtAt = item.BoughtAt
+	stored.DeletedAt = item.DeletedAt
+	stored.Deadline = item.Deadline
+	m.data.items[item.ID] = stored
+	return nil
+}
+
+func (m *Memory) PurgeDeletedItems(ctx context.Context, before time.Time) (int64, error) {
+	defer m.lock()()
+	var purged int64
+	for id, item := range m.data.items {
+		if item.Status == api.Deleted && item.DeletedAt.Before(before) {
+			for reminderID, reminder := range m.data.reminders {
+				if reminder.ItemID == id {
+					delete(m.data.reminders, reminderID)
+				}
+			}
+			delete(m.data.items, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (m *Memory) CreateCategory(ctx context.Context, category *api.Category) error {
+	defer m.lock()()
+	category.ID = m.data.nextID()
+	m.data.categories[category.ID] = *category
+	return nil
+}
+
+func (m *Memory) GetCategory(ctx context.Context, categoryID int64) (api.Category, error) {
+	defer m.lock()()
+	category, ok := m.data.categories[categoryID]
+	if !ok {
+		return category, errors.Wrapf(ErrNotFound, "category %v", categoryID)
+	}
+	return category, nil
+}
+
+func (m *Memory) GetVisibleCategories(ctx context.Context, userID int64) ([]api.Category, error) {
+	defer m.lock()()
+	var categories []api.Category
+	for _, category := range m.data.categories {
+		system := category.OwnerID == 0 && category.GroupID == 0
+		if system || category.OwnerID == userID || (category.GroupID != 0 && m.data.isGroupMember(category.GroupID, userID)) {
+			categories = append(categories, category)
+		}
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].ID < categories[j].ID })
+	return categories, nil
+}
+
+func (m *Memory) UpdateCategory(ctx context.Context, category *api.Category) error {
+	defer m.lock()()
+	if _, ok := m.data.categories[category.ID]; !ok {
+		return errors.Wrapf(ErrNotFound, "category %v", category.ID)
+	}
+	m.data.categories[category.ID] = *category
+	return nil
+}
+
+func (m *Memory) DeleteCategory(ctx context.Context, categoryID int64) error {
+	defer m.lock()()
+	if _, ok := m.data.categories[categoryID]; !ok {
+		return errors.Wrapf(ErrNotFound, "category %v", categoryID)
+	}
+	for _, item := range m.data.items {
+		if item.Category.ID == categoryID {
+			return errors.New(fmt.Sprintf("category %v is still used by item %v", categoryID, item.ID))
+		}
+	}
+	delete(m.data.categories, categoryID)
+	for id, rule := range m.data.rules {
+		if rule.CategoryID == categoryID {
+			delete(m.data.rules, id)
+		}
+	}
+	for key, choice := range m.data.choices {
+		if choice.CategoryID == categoryID {
+			delete(m.data.choices, key)
+		}
+	}
+	for storeID, layout := range m.data.layouts {
+		var kept []int64
+		for _, id := range layout {
+			if id != categoryID {
+				kept = append(kept, id)
+			}
+		}
+		m.data.layouts[storeID] = kept
+	}
+	return nil
+}
+
+func (m *Memory) CountCategoryItems(ctx context.Context, categoryID int64) (int64, error) {
+	defer m.lock()()
+	var count int64
+	for _, item := range m.data.items {
+		if item.Category.ID == categoryID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *Memory) MoveCategoryItems(ctx context.Context, fromID int64, toID int64) (int64, error) {
+	defer m.lock()()
+	if _, ok := m.data.categories[toID]; !ok {
+		return 0, errors.Wrapf(ErrNotFound, "category %v", toID)
+	}
+	var moved int64
+	for id, item := range m.data.items {
+		if item.Category.ID == fromID {
+			item.Category.ID = toID
+			m.data.items[id] = item
+			moved++
+		}
+	}
+	return moved, nil
+}
+
+func (m *Memory) CreateCategoryRule(ctx context.Context, rule *CategoryRule) error {
+	defer m.lock()()
+	if _, ok := m.data.categories[rule.CategoryID]; !ok {
+		return errors.Wrapf(ErrNotFound, "category %v", rule.CategoryID)
+	}
+	rule.ID = m.data.nextID()
+	m.data.rules[rule.ID] = *rule
+	return nil
+}
+
+func (m *Memory) GetCategoryRule(ctx context.Context, ruleID int64) (CategoryRule, error) {
+	defer m.lock()()
+	rule, ok := m.data.rules[ruleID]
+	if !ok {
+		return rule, errors.Wrapf(ErrNotFound, "category rule %v", ruleID)
+	}
+	return rule, nil
+}
+
+func (m *Memory) GetUserCategoryRules(ctx context.Context, userID int64) ([]CategoryRule, error) {
+	defer m.lock()()
+	var rules []CategoryRule
+	for _, rule := range m.data.rules {
+		if rule.UserID == userID {
+			rules = append(rules, rule)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID > rules[j].ID })
+	return rules, nil
+}
+
+func (m *Memory) DeleteCategoryRule(ctx context.Context, ruleID int64) error {
+	defer m.lock()()
+	if _, ok := m.data.rules[ruleID]; !ok {
+		return errors.Wrapf(ErrNotFound, "category rule %v", ruleID)
+	}
+	delete(m.data.rules, ruleID)
+	return nil
+}
+
+func (m *Memory) SaveCategoryChoice(ctx context.Context, choice *CategoryChoice) error {
+	defer m.lock()()
+	if _, ok := m.data.categories[choice.CategoryID]; !ok {
+		return errors.Wrapf(ErrNotFound, "category %v", choice.CategoryID)
+	}
+	m.data.choices[choiceKey{choice.UserID, choice.Title}] = *choice
+	return nil
+}
+
+func (m *Memory) GetCategoryChoice(ctx context.Context, userID int64, title string) (CategoryChoice, error) {
+	defer m.lock()()
+	choice, ok := m.data.choices[choiceKey{userID, title}]
+	if !ok {
+		return choice, errors.Wrapf(ErrNotFound, "category choice of user %v for %v", userID, title)
+	}
+	return choice, nil
+}
+
+func (m *Memory) MoveCategoryRules(ctx context.Context, fromID int64, toID int64) error {
+	defer m.lock()()
+	if _, ok := m.data.categories[toID]; !ok {
+		return errors.Wrapf(ErrNotFound, "category %v", toID)
+	}
+	for id, rule := range m.data.rules {
+		if rule.CategoryID == fromID {
+			rule.CategoryID = toID
+			m.data.rules[id] = rule
+		}
+	}
+	for key, choice := range m.data.choices {
+		if choice.CategoryID == fromID {
+			choice.CategoryID = toID
+			m.data.choices[key] = choice
+		}
+	}
+	return nil
+}
+
+func (m *Memory) CreateStore(ctx context.Context, shop *api.Store) error {
+	defer m.lock()()
+	if _, ok := m.data.users[shop.OwnerID]; !ok {
+		return errors.Wrapf(ErrNotFound, "store owner %v", shop.OwnerID)
+	}
+	shop.ID = m.data.nextID()
+	stored := *shop
+	stored.Categories = nil
+	m.data.stores[shop.ID] = stored
+	return nil
+}
+
+// withLayout returns the store with its categories in aisle order
+func (d *memData) withLayout(shop api.Store) api.Store {
+	shop.Categories = []api.Category{}
+	for _, id := range d.layouts[shop.ID] {
+		shop.Categories = append(shop.Categories, d.categories[id])
+	}
+	return shop
+}
+
+func (m *Memory) GetStore(ctx context.Context, storeID int64) (api.Store, error) {
+	defer m.lock()()
+	shop, ok := m.data.stores[storeID]
+	if !ok {
+		return shop, errors.Wrapf(ErrNotFound, "store %v", storeID)
+	}
+	return m.data.withLayout(shop), nil
+}
+
+func (m *Memory) GetUserStores(ctx context.Context, userID int64) ([]api.Store, error) {
+	defer m.lock()()
+	var stores []api.Store
+	for _, shop := range m.data.stores {
+		if shop.OwnerID == userID {
+			stores = append(stores, m.data.withLayout(shop))
+		}
+	}
+	sort.Slice(stores, func(i, j int) bool { return stores[i].ID < stores[j].ID })
+	return stores, nil
+}
+
+func (m *Memory) UpdateStore(ctx context.Context, shop *api.Store) error {
+	defer m.lock()()
+	stored, ok := m.data.stores[shop.ID]
+	if !ok {
+		return errors.Wrapf(ErrNotFound, "store %v", shop.ID)
+	}
+	stored.Name = shop.Name
+	m.data.stores[shop.ID] = stored
+	return nil
+}
+
+func (m *Memory) SetStoreLayout(ctx context.Context, storeID int64, categoryIDs []int64) error {
+	defer m.lock()()
+	if _, ok := m.data.stores[storeID]; !ok {
+		return errors.Wrapf(ErrNotFound, "store %v", storeID)
+	}
+	for _, id := range categoryIDs {
+		if _, ok := m.data.categories[id]; !ok {
+			return errors.Wrapf(ErrNotFound, "category %v", id)
+		}
+	}
+	m.data.layouts[storeID] = append([]int64(nil), categoryIDs...)
+	return nil
+}
+
+func (m *Memory) DeleteStore(ctx context.Context, storeID int64) error {
+	defer m.lock()()
+	if _, ok := m.data.stores[storeID]; !ok {
+		return errors.Wrapf(ErrNotFound, "store %v", storeID)
+	}
+	for id, list := range m.data.lists {
+		if list.StoreID == storeID {
+			list.StoreID = 0
+			m.data.lists[id] = list
+		}
+	}
+	delete(m.data.layouts, storeID)
+	delete(m.data.stores, storeID)
+	return nil
+}
+
+func (m *Memory) CreateRecurrence(ctx context.Context, recurrence *api.Recurrence) error {
+	defer m.lock()()
+	if _, ok := m.data.users[recurrence.OwnerID]; !ok {
+		return errors.Wrapf(ErrNotFound, "recurrence owner %v", recurrence.OwnerID)
+	}
+	if _, ok := m.data.lists[recurrence.SourceListID]; !ok {
+		return errors.Wrapf(ErrNotFound, "list %v", recurrence.SourceListID)
+	}
+	recurrence.ID = m.data.nextID()
+	m.data.recurrences[recurrence.ID] = *recurrence
+	return nil
+}
+
+func (m *Memory) GetRecurrence(ctx context.Context, recurrenceID int64) (api.Recurrence, error) {
+	defer m.lock()()
+	recurrence, ok := m.data.recurrences[recurrenceID]
+	if !ok {
+		return recurrence, errors.Wrapf(ErrNotFound, "recurrence %v", recurrenceID)
+	}
+	return recurrence, nil
+}
+
+func (m *Memory) GetUserRecurrences(ctx context.Context, userID int64) ([]api.Recurrence, error) {
+	defer m.lock()()
+	var recurrences []api.Recurrence
+	for _, recurrence := range m.data.recurrences {
+		if recurrence.OwnerID == userID {
+			recurrences = append(recurrences, recurrence)
+		}
+	}
+	sort.Slice(recurrences, func(i, j int) bool { return recurrences[i].ID < recurrences[j].ID })
+	return recurrences, nil
+}
+
+func (m *Memory) GetDueRecurrences(ctx context.Context, by time.Time) ([]api.Recurrence, error) {
+	defer m.lock()()
+	var recurrences []api.Recurrence
+	for _, recurrence := range m.data.recurrences {
+		if !recurrence.Paused && !recurrence.NextRunAt.After(by) {
+			recurrences = append(recurrences, recurrence)
+		}
+	}
+	sort.Slice(recurrences, func(i, j int) bool { return recurrences[i].ID < recurrences[j].ID })
+	return recurrences, nil
+}
+
+func (m *Memory) UpdateRecurrence(ctx context.Context, recurrence *api.Recurrence) error {
+	defer m.lock()()
+	stored, ok := m.data.recurrences[recurrence.ID]
+	if !ok {
+		return errors.Wrapf(ErrNotFound, "recurrence %v", recurrence.ID)
+	}
+	stored.NextRunAt = recurrence.NextRunAt
+	stored.Paused = recurrence.Paused
+	m.data.recurrences[recurrence.ID] = stored
+	return nil
+}
+
+// deleteRecurrence removes a recurrence and its instances
+func (d *memData) deleteRecurrence(recurrenceID int64) {
+	for id, instance := range d.instances {
+		if instance.RecurrenceID == recurrenceID {
+			delete(d.instances, id)
+		}
+	}
+	delete(d.recurrences, recurrenceID)
+}
+
+func (m *Memory) DeleteRecurrence(ctx context.Context, recurrenceID int64) error {
+	defer m.lock()()
+	if _, ok := m.data.recurrences[recurrenceID]; !ok {
+		return errors.Wrapf(ErrNotFound, "recurrence %v", recurrenceID)
+	}
+	m.data.deleteRecurrence(recurrenceID)
+	return nil
+}
+
+func (m *Memory) AddRecurrenceInstance(ctx context.Context, instance *api.RecurrenceInstance) error {
+	defer m.lock()()
+	if _, ok := m.data.recurrences[instance.RecurrenceID]; !ok {
+		return errors.Wrapf(ErrNotFound, "recurrence %v", instance.RecurrenceID)
+	}
+	if _, ok := m.data.lists[instance.ListID]; !ok {
+		return errors.Wrapf(ErrNotFound, "list %v", instance.ListID)
+	}
+	instance.ID = m.data.nextID()
+	m.data.instances[instance.ID] = *instance
+	return nil
+}
+
+func (m *Memory) GetRecurrenceInstances(ctx context.Context, recurrenceID int64) ([]api.RecurrenceInstance, error) {
+	defer m.lock()()
+	var instances []api.RecurrenceInstance
+	for _, instance := range m.data.instances {
+		if instance.RecurrenceID == recurrenceID {
+			instances = append(instances, instance)
+		}
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i].ID > instances[j].ID })
+	return instances, nil
+}
+
+func (m *Memory) GetListsDueBetween(ctx context.Context, from time.Time, to time.Time) ([]api.List, error) {
+	defer m.lock()()
+	var lists []api.List
+	for _, list := range m.data.lists {
+		if list.Status == api.Todo && !list.Template && list.Deadline.After(from) && !list.Deadline.After(to) {
+			lists = append(lists, m.data.hydrateList(list))
+		}
+	}
+	sort.Slice(lists, func(i, j int) bool { return lists[i].ID < lists[j].ID })
+	return lists, nil
+}
+
+func (m *Memory) GetItemsDueBetween(ctx context.Context, from time.Time, to time.Time) ([]api.Item, error) {
+	defer m.lock()()
+	var items []api.Item
+	for _, item := range m.data.items {
+		if item.Status == api.Todo && item.Deadline.After(from) && !item.Deadline.After(to) {
+			items = append(items, m.data.hydrateItem(item))
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return items, nil
+}
+
+func (m *Memory) GetReminderPreferences(ctx context.Context, userID int64) (ReminderPreferences, error) {
+	defer m.lock()()
+	preferences, ok := m.data.preferences[userID]
+	if !ok {
+		return preferences, errors.Wrapf(ErrNotFound, "reminder preferences of user %v", userID)
+	}
+	return preferences, nil
+}
+
+func (m *Memory) SaveReminderPreferences(ctx context.Context, preferences *ReminderPreferences) error {
+	defer m.lock()()
+	if _, ok := m.data.users[preferences.UserID]; !ok {
+		return errors.Wrapf(ErrNotFound, "user %v", preferences.UserID)
+	}
+	m.data.preferences[preferences.UserID] = *preferences
+	return nil
+}
+
+func (m *Memory) AddReminder(ctx context.Context, reminder *Reminder) error {
+	defer m.lock()()
+	if _, ok := m.data.users[reminder.UserID]; !ok {
+		return errors.Wrapf(ErrNotFound, "user %v", reminder.UserID)
+	}
+	if _, ok := m.data.lists[reminder.ListID]; !ok {
+		return errors.Wrapf(ErrNotFound, "list %v", reminder.ListID)
+	}
+	if _, ok := m.data.items[reminder.ItemID]; reminder.ItemID != 0 && !ok {
+		return errors.Wrapf(ErrNotFound, "item %v", reminder.ItemID)
+	}
+	reminder.ID = m.data.nextID()
+	m.data.reminders[reminder.ID] = *reminder
+	return nil
+}
+
+func (m *Memory) GetReminder(ctx context.Context, userID int64, listID int64, itemID int64, deadline time.Time) (Reminder, error) {
+	defer m.lock()()
+	for _, reminder := range m.data.reminders {
+		if reminder.UserID == userID && reminder.ListID == listID && reminder.ItemID == itemID && reminder.Deadline.Equal(deadline) {
+			return reminder, nil
+		}
+	}
+	return Reminder{}, errors.Wrapf(ErrNotFound, "reminder of user %v for list %v item %v", userID, listID, itemID)
+}
@@ -0,0 +1,175 @@
+package store
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"shoppinglist/pkg/api"
+	"testing"
+	"time"
+)
+
+// seed returns a repository with one user owning one list and a category for its items
+func seed(t *testing.T) (*Memory, User, api.List, api.Category) {
+	ctx := context.Background()
+	m := NewMemory()
+	user := User{UserName: "alice", Password: "pw", Status: "active"}
+	if err := m.CreateUser(ctx, &user); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+	list := api.List{Name: "weekly", Owner: api.User{UserID: user.ID}, Status: api.Todo, Deadline: time.Now().Add(time.Hour)}
+	if err := m.CreateList(ctx, &list); err != nil {
+		t.Fatalf("failed to add list: %v", err)
+	}
+	category := api.Category{Name: "Dairy"}
+	if err := m.CreateCategory(ctx, &category); err != nil {
+		t.Fatalf("failed to add category: %v", err)
+	}
+	return m, user, list, category
+}
+
+func TestMemoryTxCommitsOrRollsBack(t *testing.T) {
+	ctx := context.Background()
+	m, _, list, category := seed(t)
+
+	failed := errors.New("failed")
+	var itemID int64
+	err := m.Tx(ctx, func(tx Repository) error {
+		item := api.Item{ListID: list.ID, Title: "milk", Status: api.Todo, Category: category}
+		if err := tx.CreateItem(ctx, &item); err != nil {
+			return err
+		}
+		itemID = item.ID
+		renamed := list
+		renamed.Name = "renamed"
+		if err := tx.UpdateList(ctx, &renamed); err != nil {
+			return err
+		}
+		return failed
+	})
+	if err != failed {
+		t.Fatalf("Tx returned %v, expected the error of fn", err)
+	}
+	if _, err := m.GetItem(ctx, itemID); !IsNotFound(err) {
+		t.Fatalf("item added in a rolled back transaction was kept, error %v", err)
+	}
+	stored, err := m.GetList(ctx, list.ID)
+	if err != nil {
+		t.Fatalf("failed to read list: %v", err)
+	}
+	if stored.Name != "weekly" {
+		t.Fatalf("list change of a rolled back transaction was kept, name %q", stored.Name)
+	}
+
+	err = m.Tx(ctx, func(tx Repository) error {
+		item := api.Item{ListID: list.ID, Title: "milk", Status: api.Todo, Category: category}
+		err := tx.CreateItem(ctx, &item)
+		itemID = item.ID
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	if _, err := m.GetItem(ctx, itemID); err != nil {
+		t.Fatalf("item added in a committed transaction is missing: %v", err)
+	}
+}
+
+func TestMemoryUsers(t *testing.T) {
+	ctx := context.Background()
+	m, user, _, _ := seed(t)
+
+	duplicate := User{UserName: user.UserName, Password: "pw", Status: "active"}
+	if err := m.CreateUser(ctx, &duplicate); err == nil {
+		t.Fatal("added a second user with the same name")
+	}
+	stored, err := m.GetUserByName(ctx, user.UserName)
+	if err != nil || stored.ID != user.ID {
+		t.Fatalf("read user %v by name with error %v", stored.ID, err)
+	}
+	if _, err := m.GetUserByID(ctx, user.ID+100); !IsNotFound(err) {
+		t.Fatalf("reading an unknown user returned %v", err)
+	}
+}
+
+func TestMemoryListItemsByPosition(t *testing.T) {
+	ctx := context.Background()
+	m, _, list, category := seed(t)
+	for i, title := range []string{"milk", "bread", "eggs"} {
+		// added in reverse of their position
+		item := api.Item{ListID: list.ID, Title: title, Status: api.Todo, Category: category, Position: int64(3 - i)}
+		if err := m.CreateItem(ctx, &item); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+	}
+	items, err := m.GetListItems(ctx, list.ID)
+	if err != nil {
+		t.Fatalf("failed to read items: %v", err)
+	}
+	var titles []string
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+	if len(titles) != 3 || titles[0] != "eggs" || titles[1] != "bread" || titles[2] != "milk" {
+		t.Fatalf("items are ordered %v", titles)
+	}
+}
+
+func TestMemoryGetListsDueBetween(t *testing.T) {
+	ctx := context.Background()
+	m, user, list, _ := seed(t)
+	for _, other := range []api.List{
+		{Name: "template", Status: api.Todo, Template: true, Deadline: list.Deadline},
+		{Name: "bought", Status: api.Bought, Deadline: list.Deadline},
+		{Name: "later", Status: api.Todo, Deadline: list.Deadline.Add(time.Hour)},
+	} {
+		other.Owner.UserID = user.ID
+		if err := m.CreateList(ctx, &other); err != nil {
+			t.Fatalf("failed to add list: %v", err)
+		}
+	}
+
+	lists, err := m.GetListsDueBetween(ctx, list.Deadline.Add(-time.Minute), list.Deadline)
+	if err != nil {
+		t.Fatalf("failed to read due lists: %v", err)
+	}
+	if len(lists) != 1 || lists[0].ID != list.ID {
+		t.Fatalf("expected only the weekly list to be due, got %+v", lists)
+	}
+	// from is exclusive
+	lists, err = m.GetListsDueBetween(ctx, list.Deadline, list.Deadline.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("failed to read due lists: %v", err)
+	}
+	if len(lists) != 0 {
+		t.Fatalf("expected no list due, got %+v", lists)
+	}
+}
+
+func TestMemoryPurgeDeletedLists(t *testing.T) {
+	ctx := context.Background()
+	m, _, list, category := seed(t)
+	item := api.Item{ListID: list.ID, Title: "milk", Status: api.Todo, Category: category}
+	if err := m.CreateItem(ctx, &item); err != nil {
+		t.Fatalf("failed to add item: %v", err)
+	}
+	list.Status = api.Deleted
+	list.DeletedAt = time.Now().Add(-time.Hour)
+	if err := m.UpdateList(ctx, &list); err != nil {
+		t.Fatalf("failed to delete list: %v", err)
+	}
+
+	purged, err := m.PurgeDeletedLists(ctx, list.DeletedAt)
+	if err != nil || purged != 0 {
+		t.Fatalf("purged %v lists deleted at the cut-off, error %v", purged, err)
+	}
+	purged, err = m.PurgeDeletedLists(ctx, time.Now())
+	if err != nil || purged != 1 {
+		t.Fatalf("purged %v lists, error %v", purged, err)
+	}
+	if _, err := m.GetList(ctx, list.ID); !IsNotFound(err) {
+		t.Fatalf("purged list is still there, error %v", err)
+	}
+	if _, err := m.GetItem(ctx, item.ID); !IsNotFound(err) {
+		t.Fatalf("item of a purged list is still there, error %v", err)
+	}
+}
@@ -0,0 +1,1227 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"shoppinglist/pkg/api"
+	"time"
+)
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// MySQL is a Repository backed by the mysql schema in shopping_list_ddl
+type MySQL struct {
+	db *sqlx.DB
+	// ext is the db itself or the transaction the repository is bound to
+	ext sqlx.ExtContext
+	tx  *sqlx.Tx
+}
+
+// NewMySQL returns a Repository that reads and writes through given db
+func NewMySQL(db *sqlx.DB) *MySQL {
+	return &MySQL{db: db, ext: db}
+}
+
+func notFound(err error, msg string) error {
+	if err == sql.ErrNoRows {
+		return errors.Wrap(ErrNotFound, msg)
+	}
+	return errors.Wrap(err, msg)
+}
+
+func nullInt64(v int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: v, Valid: v != 0}
+}
+
+func nullFloat64(v float64) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: v, Valid: v != 0}
+}
+
+func nullString(v string) sql.NullString {
+	return sql.NullString{String: v, Valid: v != ""}
+}
+
+func nullTime(t time.Time) mysql.NullTime {
+	return mysql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func (s *MySQL) Tx(ctx context.Context, fn func(Repository) error) error {
+	if s.tx != nil {
+		// already bound to a transaction, join it
+		return fn(s)
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	err = fn(&MySQL{db: s.db, ext: tx, tx: tx})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+const userColumns = "id, username, full_name, email, password, created_at, updated_at, last_logged_in_at, status"
+
+func scanUser(row scanner) (User, error) {
+	var (
+		user            User
+		fullName, email sql.NullString
+	)
+	err := row.Scan(&user.ID, &user.UserName, &fullName, &email, &user.Password, &user.CreatedAt, &user.UpdatedAt,
+		&user.LastLoggedInAt, &user.Status)
+	user.FullName = fullName.String
+	user.Email = email.String
+	return user, err
+}
+
+func (s *MySQL) CreateUser(ctx context.Context, user *User) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into users (username, full_name, email, password, created_at, updated_at, "+
+		"last_logged_in_at, status) values (?,?,?,?,?,?,?,?)",
+		user.UserName, user.FullName, user.Email, user.Password, user.CreatedAt, user.UpdatedAt, user.LastLoggedInAt, user.Status)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert user in DB")
+	}
+	user.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of created user")
+	}
+	return nil
+}
+
+func (s *MySQL) GetUserByID(ctx context.Context, userID int64) (User, error) {
+	user, err := scanUser(s.ext.QueryRowxContext(ctx, "select "+userColumns+" from users where id=?", userID))
+	if err != nil {
+		return user, notFound(err, "failed to read user from DB")
+	}
+	return user, nil
+}
+
+func (s *MySQL) GetUserByName(ctx context.Context, userName string) (User, error) {
+	user, err := scanUser(s.ext.QueryRowxContext(ctx, "select "+userColumns+" from users where username=?", userName))
+	if err != nil {
+		return user, notFound(err, "failed to read user from DB")
+	}
+	return user, nil
+}
+
+func (s *MySQL) UpdateLastLoggedIn(ctx context.Context, userID int64, at time.Time) error {
+	_, err := s.ext.ExecContext(ctx, "update users set last_logged_in_at=? where id=?", at, userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to update the last logged in date in DB")
+	}
+	return nil
+}
+
+const listColumns = "l.id, l.name, l.description, l.owner, u.username, l.created_at, l.last_modified_at, l.deadline, l.status, l.deleted_at, " +
+	"l.store, l.is_template"
+
+func scanList(row scanner, extra ...interface{}) (api.List, error) {
+	var (
+		list                api.List
+		description, status sql.NullString
+		deletedAt           mysql.NullTime
+		storeID             sql.NullInt64
+	)
+	dest := []interface{}{&list.ID, &list.Name, &description, &list.Owner.UserID, &list.Owner.UserName, &list.CreatedAt,
+		&list.LastModifiedAt, &list.Deadline, &status, &deletedAt, &storeID, &list.Template}
+	err := row.Scan(append(dest, extra...)...)
+	list.Description = description.String
+	list.Status = status.String
+	list.DeletedAt = deletedAt.Time
+	list.StoreID = storeID.Int64
+	return list, err
+}
+
+func (s *MySQL) CreateList(ctx context.Context, list *api.List) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into list (name, description, owner, created_at, last_modified_at, deadline, status, "+
+		"deleted_at, store, is_template) values (?,?,?,?,?,?,?,?,?,?)",
+		list.Name, list.Description, list.Owner.UserID, list.CreatedAt, list.LastModifiedAt, list.Deadline, list.Status,
+		nullTime(list.DeletedAt), nullInt64(list.StoreID), list.Template)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert new list in DB")
+	}
+	list.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of created list")
+	}
+	return nil
+}
+
+func (s *MySQL) GetList(ctx context.Context, listID int64) (api.List, error) {
+	list, err := scanList(s.ext.QueryRowxContext(ctx, "select "+listColumns+" from list l join users u on u.id=l.owner "+
+		"where l.id=?", listID))
+	if err != nil {
+		return list, notFound(err, "failed to read list from DB")
+	}
+	return list, nil
+}
+
+// strongestRole picks the most privileged role among the access types of the
+// grants aliased g, the access types of older clients count as their role
+const strongestRole = "elt(max(field(g.access_type, 'read_only', 'viewer', 'shopper', 'edit', 'editor', 'manager')), " +
+	"'viewer', 'viewer', 'shopper', 'editor', 'editor', 'manager')"
+
+// userGrants selects the list and access type of every grant a user holds,
+// directly or through a group
+const userGrants = "select lc.list, lc.access_type from list_contributer lc " +
+	"where lc.user=? and (lc.valid_until is null or lc.valid_until>?) " +
+	"union all select lg.list, lg.access_type from list_group lg " +
+	"join group_member gm on gm.group_id=lg.group_id where gm.user=?"
+
+func (s *MySQL) GetListsForUser(ctx context.Context, userID int64) ([]api.List, error) {
+	var lists []api.List
+	rows, err := s.ext.QueryxContext(ctx, "select "+listColumns+", "+
+		strongestRole+" from list l "+
+		"join ("+userGrants+") g on g.list=l.id join users u on u.id=l.owner group by l.id order by l.id",
+		userID, time.Now(), userID)
+	if err != nil {
+		return lists, errors.Wrap(err, "failed to query DB for given user's lists")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var accessType string
+		list, err := scanList(rows, &accessType)
+		if err != nil {
+			return lists, errors.Wrap(err, "failed to read list from DB")
+		}
+		list.AccessType = accessType
+		lists = append(lists, list)
+	}
+	return lists, rows.Err()
+}
+
+func (s *MySQL) UpdateList(ctx context.Context, list *api.List) error {
+	_, err := s.ext.ExecContext(ctx, "update list set name=?, description=?, owner=?, last_modified_at=?, deadline=?, status=?, "+
+		"deleted_at=?, store=? where id=?",
+		list.Name, list.Description, list.Owner.UserID, list.LastModifiedAt, list.Deadline, list.Status,
+		nullTime(list.DeletedAt), nullInt64(list.StoreID), list.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update list:%v in DB", list.ID)
+	}
+	return nil
+}
+
+// deletedBefore matches the deleted rows of a table deleted before a given time,
+// rows deleted before deleted_at was recorded fall back to their last change
+const deletedBefore = "status='deleted' and coalesce(deleted_at, last_modified_at) < ?"
+
+func (s *MySQL) PurgeDeletedLists(ctx context.Context, before time.Time) (int64, error) {
+	purged := "select id from list where " + deletedBefore
+	_, err := s.ext.ExecContext(ctx, "delete from item where list in ("+purged+")", before)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete items of purged lists from DB")
+	}
+	_, err = s.ext.ExecContext(ctx, "delete from list_contributer where list in ("+purged+")", before)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete contributers of purged lists from DB")
+	}
+	_, err = s.ext.ExecContext(ctx, "delete from invitation where list in ("+purged+")", before)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete invitations of purged lists from DB")
+	}
+	_, err = s.ext.ExecContext(ctx, "delete from public_link where list in ("+purged+")", before)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete public links of purged lists from DB")
+	}
+	_, err = s.ext.ExecContext(ctx, "delete from list_group where list in ("+purged+")", before)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete group grants of purged lists from DB")
+	}
+	resp, err := s.ext.ExecContext(ctx, "delete from list where "+deletedBefore, before)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete purged lists from DB")
+	}
+	return resp.RowsAffected()
+}
+
+func (s *MySQL) AddContributor(ctx context.Context, contributor *Contributor) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into list_contributer (list, user, access_type, valid_until) values (?,?,?,?)",
+		contributor.ListID, contributor.UserID, contributor.AccessType, nullTime(contributor.ValidUntil))
+	if err != nil {
+		return errors.Wrap(err, "failed to make an entry in list_contributer table")
+	}
+	contributor.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of list contributer")
+	}
+	return nil
+}
+
+const contributorColumns = "id, list, user, access_type, valid_until"
+
+func scanContributor(row scanner) (Contributor, error) {
+	var (
+		c          Contributor
+		validUntil mysql.NullTime
+	)
+	err := row.Scan(&c.ID, &c.ListID, &c.UserID, &c.AccessType, &validUntil)
+	c.ValidUntil = validUntil.Time
+	return c, err
+}
+
+func (s *MySQL) GetContributor(ctx context.Context, listID int64, userID int64) (Contributor, error) {
+	c, err := scanContributor(s.ext.QueryRowxContext(ctx, "select "+contributorColumns+" from list_contributer "+
+		"where list=? and user=?", listID, userID))
+	if err != nil {
+		return c, notFound(err, "failed to read list contributer from DB")
+	}
+	return c, nil
+}
+
+func (s *MySQL) GetListContributors(ctx context.Context, listID int64) ([]Contributor, error) {
+	var contributors []Contributor
+	rows, err := s.ext.QueryxContext(ctx, "select "+contributorColumns+" from list_contributer where list=? order by id",
+		listID)
+	if err != nil {
+		return contributors, errors.Wrap(err, "failed to read contributers of given list")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		c, err := scanContributor(rows)
+		if err != nil {
+			return contributors, errors.Wrap(err, "failed to read list contributer from DB")
+		}
+		contributors = append(contributors, c)
+	}
+	return contributors, rows.Err()
+}
+
+func (s *MySQL) UpdateContributor(ctx context.Context, contributor *Contributor) error {
+	_, err := s.ext.ExecContext(ctx, "update list_contributer set access_type=?, valid_until=? where id=?",
+		contributor.AccessType, nullTime(contributor.ValidUntil), contributor.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update list contributer:%v in DB", contributor.ID)
+	}
+	return nil
+}
+
+func (s *MySQL) RemoveContributor(ctx context.Context, contributorID int64) error {
+	_, err := s.ext.ExecContext(ctx, "delete from list_contributer where id=?", contributorID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete list contributer:%v from DB", contributorID)
+	}
+	return nil
+}
+
+const inviteColumns = "id, list, access_type, status, created_by, created_at, expires_at, accepted_by, accepted_at"
+
+func scanInvite(row scanner) (Invite, error) {
+	var (
+		invite     Invite
+		acceptedBy sql.NullInt64
+		acceptedAt mysql.NullTime
+	)
+	err := row.Scan(&invite.ID, &invite.ListID, &invite.AccessType, &invite.Status, &invite.CreatedBy, &invite.CreatedAt,
+		&invite.ExpiresAt, &acceptedBy, &acceptedAt)
+	invite.AcceptedBy = acceptedBy.Int64
+	invite.AcceptedAt = acceptedAt.Time
+	return invite, err
+}
+
+func (s *MySQL) CreateInvite(ctx context.Context, invite *Invite) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into invitation (list, access_type, status, created_by, created_at, "+
+		"expires_at, accepted_by, accepted_at) values (?,?,?,?,?,?,?,?)",
+		invite.ListID, invite.AccessType, invite.Status, invite.CreatedBy, invite.CreatedAt, invite.ExpiresAt,
+		nullInt64(invite.AcceptedBy), nullTime(invite.AcceptedAt))
+	if err != nil {
+		return errors.Wrap(err, "failed to insert invitation in DB")
+	}
+	invite.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of created invitation")
+	}
+	return nil
+}
+
+func (s *MySQL) GetInvite(ctx context.Context, inviteID int64) (Invite, error) {
+	invite, err := scanInvite(s.ext.QueryRowxContext(ctx, "select "+inviteColumns+" from invitation where id=?", inviteID))
+	if err != nil {
+		return invite, notFound(err, "failed to read invitation from DB")
+	}
+	return invite, nil
+}
+
+func (s *MySQL) GetListInvites(ctx context.Context, listID int64) ([]Invite, error) {
+	var invites []Invite
+	rows, err := s.ext.QueryxContext(ctx, "select "+inviteColumns+" from invitation where list=? order by id", listID)
+	if err != nil {
+		return invites, errors.Wrap(err, "failed to read invitations for given list")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		invite, err := scanInvite(rows)
+		if err != nil {
+			return invites, errors.Wrap(err, "failed to read invitation from DB")
+		}
+		invites = append(invites, invite)
+	}
+	return invites, rows.Err()
+}
+
+func (s *MySQL) UpdateInvite(ctx context.Context, invite *Invite) error {
+	_, err := s.ext.ExecContext(ctx, "update invitation set status=?, accepted_by=?, accepted_at=? where id=?",
+		invite.Status, nullInt64(invite.AcceptedBy), nullTime(invite.AcceptedAt), invite.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update invitation:%v in DB", invite.ID)
+	}
+	return nil
+}
+
+func (s *MySQL) CreateOwnershipTransfer(ctx context.Context, transfer *OwnershipTransfer) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into ownership_transfer (list, from_user, to_user, transferred_at) values (?,?,?,?)",
+		transfer.ListID, transfer.FromUserID, transfer.ToUserID, transfer.TransferredAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert ownership transfer in DB")
+	}
+	transfer.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of created ownership transfer")
+	}
+	return nil
+}
+
+func (s *MySQL) GetListOwnershipTransfers(ctx context.Context, listID int64) ([]OwnershipTransfer, error) {
+	var transfers []OwnershipTransfer
+	rows, err := s.ext.QueryxContext(ctx, "select id, list, from_user, to_user, transferred_at from ownership_transfer "+
+		"where list=? order by id", listID)
+	if err != nil {
+		return transfers, errors.Wrap(err, "failed to read ownership transfers for given list")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var transfer OwnershipTransfer
+		err = rows.Scan(&transfer.ID, &transfer.ListID, &transfer.FromUserID, &transfer.ToUserID, &transfer.TransferredAt)
+		if err != nil {
+			return transfers, errors.Wrap(err, "failed to read ownership transfer from DB")
+		}
+		transfers = append(transfers, transfer)
+	}
+	return transfers, rows.Err()
+}
+
+const publicLinkColumns = "id, list, access_type, created_by, created_at, expires_at, revoked_at"
+
+func scanPublicLink(row scanner) (PublicLink, error) {
+	var (
+		link      PublicLink
+		revokedAt mysql.NullTime
+	)
+	err := row.Scan(&link.ID, &link.ListID, &link.AccessType, &link.CreatedBy, &link.CreatedAt, &link.ExpiresAt, &revokedAt)
+	link.RevokedAt = revokedAt.Time
+	return link, err
+}
+
+func (s *MySQL) CreatePublicLink(ctx context.Context, link *PublicLink) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into public_link (list, access_type, created_by, created_at, expires_at, "+
+		"revoked_at) values (?,?,?,?,?,?)",
+		link.ListID, link.AccessType, link.CreatedBy, link.CreatedAt, link.ExpiresAt, nullTime(link.RevokedAt))
+	if err != nil {
+		return errors.Wrap(err, "failed to insert public link in DB")
+	}
+	link.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of created public link")
+	}
+	return nil
+}
+
+func (s *MySQL) GetPublicLink(ctx context.Context, linkID int64) (PublicLink, error) {
+	link, err := scanPublicLink(s.ext.QueryRowxContext(ctx, "select "+publicLinkColumns+" from public_link where id=?", linkID))
+	if err != nil {
+		return link, notFound(err, "failed to read public link from DB")
+	}
+	return link, nil
+}
+
+func (s *MySQL) GetListPublicLinks(ctx context.Context, listID int64) ([]PublicLink, error) {
+	var links []PublicLink
+	rows, err := s.ext.QueryxContext(ctx, "select "+publicLinkColumns+" from public_link where list=? order by id", listID)
+	if err != nil {
+		return links, errors.Wrap(err, "failed to read public links for given list")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		link, err := scanPublicLink(rows)
+		if err != nil {
+			return links, errors.Wrap(err, "failed to read public link from DB")
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+func (s *MySQL) UpdatePublicLink(ctx context.Context, link *PublicLink) error {
+	_, err := s.ext.ExecContext(ctx, "update public_link set revoked_at=? where id=?", nullTime(link.RevokedAt), link.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update public link:%v in DB", link.ID)
+	}
+	return nil
+}
+
+func (s *MySQL) CreateGroup(ctx context.Context, group *Group) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into user_group (name, created_by, created_at) values (?,?,?)",
+		group.Name, group.CreatedBy, group.CreatedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert group in DB")
+	}
+	group.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of created group")
+	}
+	return nil
+}
+
+func (s *MySQL) GetGroup(ctx context.Context, groupID int64) (Group, error) {
+	var group Group
+	err := s.ext.QueryRowxContext(ctx, "select id, name, created_by, created_at from user_group where id=?", groupID).
+		Scan(&group.ID, &group.Name, &group.CreatedBy, &group.CreatedAt)
+	if err != nil {
+		return group, notFound(err, "failed to read group from DB")
+	}
+	return group, nil
+}
+
+const groupMemberColumns = "id, group_id, user, role"
+
+func scanGroupMember(row scanner) (GroupMember, error) {
+	var member GroupMember
+	err := row.Scan(&member.ID, &member.GroupID, &member.UserID, &member.Role)
+	return member, err
+}
+
+func (s *MySQL) queryGroupMembers(ctx context.Context, where string, arg int64) ([]GroupMember, error) {
+	var members []GroupMember
+	rows, err := s.ext.QueryxContext(ctx, "select "+groupMemberColumns+" from group_member where "+where+" order by id", arg)
+	if err != nil {
+		return members, errors.Wrap(err, "failed to read group members from DB")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		member, err := scanGroupMember(rows)
+		if err != nil {
+			return members, errors.Wrap(err, "failed to read group member from DB")
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+func (s *MySQL) AddGroupMember(ctx context.Context, member *GroupMember) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into group_member (group_id, user, role) values (?,?,?)",
+		member.GroupID, member.UserID, member.Role)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert group member in DB")
+	}
+	member.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of group member")
+	}
+	return nil
+}
+
+func (s *MySQL) GetGroupMember(ctx context.Context, groupID int64, userID int64) (GroupMember, error) {
+	member, err := scanGroupMember(s.ext.QueryRowxContext(ctx, "select "+groupMemberColumns+" from group_member "+
+		"where group_id=? and user=?", groupID, userID))
+	if err != nil {
+		return member, notFound(err, "failed to read group member from DB")
+	}
+	return member, nil
+}
+
+func (s *MySQL) GetGroupMembers(ctx context.Context, groupID int64) ([]GroupMember, error) {
+	return s.queryGroupMembers(ctx, "group_id=?", groupID)
+}
+
+func (s *MySQL) GetUserGroups(ctx context.Context, userID int64) ([]GroupMember, error) {
+	return s.queryGroupMembers(ctx, "user=?", userID)
+}
+
+func (s *MySQL) UpdateGroupMember(ctx context.Context, member *GroupMember) error {
+	_, err := s.ext.ExecContext(ctx, "update group_member set role=? where id=?", member.Role, member.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update group member:%v in DB", member.ID)
+	}
+	return nil
+}
+
+func (s *MySQL) RemoveGroupMember(ctx context.Context, memberID int64) error {
+	_, err := s.ext.ExecContext(ctx, "delete from group_member where id=?", memberID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete group member:%v from DB", memberID)
+	}
+	return nil
+}
+
+const listGroupColumns = "id, list, group_id, access_type"
+
+func scanListGroup(row scanner) (ListGroup, error) {
+	var lg ListGroup
+	err := row.Scan(&lg.ID, &lg.ListID, &lg.GroupID, &lg.AccessType)
+	return lg, err
+}
+
+func (s *MySQL) AddListGroup(ctx context.Context, listGroup *ListGroup) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into list_group (list, group_id, access_type) values (?,?,?)",
+		listGroup.ListID, listGroup.GroupID, listGroup.AccessType)
+	if err != nil {
+		return errors.Wrap(err, "failed to make an entry in list_group table")
+	}
+	listGroup.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of list group")
+	}
+	return nil
+}
+
+func (s *MySQL) GetListGroup(ctx context.Context, listID int64, groupID int64) (ListGroup, error) {
+	lg, err := scanListGroup(s.ext.QueryRowxContext(ctx, "select "+listGroupColumns+" from list_group "+
+		"where list=? and group_id=?", listID, groupID))
+	if err != nil {
+		return lg, notFound(err, "failed to read list group from DB")
+	}
+	return lg, nil
+}
+
+func (s *MySQL) GetListGroups(ctx context.Context, listID int64) ([]ListGroup, error) {
+	var listGroups []ListGroup
+	rows, err := s.ext.QueryxContext(ctx, "select "+listGroupColumns+" from list_group where list=? order by id", listID)
+	if err != nil {
+		return listGroups, errors.Wrap(err, "failed to read groups of given list")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		lg, err := scanListGroup(rows)
+		if err != nil {
+			return listGroups, errors.Wrap(err, "failed to read list group from DB")
+		}
+		listGroups = append(listGroups, lg)
+	}
+	return listGroups, rows.Err()
+}
+
+func (s *MySQL) UpdateListGroup(ctx context.Context, listGroup *ListGroup) error {
+	_, err := s.ext.ExecContext(ctx, "update list_group set access_type=? where id=?", listGroup.AccessType, listGroup.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update list group:%v in DB", listGroup.ID)
+	}
+	return nil
+}
+
+func (s *MySQL) RemoveListGroup(ctx context.Context, listGroupID int64) error {
+	_, err := s.ext.ExecContext(ctx, "delete from list_group where id=?", listGroupID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete list group:%v from DB", listGroupID)
+	}
+	return nil
+}
+
+func (s *MySQL) GetGroupAccess(ctx context.Context, listID int64, userID int64) (string, error) {
+	var accessType sql.NullString
+	err := s.ext.QueryRowxContext(ctx, "select "+strongestRole+" from list_group g "+
+		"join group_member gm on gm.group_id=g.group_id where g.list=? and gm.user=? having count(*)>0",
+		listID, userID).Scan(&accessType)
+	if err != nil {
+		return "", notFound(err, "failed to read group access from DB")
+	}
+	return accessType.String, nil
+}
+
+const itemColumns = "i.id, i.list, i.title, i.description, i.quantity, i.unit, i.status, c.id, c.name, c.type, " +
+	"c.owner_user, c.owner_group, i.created_by, cu.username, i.last_modified_by, mu.username, i.bought_by, bu.username, i.created_at, " +
+	"i.last_modified_at, i.bought_at, i.deleted_at, i.deadline, i.position"
+
+const itemTables = "item i join category c on c.id=i.category join users cu on cu.id=i.created_by " +
+	"join users mu on mu.id=i.last_modified_by left join users bu on bu.id=i.bought_by"
+
+func scanItem(row scanner) (api.Item, error) {
+	var (
+		item                                          api.Item
+		description, unit, categoryType, boughtByName sql.NullString
+		quantity                                      sql.NullFloat64
+		boughtBy                                      sql.NullInt64
+		boughtAt, deletedAt                           mysql.NullTime
+	)
+	err := row.Scan(&item.ID, &item.ListID, &item.Title, &description, &quantity, &unit, &item.Status, &item.Category.ID,
+		&item.Category.Name, &categoryType, &item.Category.OwnerID, &item.Category.GroupID, &item.CreatedBy.UserID, &item.CreatedBy.UserName, &item.LastModifiedBy.UserID,
+		&item.LastModifiedBy.UserName, &boughtBy, &boughtByName, &item.CreatedAt, &item.LastModifiedAt, &boughtAt, &deletedAt,
+		&item.Deadline, &item.Position)
+	item.Description = description.String
+	item.Quantity = quantity.Float64
+	item.Unit = unit.String
+	item.Category.Type = categoryType.String
+	item.BoughtBy.UserID = boughtBy.Int64
+	item.BoughtBy.UserName = boughtByName.String
+	item.BoughtAt = boughtAt.Time
+	item.DeletedAt = deletedAt.Time
+	return item, err
+}
+
+func (s *MySQL) CreateItem(ctx context.Context, item *api.Item) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into item (list, title, description, quantity, unit, status, category, "+
+		"created_by, last_modified_by, bought_by, created_at, last_modified_at, bought_at, deleted_at, deadline, position) "+
+		"values (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)",
+		item.ListID, item.Title, item.Description, nullFloat64(item.Quantity), nullString(item.Unit), item.Status, item.Category.ID, item.CreatedBy.UserID,
+		item.LastModifiedBy.UserID, nullInt64(item.BoughtBy.UserID), item.CreatedAt, item.LastModifiedAt,
+		nullTime(item.BoughtAt), nullTime(item.DeletedAt), item.Deadline, item.Position)
+	if err != nil {
+		return errors.Wrap(err, "failed to add new item")
+	}
+	item.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of created item")
+	}
+	return nil
+}
+
+func (s *MySQL) GetItem(ctx context.Context, itemID int64) (api.Item, error) {
+	query := "select " + itemColumns + " from " + itemTables + " where i.id=?"
+	if s.tx != nil {
+		// an item read in a transaction is about to be changed, concurrent
+		// changes wait for the transaction instead of being overwritten
+		query += " for update"
+	}
+	item, err := scanItem(s.ext.QueryRowxContext(ctx, query, itemID))
+	if err != nil {
+		return item, notFound(err, "failed to read item from DB")
+	}
+	return item, nil
+}
+
+func (s *MySQL) GetListItems(ctx context.Context, listID int64) ([]api.Item, error) {
+	var items []api.Item
+	rows, err := s.ext.QueryxContext(ctx, "select "+itemColumns+" from "+itemTables+" where i.list=? order by i.position, i.id", listID)
+	if err != nil {
+		return items, errors.Wrap(err, "failed to read items for given list")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return items, errors.Wrap(err, "failed to read item from DB")
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (s *MySQL) UpdateItem(ctx context.Context, item *api.Item) error {
+	_, err := s.ext.ExecContext(ctx, "update item set list=?, title=?, description=?, quantity=?, unit=?, status=?, "+
+		"category=?, last_modified_by=?, bought_by=?, last_modified_at=?, bought_at=?, deleted_at=?, deadline=?, position=? "+
+		"where id=?",
+		item.ListID, item.Title, item.Description, nullFloat64(item.Quantity), nullString(item.Unit), item.Status, item.Category.ID, item.LastModifiedBy.UserID,
+		nullInt64(item.BoughtBy.UserID), item.LastModifiedAt, nullTime(item.BoughtAt), nullTime(item.DeletedAt), item.Deadline,
+		item.Position, item.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update item:%v in DB", item.ID)
+	}
+	return nil
+}
+
+func (s *MySQL) PurgeDeletedItems(ctx context.Context, before time.Time) (int64, error) {
+	resp, err := s.ext.ExecContext(ctx, "delete from item where "+deletedBefore, before)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete purged items from DB")
+	}
+	return resp.RowsAffected()
+}
+
+func (s *MySQL) CreateCategory(ctx context.Context, category *api.Category) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into category (name, type, owner_user, owner_group) values (?,?,?,?)",
+		category.Name, category.Type, category.OwnerID, category.GroupID)
+	if err != nil {
+		return errors.Wrap(err, "failed to add new category in DB")
+	}
+	category.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of created category")
+	}
+	return nil
+}
+
+const categoryColumns = "c.id, c.name, c.type, c.owner_user, c.owner_group"
+
+func scanCategory(row scanner) (api.Category, error) {
+	var (
+		category     api.Category
+		categoryType sql.NullString
+	)
+	err := row.Scan(&category.ID, &category.Name, &categoryType, &category.OwnerID, &category.GroupID)
+	category.Type = categoryType.String
+	return category, err
+}
+
+func (s *MySQL) GetCategory(ctx context.Context, categoryID int64) (api.Category, error) {
+	category, err := scanCategory(s.ext.QueryRowxContext(ctx, "select "+categoryColumns+" from category c where c.id=?", categoryID))
+	if err != nil {
+		return category, notFound(err, "failed to read category from DB")
+	}
+	return category, nil
+}
+
+func (s *MySQL) GetVisibleCategories(ctx context.Context, userID int64) ([]api.Category, error) {
+	var categories []api.Category
+	rows, err := s.ext.QueryxContext(ctx, "select "+categoryColumns+" from category c "+
+		"where (c.owner_user=0 and c.owner_group=0) or c.owner_user=? "+
+		"or c.owner_group in (select gm.group_id from group_member gm where gm.user=?) order by c.id", userID, userID)
+	if err != nil {
+		return categories, errors.Wrap(err, "failed to read categories from system")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return categories, errors.Wrap(err, "failed to read category from DB")
+		}
+		categories = append(categories, category)
+	}
+	return categories, rows.Err()
+}
+
+func (s *MySQL) UpdateCategory(ctx context.Context, category *api.Category) error {
+	_, err := s.ext.ExecContext(ctx, "update category set name=?, type=? where id=?", category.Name, category.Type, category.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update category:%v in DB", category.ID)
+	}
+	return nil
+}
+
+func (s *MySQL) DeleteCategory(ctx context.Context, categoryID int64) error {
+	_, err := s.ext.ExecContext(ctx, "delete from category where id=?", categoryID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete category:%v from DB", categoryID)
+	}
+	return nil
+}
+
+func (s *MySQL) CountCategoryItems(ctx context.Context, categoryID int64) (int64, error) {
+	var count int64
+	err := s.ext.QueryRowxContext(ctx, "select count(*) from item where category=?", categoryID).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to count items of category:%v", categoryID)
+	}
+	return count, nil
+}
+
+func (s *MySQL) MoveCategoryItems(ctx context.Context, fromID int64, toID int64) (int64, error) {
+	resp, err := s.ext.ExecContext(ctx, "update item set category=? where category=?", toID, fromID)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to move items of category:%v to category:%v", fromID, toID)
+	}
+	return resp.RowsAffected()
+}
+
+const categoryRuleColumns = "id, user, pattern, is_regexp, category, created_at"
+
+func scanCategoryRule(row scanner) (CategoryRule, error) {
+	var rule CategoryRule
+	err := row.Scan(&rule.ID, &rule.UserID, &rule.Pattern, &rule.Regexp, &rule.CategoryID, &rule.CreatedAt)
+	return rule, err
+}
+
+func (s *MySQL) CreateCategoryRule(ctx context.Context, rule *CategoryRule) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into category_rule (user, pattern, is_regexp, category, created_at) values (?,?,?,?,?)",
+		rule.UserID, rule.Pattern, rule.Regexp, rule.CategoryID, rule.CreatedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert category rule in DB")
+	}
+	rule.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of created category rule")
+	}
+	return nil
+}
+
+func (s *MySQL) GetCategoryRule(ctx context.Context, ruleID int64) (CategoryRule, error) {
+	rule, err := scanCategoryRule(s.ext.QueryRowxContext(ctx, "select "+categoryRuleColumns+" from category_rule where id=?", ruleID))
+	if err != nil {
+		return rule, notFound(err, "failed to read category rule from DB")
+	}
+	return rule, nil
+}
+
+func (s *MySQL) GetUserCategoryRules(ctx context.Context, userID int64) ([]CategoryRule, error) {
+	var rules []CategoryRule
+	rows, err := s.ext.QueryxContext(ctx, "select "+categoryRuleColumns+" from category_rule where user=? order by id desc", userID)
+	if err != nil {
+		return rules, errors.Wrap(err, "failed to read category rules for given user")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		rule, err := scanCategoryRule(rows)
+		if err != nil {
+			return rules, errors.Wrap(err, "failed to read category rule from DB")
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+func (s *MySQL) DeleteCategoryRule(ctx context.Context, ruleID int64) error {
+	_, err := s.ext.ExecContext(ctx, "delete from category_rule where id=?", ruleID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete category rule:%v from DB", ruleID)
+	}
+	return nil
+}
+
+func (s *MySQL) SaveCategoryChoice(ctx context.Context, choice *CategoryChoice) error {
+	_, err := s.ext.ExecContext(ctx, "insert into category_choice (user, title, category, chosen_at) values (?,?,?,?) "+
+		"on duplicate key update category=values(category), chosen_at=values(chosen_at)",
+		choice.UserID, choice.Title, choice.CategoryID, choice.ChosenAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to save category choice in DB")
+	}
+	return nil
+}
+
+func (s *MySQL) GetCategoryChoice(ctx context.Context, userID int64, title string) (CategoryChoice, error) {
+	var choice CategoryChoice
+	err := s.ext.QueryRowxContext(ctx, "select user, title, category, chosen_at from category_choice where user=? and title=?",
+		userID, title).Scan(&choice.UserID, &choice.Title, &choice.CategoryID, &choice.ChosenAt)
+	if err != nil {
+		return choice, notFound(err, "failed to read category choice from DB")
+	}
+	return choice, nil
+}
+
+func (s *MySQL) MoveCategoryRules(ctx context.Context, fromID int64, toID int64) error {
+	_, err := s.ext.ExecContext(ctx, "update category_rule set category=? where category=?", toID, fromID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to move rules of category:%v to category:%v", fromID, toID)
+	}
+	_, err = s.ext.ExecContext(ctx, "update category_choice set category=? where category=?", toID, fromID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to move choices of category:%v to category:%v", fromID, toID)
+	}
+	return nil
+}
+
+func (s *MySQL) CreateStore(ctx context.Context, shop *api.Store) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into store (name, owner, created_at) values (?,?,?)",
+		shop.Name, shop.OwnerID, shop.CreatedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert new store in DB")
+	}
+	shop.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of created store")
+	}
+	return nil
+}
+
+// storeLayout reads the categories of a store in aisle order
+func (s *MySQL) storeLayout(ctx context.Context, storeID int64) ([]api.Category, error) {
+	categories := []api.Category{}
+	rows, err := s.ext.QueryxContext(ctx, "select "+categoryColumns+" from store_category sc "+
+		"join category c on c.id=sc.category where sc.store=? order by sc.position", storeID)
+	if err != nil {
+		return categories, errors.Wrapf(err, "failed to read layout of store:%v", storeID)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return categories, errors.Wrap(err, "failed to read category from DB")
+		}
+		categories = append(categories, category)
+	}
+	return categories, rows.Err()
+}
+
+func (s *MySQL) GetStore(ctx context.Context, storeID int64) (api.Store, error) {
+	var shop api.Store
+	err := s.ext.QueryRowxContext(ctx, "select id, name, owner, created_at from store where id=?", storeID).
+		Scan(&shop.ID, &shop.Name, &shop.OwnerID, &shop.CreatedAt)
+	if err != nil {
+		return shop, notFound(err, "failed to read store from DB")
+	}
+	shop.Categories, err = s.storeLayout(ctx, storeID)
+	return shop, err
+}
+
+func (s *MySQL) GetUserStores(ctx context.Context, userID int64) ([]api.Store, error) {
+	var stores []api.Store
+	rows, err := s.ext.QueryxContext(ctx, "select id, name, owner, created_at from store where owner=? order by id", userID)
+	if err != nil {
+		return stores, errors.Wrap(err, "failed to read stores for given user")
+	}
+	for rows.Next() {
+		var shop api.Store
+		err := rows.Scan(&shop.ID, &shop.Name, &shop.OwnerID, &shop.CreatedAt)
+		if err != nil {
+			rows.Close()
+			return stores, errors.Wrap(err, "failed to read store from DB")
+		}
+		stores = append(stores, shop)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return stores, errors.Wrap(err, "failed to read stores for given user")
+	}
+	// the layouts are read once the rows are closed, a transaction runs one query at a time
+	for i := range stores {
+		stores[i].Categories, err = s.storeLayout(ctx, stores[i].ID)
+		if err != nil {
+			return stores, err
+		}
+	}
+	return stores, nil
+}
+
+func (s *MySQL) UpdateStore(ctx context.Context, shop *api.Store) error {
+	_, err := s.ext.ExecContext(ctx, "update store set name=? where id=?", shop.Name, shop.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update store:%v in DB", shop.ID)
+	}
+	return nil
+}
+
+func (s *MySQL) SetStoreLayout(ctx context.Context, storeID int64, categoryIDs []int64) error {
+	_, err := s.ext.ExecContext(ctx, "delete from store_category where store=?", storeID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to clear layout of store:%v", storeID)
+	}
+	for position, categoryID := range categoryIDs {
+		_, err = s.ext.ExecContext(ctx, "insert into store_category (store, category, position) values (?,?,?)",
+			storeID, categoryID, position+1)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add category:%v to layout of store:%v", categoryID, storeID)
+		}
+	}
+	return nil
+}
+
+func (s *MySQL) DeleteStore(ctx context.Context, storeID int64) error {
+	_, err := s.ext.ExecContext(ctx, "delete from store where id=?", storeID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete store:%v from DB", storeID)
+	}
+	return nil
+}
+
+const recurrenceColumns = "id, owner, source_list, name, frequency, weekday, day, next_run_at, paused, created_at"
+
+func scanRecurrence(row scanner) (api.Recurrence, error) {
+	var (
+		recurrence api.Recurrence
+		name       sql.NullString
+		weekday    sql.NullString
+		day        sql.NullInt64
+	)
+	err := row.Scan(&recurrence.ID, &recurrence.OwnerID, &recurrence.SourceListID, &name, &recurrence.Frequency,
+		&weekday, &day, &recurrence.NextRunAt, &recurrence.Paused, &recurrence.CreatedAt)
+	recurrence.Name = name.String
+	recurrence.Weekday = weekday.String
+	recurrence.Day = int(day.Int64)
+	return recurrence, err
+}
+
+func (s *MySQL) CreateRecurrence(ctx context.Context, recurrence *api.Recurrence) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into recurrence (owner, source_list, name, frequency, weekday, day, "+
+		"next_run_at, paused, created_at) values (?,?,?,?,?,?,?,?,?)",
+		recurrence.OwnerID, recurrence.SourceListID, nullString(recurrence.Name), recurrence.Frequency,
+		nullString(recurrence.Weekday), nullInt64(int64(recurrence.Day)), recurrence.NextRunAt, recurrence.Paused,
+		recurrence.CreatedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert new recurrence in DB")
+	}
+	recurrence.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of created recurrence")
+	}
+	return nil
+}
+
+func (s *MySQL) GetRecurrence(ctx context.Context, recurrenceID int64) (api.Recurrence, error) {
+	recurrence, err := scanRecurrence(s.ext.QueryRowxContext(ctx,
+		"select "+recurrenceColumns+" from recurrence where id=?", recurrenceID))
+	if err != nil {
+		return recurrence, notFound(err, "failed to read recurrence from DB")
+	}
+	return recurrence, nil
+}
+
+// queryRecurrences reads the recurrences selected by a query for recurrenceColumns
+func (s *MySQL) queryRecurrences(ctx context.Context, query string, args ...interface{}) ([]api.Recurrence, error) {
+	var recurrences []api.Recurrence
+	rows, err := s.ext.QueryxContext(ctx, "select "+recurrenceColumns+" from recurrence "+query, args...)
+	if err != nil {
+		return recurrences, errors.Wrap(err, "failed to query DB for recurrences")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		recurrence, err := scanRecurrence(rows)
+		if err != nil {
+			return recurrences, errors.Wrap(err, "failed to read recurrence from DB")
+		}
+		recurrences = append(recurrences, recurrence)
+	}
+	return recurrences, rows.Err()
+}
+
+func (s *MySQL) GetUserRecurrences(ctx context.Context, userID int64) ([]api.Recurrence, error) {
+	return s.queryRecurrences(ctx, "where owner=? order by id", userID)
+}
+
+func (s *MySQL) GetDueRecurrences(ctx context.Context, by time.Time) ([]api.Recurrence, error) {
+	return s.queryRecurrences(ctx, "where paused=0 and next_run_at<=? order by id", by)
+}
+
+func (s *MySQL) UpdateRecurrence(ctx context.Context, recurrence *api.Recurrence) error {
+	_, err := s.ext.ExecContext(ctx, "update recurrence set next_run_at=?, paused=? where id=?",
+		recurrence.NextRunAt, recurrence.Paused, recurrence.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update recurrence:%v in DB", recurrence.ID)
+	}
+	return nil
+}
+
+func (s *MySQL) DeleteRecurrence(ctx context.Context, recurrenceID int64) error {
+	_, err := s.ext.ExecContext(ctx, "delete from recurrence where id=?", recurrenceID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete recurrence:%v from DB", recurrenceID)
+	}
+	return nil
+}
+
+func (s *MySQL) AddRecurrenceInstance(ctx context.Context, instance *api.RecurrenceInstance) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into recurrence_instance (recurrence, list, scheduled_for, created_at) "+
+		"values (?,?,?,?)", instance.RecurrenceID, instance.ListID, instance.ScheduledFor, instance.CreatedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert new recurrence instance in DB")
+	}
+	instance.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of created recurrence instance")
+	}
+	return nil
+}
+
+func (s *MySQL) GetRecurrenceInstances(ctx context.Context, recurrenceID int64) ([]api.RecurrenceInstance, error) {
+	var instances []api.RecurrenceInstance
+	rows, err := s.ext.QueryxContext(ctx, "select id, recurrence, list, scheduled_for, created_at "+
+		"from recurrence_instance where recurrence=? order by id desc", recurrenceID)
+	if err != nil {
+		return instances, errors.Wrapf(err, "failed to read instances of recurrence:%v", recurrenceID)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			instance api.RecurrenceInstance
+			listID   sql.NullInt64
+		)
+		err := rows.Scan(&instance.ID, &instance.RecurrenceID, &listID, &instance.ScheduledFor, &instance.CreatedAt)
+		if err != nil {
+			return instances, errors.Wrap(err, "failed to read recurrence instance from DB")
+		}
+		instance.ListID = listID.Int64
+		instances = append(instances, instance)
+	}
+	return instances, rows.Err()
+}
+
+func (s *MySQL) GetListsDueBetween(ctx context.Context, from time.Time, to time.Time) ([]api.List, error) {
+	var lists []api.List
+	rows, err := s.ext.QueryxContext(ctx, "select "+listColumns+" from list l join users u on u.id=l.owner "+
+		"where l.status=? and l.is_template=0 and l.deadline>? and l.deadline<=? order by l.id", api.Todo, from, to)
+	if err != nil {
+		return lists, errors.Wrap(err, "failed to query DB for due lists")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		list, err := scanList(rows)
+		if err != nil {
+			return lists, errors.Wrap(err, "failed to read list from DB")
+		}
+		lists = append(lists, list)
+	}
+	return lists, rows.Err()
+}
+
+func (s *MySQL) GetItemsDueBetween(ctx context.Context, from time.Time, to time.Time) ([]api.Item, error) {
+	var items []api.Item
+	rows, err := s.ext.QueryxContext(ctx, "select "+itemColumns+" from "+itemTables+
+		" where i.status=? and i.deadline>? and i.deadline<=? order by i.id", api.Todo, from, to)
+	if err != nil {
+		return items, errors.Wrap(err, "failed to query DB for due items")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return items, errors.Wrap(err, "failed to read item from DB")
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (s *MySQL) GetReminderPreferences(ctx context.Context, userID int64) (ReminderPreferences, error) {
+	var (
+		preferences           ReminderPreferences
+		leadTime              int64
+		quietFrom, quietUntil sql.NullString
+	)
+	err := s.ext.QueryRowxContext(ctx, "select user, lead_time, quiet_from, quiet_until, time_zone, channel "+
+		"from reminder_preference where user=?", userID).
+		Scan(&preferences.UserID, &leadTime, &quietFrom, &quietUntil, &preferences.TimeZone, &preferences.Channel)
+	if err != nil {
+		return preferences, notFound(err, "failed to read reminder preferences from DB")
+	}
+	// the lead time is kept in seconds
+	preferences.LeadTime = time.Duration(leadTime) * time.Second
+	preferences.QuietFrom = quietFrom.String
+	preferences.QuietUntil = quietUntil.String
+	return preferences, nil
+}
+
+func (s *MySQL) SaveReminderPreferences(ctx context.Context, preferences *ReminderPreferences) error {
+	_, err := s.ext.ExecContext(ctx, "insert into reminder_preference (user, lead_time, quiet_from, quiet_until, time_zone, channel) "+
+		"values (?,?,?,?,?,?) on duplicate key update lead_time=values(lead_time), quiet_from=values(quiet_from), "+
+		"quiet_until=values(quiet_until), time_zone=values(time_zone), channel=values(channel)",
+		preferences.UserID, int64(preferences.LeadTime/time.Second), nullString(preferences.QuietFrom),
+		nullString(preferences.QuietUntil), preferences.TimeZone, preferences.Channel)
+	if err != nil {
+		return errors.Wrap(err, "failed to save reminder preferences in DB")
+	}
+	return nil
+}
+
+func (s *MySQL) AddReminder(ctx context.Context, reminder *Reminder) error {
+	resp, err := s.ext.ExecContext(ctx, "insert into reminder (user, list, item, deadline, sent_at) values (?,?,?,?,?)",
+		reminder.UserID, reminder.ListID, nullInt64(reminder.ItemID), reminder.Deadline, reminder.SentAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert reminder in DB")
+	}
+	reminder.ID, err = resp.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the id of created reminder")
+	}
+	return nil
+}
+
+func (s *MySQL) GetReminder(ctx context.Context, userID int64, listID int64, itemID int64, deadline time.Time) (Reminder, error) {
+	var (
+		reminder Reminder
+		item     sql.NullInt64
+	)
+	err := s.ext.QueryRowxContext(ctx, "select id, user, list, item, deadline, sent_at from reminder "+
+		"where user=? and list=? and item<=>? and deadline=?", userID, listID, nullInt64(itemID), deadline).
+		Scan(&reminder.ID, &reminder.UserID, &reminder.ListID, &item, &reminder.Deadline, &reminder.SentAt)
+	if err != nil {
+		return reminder, notFound(err, "failed to read reminder from DB")
+	}
+	reminder.ItemID = item.Int64
+	return reminder, nil
+}
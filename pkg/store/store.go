@@ -0,0 +1,348 @@
+package store
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"shoppinglist/pkg/api"
+	"time"
+)
+
+// ErrNotFound is returned by a Repository when the requested record does not exist
+var ErrNotFound = errors.New("record not found")
+
+// IsNotFound reports whether err was caused by ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Cause(err) == ErrNotFound
+}
+
+// User identifies a registered user as it is kept in storage
+type User struct {
+	ID             int64
+	UserName       string
+	FullName       string
+	Email          string
+	Password       string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	LastLoggedInAt time.Time
+	Status         string
+}
+
+// Contributor identifies the access a user has been granted on a list.
+// A zero ValidUntil grants the access without expiry.
+type Contributor struct {
+	ID         int64
+	ListID     int64
+	UserID     int64
+	AccessType string
+	ValidUntil time.Time
+}
+
+// Expired reports whether the access has lapsed at given time
+func (c Contributor) Expired(at time.Time) bool {
+	return !c.ValidUntil.IsZero() && !at.Before(c.ValidUntil)
+}
+
+// PublicLink gives whoever holds its token access to a list without an account,
+// a revoked link has RevokedAt set
+type PublicLink struct {
+	ID         int64
+	ListID     int64
+	AccessType string
+	CreatedBy  int64
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	RevokedAt  time.Time
+}
+
+// OwnershipTransfer records that the ownership of a list passed from one user to another
+type OwnershipTransfer struct {
+	ID            int64
+	ListID        int64
+	FromUserID    int64
+	ToUserID      int64
+	TransferredAt time.Time
+}
+
+// CategoryRule puts the items of a user whose title matches Pattern in a
+// category. Pattern is a keyword matched as a whole word unless Regexp is set.
+type CategoryRule struct {
+	ID         int64
+	UserID     int64
+	Pattern    string
+	Regexp     bool
+	CategoryID int64
+	CreatedAt  time.Time
+}
+
+// CategoryChoice records the category a user last picked for items of a title,
+// titles are kept in lower case
+type CategoryChoice struct {
+	UserID     int64
+	Title      string
+	CategoryID int64
+	ChosenAt   time.Time
+}
+
+// ReminderPreferences tell how long before a deadline a user is reminded and
+// over which channel. Reminders wait out the quiet hours from QuietFrom to
+// QuietUntil, given as 15:04 in TimeZone, there are none when both are empty.
+type ReminderPreferences struct {
+	UserID     int64
+	LeadTime   time.Duration
+	QuietFrom  string
+	QuietUntil string
+	TimeZone   string
+	Channel    string
+}
+
+// Reminder records that a user was reminded of a list, or of an item of it
+// when ItemID is set, being due at Deadline
+type Reminder struct {
+	ID       int64
+	UserID   int64
+	ListID   int64
+	ItemID   int64
+	Deadline time.Time
+	SentAt   time.Time
+}
+
+// Invite is an invitation to a list for whoever holds its token
+type Invite struct {
+	ID         int64
+	ListID     int64
+	AccessType string
+	Status     string
+	CreatedBy  int64
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	AcceptedBy int64
+	AcceptedAt time.Time
+}
+
+// Group is a set of users, such as a household, lists can be shared with as a unit
+type Group struct {
+	ID        int64
+	Name      string
+	CreatedBy int64
+	CreatedAt time.Time
+}
+
+// GroupMember identifies the role of a user in a group
+type GroupMember struct {
+	ID      int64
+	GroupID int64
+	UserID  int64
+	Role    string
+}
+
+// ListGroup identifies the access the members of a group have been granted on a list
+type ListGroup struct {
+	ID         int64
+	ListID     int64
+	GroupID    int64
+	AccessType string
+}
+
+// Repository is the storage backend used by the shopping list service.
+// Every method returns ErrNotFound (possibly wrapped) when the record it
+// looks up does not exist.
+type Repository interface {
+	Users
+	Lists
+	Contributors
+	OwnershipTransfers
+	Groups
+	Invites
+	PublicLinks
+	Items
+	Categories
+	CategoryRules
+	Stores
+	Recurrences
+	Reminders
+
+	// Tx runs fn against a repository bound to a single transaction.
+	// The transaction is committed if fn returns nil and rolled back otherwise.
+	Tx(ctx context.Context, fn func(Repository) error) error
+}
+
+// Users stores registered users
+type Users interface {
+	CreateUser(ctx context.Context, user *User) error
+	GetUserByID(ctx context.Context, userID int64) (User, error)
+	GetUserByName(ctx context.Context, userName string) (User, error)
+	UpdateLastLoggedIn(ctx context.Context, userID int64, at time.Time) error
+}
+
+// Lists stores shopping lists
+type Lists interface {
+	CreateList(ctx context.Context, list *api.List) error
+	GetList(ctx context.Context, listID int64) (api.List, error)
+	// GetListsForUser returns every list the user holds an unexpired grant on,
+	// directly or through a group, with AccessType set to the strongest access
+	// the user has on that list
+	GetListsForUser(ctx context.Context, userID int64) ([]api.List, error)
+	UpdateList(ctx context.Context, list *api.List) error
+	// PurgeDeletedLists removes the lists deleted before given time, together
+	// with their items, contributors, group grants and invites, and returns how
+	// many lists it removed
+	PurgeDeletedLists(ctx context.Context, before time.Time) (int64, error)
+	// GetListsDueBetween returns the lists still to be bought, templates aside,
+	// whose deadline is after from and not after to
+	GetListsDueBetween(ctx context.Context, from time.Time, to time.Time) ([]api.List, error)
+}
+
+// Contributors stores the users a list is shared with
+type Contributors interface {
+	AddContributor(ctx context.Context, contributor *Contributor) error
+	GetContributor(ctx context.Context, listID int64, userID int64) (Contributor, error)
+	GetListContributors(ctx context.Context, listID int64) ([]Contributor, error)
+	// UpdateContributor changes the access type and validity of a contributor
+	UpdateContributor(ctx context.Context, contributor *Contributor) error
+	RemoveContributor(ctx context.Context, contributorID int64) error
+}
+
+// OwnershipTransfers stores the history of the owners of lists
+type OwnershipTransfers interface {
+	CreateOwnershipTransfer(ctx context.Context, transfer *OwnershipTransfer) error
+	// GetListOwnershipTransfers returns the transfers of a list, oldest first
+	GetListOwnershipTransfers(ctx context.Context, listID int64) ([]OwnershipTransfer, error)
+}
+
+// Groups stores groups, their members and the lists shared with them
+type Groups interface {
+	CreateGroup(ctx context.Context, group *Group) error
+	GetGroup(ctx context.Context, groupID int64) (Group, error)
+	AddGroupMember(ctx context.Context, member *GroupMember) error
+	GetGroupMember(ctx context.Context, groupID int64, userID int64) (GroupMember, error)
+	GetGroupMembers(ctx context.Context, groupID int64) ([]GroupMember, error)
+	// GetUserGroups returns the memberships of given user
+	GetUserGroups(ctx context.Context, userID int64) ([]GroupMember, error)
+	UpdateGroupMember(ctx context.Context, member *GroupMember) error
+	RemoveGroupMember(ctx context.Context, memberID int64) error
+
+	AddListGroup(ctx context.Context, listGroup *ListGroup) error
+	GetListGroup(ctx context.Context, listID int64, groupID int64) (ListGroup, error)
+	GetListGroups(ctx context.Context, listID int64) ([]ListGroup, error)
+	UpdateListGroup(ctx context.Context, listGroup *ListGroup) error
+	RemoveListGroup(ctx context.Context, listGroupID int64) error
+	// GetGroupAccess returns the strongest access given user has on a list
+	// through the groups they are a member of
+	GetGroupAccess(ctx context.Context, listID int64, userID int64) (string, error)
+}
+
+// PublicLinks stores the public links to lists
+type PublicLinks interface {
+	CreatePublicLink(ctx context.Context, link *PublicLink) error
+	GetPublicLink(ctx context.Context, linkID int64) (PublicLink, error)
+	GetListPublicLinks(ctx context.Context, listID int64) ([]PublicLink, error)
+	// UpdatePublicLink changes when a link was revoked
+	UpdatePublicLink(ctx context.Context, link *PublicLink) error
+}
+
+// Invites stores the invitations to lists
+type Invites interface {
+	CreateInvite(ctx context.Context, invite *Invite) error
+	GetInvite(ctx context.Context, inviteID int64) (Invite, error)
+	GetListInvites(ctx context.Context, listID int64) ([]Invite, error)
+	// UpdateInvite changes the status of an invite and who accepted it
+	UpdateInvite(ctx context.Context, invite *Invite) error
+}
+
+// Items stores the items of shopping lists
+type Items interface {
+	CreateItem(ctx context.Context, item *api.Item) error
+	// GetItem returns an item, read within Tx the item stays locked until the
+	// transaction ends
+	GetItem(ctx context.Context, itemID int64) (api.Item, error)
+	// GetListItems returns the items of a list by position
+	GetListItems(ctx context.Context, listID int64) ([]api.Item, error)
+	UpdateItem(ctx context.Context, item *api.Item) error
+	// PurgeDeletedItems removes the items deleted before given time and returns
+	// how many items it removed
+	PurgeDeletedItems(ctx context.Context, before time.Time) (int64, error)
+	// GetItemsDueBetween returns the items still to be bought whose deadline is
+	// after from and not after to, whatever the state of their list
+	GetItemsDueBetween(ctx context.Context, from time.Time, to time.Time) ([]api.Item, error)
+}
+
+// Categories stores item categories
+type Categories interface {
+	CreateCategory(ctx context.Context, category *api.Category) error
+	GetCategory(ctx context.Context, categoryID int64) (api.Category, error)
+	// GetVisibleCategories returns the system categories and the categories
+	// of the user and of the groups they are a member of
+	GetVisibleCategories(ctx context.Context, userID int64) ([]api.Category, error)
+	UpdateCategory(ctx context.Context, category *api.Category) error
+	DeleteCategory(ctx context.Context, categoryID int64) error
+	// CountCategoryItems counts the items of a category, deleted ones included
+	CountCategoryItems(ctx context.Context, categoryID int64) (int64, error)
+	// MoveCategoryItems puts the items of category fromID in category toID
+	MoveCategoryItems(ctx context.Context, fromID int64, toID int64) (int64, error)
+}
+
+// CategoryRules stores the categorisation rules of users and the categories
+// they picked in the past. Deleting a category deletes its rules and choices.
+type CategoryRules interface {
+	CreateCategoryRule(ctx context.Context, rule *CategoryRule) error
+	GetCategoryRule(ctx context.Context, ruleID int64) (CategoryRule, error)
+	// GetUserCategoryRules returns the rules of a user, newest first
+	GetUserCategoryRules(ctx context.Context, userID int64) ([]CategoryRule, error)
+	DeleteCategoryRule(ctx context.Context, ruleID int64) error
+	// SaveCategoryChoice records a choice, replacing the earlier one of the user for the title
+	SaveCategoryChoice(ctx context.Context, choice *CategoryChoice) error
+	GetCategoryChoice(ctx context.Context, userID int64, title string) (CategoryChoice, error)
+	// MoveCategoryRules points the rules and choices of category fromID to category toID
+	MoveCategoryRules(ctx context.Context, fromID int64, toID int64) error
+}
+
+// Stores stores the shops of users and the order of categories in their aisles.
+// A store is read with its categories in aisle order, deleting a category takes
+// it out of the layouts and deleting a store unlinks its lists.
+type Stores interface {
+	CreateStore(ctx context.Context, shop *api.Store) error
+	GetStore(ctx context.Context, storeID int64) (api.Store, error)
+	GetUserStores(ctx context.Context, userID int64) ([]api.Store, error)
+	// UpdateStore changes the name of a store
+	UpdateStore(ctx context.Context, shop *api.Store) error
+	// SetStoreLayout replaces the categories of a store with given ones in aisle order
+	SetStoreLayout(ctx context.Context, storeID int64, categoryIDs []int64) error
+	DeleteStore(ctx context.Context, storeID int64) error
+}
+
+// Recurrences stores the recurring lists of users and the lists they started.
+// Purging the source list of a recurrence deletes the recurrence, purging a
+// list it started leaves the record of the instance without a list.
+type Recurrences interface {
+	CreateRecurrence(ctx context.Context, recurrence *api.Recurrence) error
+	GetRecurrence(ctx context.Context, recurrenceID int64) (api.Recurrence, error)
+	GetUserRecurrences(ctx context.Context, userID int64) ([]api.Recurrence, error)
+	// GetDueRecurrences returns the recurrences that are not paused and were to run by given time
+	GetDueRecurrences(ctx context.Context, by time.Time) ([]api.Recurrence, error)
+	// UpdateRecurrence changes when a recurrence runs next and whether it is paused
+	UpdateRecurrence(ctx context.Context, recurrence *api.Recurrence) error
+	// DeleteRecurrence removes a recurrence together with the record of its instances
+	DeleteRecurrence(ctx context.Context, recurrenceID int64) error
+	AddRecurrenceInstance(ctx context.Context, instance *api.RecurrenceInstance) error
+	// GetRecurrenceInstances returns the instances of a recurrence, the latest first
+	GetRecurrenceInstances(ctx context.Context, recurrenceID int64) ([]api.RecurrenceInstance, error)
+}
+
+// Reminders stores the reminder preferences of users and the reminders they
+// were sent. Purging a list or an item deletes the reminders sent for it.
+type Reminders interface {
+	GetReminderPreferences(ctx context.Context, userID int64) (ReminderPreferences, error)
+	// SaveReminderPreferences adds or replaces the preferences of a user
+	SaveReminderPreferences(ctx context.Context, preferences *ReminderPreferences) error
+	AddReminder(ctx context.Context, reminder *Reminder) error
+	// GetReminder returns the reminder user was sent of a list, or of one of
+	// its items when itemID is not 0, being due at deadline
+	GetReminder(ctx context.Context, userID int64, listID int64, itemID int64, deadline time.Time) (Reminder, error)
+}
+
+// compile time assertions for our repositories implementing Repository.
+var (
+	_ Repository = (*MySQL)(nil)
+	_ Repository = (*Memory)(nil)
+)
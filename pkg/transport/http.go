@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"shoppinglist/pkg/api"
 	"shoppinglist/pkg/endpoint"
+	"shoppinglist/pkg/session"
 	"strconv"
 	"strings"
 	"time"
@@ -92,6 +93,11 @@ const (
 	//   required: true
 	//   schema:
 	//     "$ref": "#/definitions/LogoutRequest"
+	// - name: all
+	//   in: query
+	//   description: end every session of the user when true
+	//   required: false
+	//   type: boolean
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/LogoutResponse"
@@ -147,6 +153,33 @@ const (
 	//     "$ref": "#/responses/ServiceError"
 	GetListsURL = "/list"
 
+	// swagger:operation PATCH /list/{lid} UpdateListRequest
+	//
+	// Updates name, description, deadline or status of given list
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: id of the list to update
+	//   required: true
+	// - name: UpdateListRequest
+	//   in: body
+	//   description: request parameters for update list
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/UpdateListRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UpdateListResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	UpdateListURL = "/list/{lid}"
+
 	// swagger:operation POST /item CreateItemRequest
 	//
 	// Creates an item in given shopping list
@@ -193,6 +226,33 @@ const (
 	//     "$ref": "#/responses/ServiceError"
 	GetListItemsURL = "/item"
 
+	// swagger:operation PATCH /item/{iid} UpdateItemRequest
+	//
+	// Updates title, description, category or deadline of given item
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: iid
+	//   in: path
+	//   description: id of the item to update
+	//   required: true
+	// - name: UpdateItemRequest
+	//   in: body
+	//   description: request parameters for update item
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/UpdateItemRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UpdateItemResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	UpdateItemURL = "/item/{iid}"
+
 	// swagger:operation POST /buy BuyItemRequest
 	//
 	// Mark an item as bought by given user
@@ -305,171 +365,2767 @@ const (
 	//   "500":
 	//     "$ref": "#/responses/ServiceError"
 	DeleteItemURL = "/delete/item/{iid}"
-)
-
-func commonHTTPMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Add("Content-Type", "application/json")
-		next.ServeHTTP(w, r)
-	})
-}
-
-// NewHTTPHandler returns an HTTP handler that makes a set of endpoints
-// available on predefined paths.
-func NewHTTPHandler(endpoints endpoint.Endpoints, logger log.Logger) http.Handler {
 
-	r := mux.NewRouter()
-	r.Use(commonHTTPMiddleware)
+	// swagger:operation POST /unbuy/item/{iid} UnbuyItemRequest
+	//
+	// Mark given bought item as todo again
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: iid
+	//   in: path
+	//   description: item to mark as not bought
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UnbuyItemResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	UnbuyItemURL = "/unbuy/item/{iid}"
 
-	r.Methods("GET").Path(PingURL).Handler(httptransport.NewServer(
-		endpoints.Ping,
-		decodeHTTPPingRequest,
-		encodeResponse,
-	))
+	// swagger:operation POST /restore/item/{iid} RestoreItemRequest
+	//
+	// Mark given deleted item as todo again
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: iid
+	//   in: path
+	//   description: item to restore
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RestoreItemResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	RestoreItemURL = "/restore/item/{iid}"
 
-	r.Methods("POST").Path(SignupURL).Handler(httptransport.NewServer(
-		endpoints.Signup,
-		decodeHTTPSignupRequest,
-		encodeResponse,
-	))
+	// swagger:operation GET /trash GetTrashRequest
+	//
+	// Returns the deleted lists and items logged in user can restore
+	//
+	// ---
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/GetTrashResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	TrashURL = "/trash"
 
-	r.Methods("GET").Path(LoginURL).Handler(httptransport.NewServer(
-		endpoints.Login,
-		decodeHTTPLoginRequest,
-		encodeResponse,
-	))
+	// swagger:operation POST /restore/list/{lid} RestoreListRequest
+	//
+	// Mark given deleted list as todo again
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list to restore
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RestoreListResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	RestoreListURL = "/restore/list/{lid}"
 
-	r.Methods("POST").Path(LogoutURL).Handler(httptransport.NewServer(
-		endpoints.Logout,
-		decodeHTTPLogoutRequest,
-		encodeResponse,
-	))
+	// swagger:operation GET /list/{lid}/contributors GetContributorsRequest
+	//
+	// Returns the users given list is shared with, only available to its managers and owner
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list to read the contributors of
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/GetContributorsResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	ContributorsURL = "/list/{lid}/contributors"
 
-	r.Methods("POST").Path(CreateListURL).Handler(httptransport.NewServer(
-		endpoints.CreateList,
-		decodeHTTPCreateListRequest,
-		encodeResponse,
-	))
+	// swagger:operation PATCH /list/{lid}/contributors/{uid} UpdateContributorRequest
+	//
+	// Changes the access type of a user given list is shared with
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list shared with the user
+	//   required: true
+	// - name: uid
+	//   in: path
+	//   description: id of the user
+	//   required: true
+	// - name: UpdateContributorRequest
+	//   in: body
+	//   description: new access type of the user
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/UpdateContributorRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UpdateContributorResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	UpdateContributorURL = "/list/{lid}/contributors/{uid}"
 
-	r.Methods("GET").Path(GetListsURL).Handler(httptransport.NewServer(
-		endpoints.GetLists,
-		decodeHTTPGetListsRequest,
-		encodeResponse,
-	))
+	// swagger:operation DELETE /list/{lid}/contributors/{uid} RevokeContributorRequest
+	//
+	// Revokes the access of a user given list is shared with
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list shared with the user
+	//   required: true
+	// - name: uid
+	//   in: path
+	//   description: id of the user
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RevokeContributorResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	RevokeContributorURL = "/list/{lid}/contributors/{uid}"
 
-	r.Methods("POST").Path(CreateItemURL).Handler(httptransport.NewServer(
-		endpoints.CreateItem,
-		decodeHTTPCreateItemRequest,
-		encodeResponse,
-	))
+	// swagger:operation POST /list/{lid}/invites CreateInviteRequest
+	//
+	// Creates a single use invitation to given list, only available to its managers and owner
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list to invite to
+	//   required: true
+	// - name: CreateInviteRequest
+	//   in: body
+	//   description: access type and expiry of the invitation
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CreateInviteRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/CreateInviteResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	CreateInviteURL = "/list/{lid}/invites"
 
-	r.Methods("GET").Path(GetListItemsURL).Handler(httptransport.NewServer(
-		endpoints.GetListItems,
-		decodeHTTPGetListItemsRequest,
-		encodeResponse,
-	))
+	// swagger:operation GET /list/{lid}/invites GetInvitesRequest
+	//
+	// Returns the pending invitations to given list, only available to its managers and owner
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list to read the invitations of
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/GetInvitesResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	GetInvitesURL = "/list/{lid}/invites"
 
-	r.Methods("POST").Path(BuyItemURL).Handler(httptransport.NewServer(
-		endpoints.BuyItem,
-		decodeHTTPBuyItemRequest,
-		encodeResponse,
-	))
+	// swagger:operation DELETE /list/{lid}/invites/{inv} CancelInviteRequest
+	//
+	// Cancels a pending invitation to given list
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list of the invitation
+	//   required: true
+	// - name: inv
+	//   in: path
+	//   description: id of the invitation
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CancelInviteResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	CancelInviteURL = "/list/{lid}/invites/{inv}"
 
-	r.Methods("POST").Path(ShareListURL).Handler(httptransport.NewServer(
-		endpoints.ShareList,
+	// swagger:operation POST /invite/accept AcceptInviteRequest
+	//
+	// Grants the logged in user the access given invitation was created for
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: AcceptInviteRequest
+	//   in: body
+	//   description: token of the invitation
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/AcceptInviteRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/AcceptInviteResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	AcceptInviteURL = "/invite/accept"
+
+	// swagger:operation POST /transfer/list/{lid} TransferOwnershipRequest
+	//
+	// Makes a contributor with at least the editor role its owner, the old owner stays an editor
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list to hand over
+	//   required: true
+	// - name: TransferOwnershipRequest
+	//   in: body
+	//   description: username of the new owner
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/TransferOwnershipRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TransferOwnershipResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	TransferOwnershipURL = "/transfer/list/{lid}"
+
+	// swagger:operation POST /group CreateGroupRequest
+	//
+	// Creates a group administered by logged in user
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: CreateGroupRequest
+	//   in: body
+	//   description: name of the group
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CreateGroupRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/CreateGroupResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	CreateGroupURL = "/group"
+
+	// swagger:operation GET /group GetGroupsRequest
+	//
+	// Returns the groups logged in user is a member of
+	//
+	// ---
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/GetGroupsResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	GetGroupsURL = "/group"
+
+	// swagger:operation GET /group/{gid}/members GetGroupMembersRequest
+	//
+	// Returns the members of given group
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: gid
+	//   in: path
+	//   description: group to read the members of
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/GetGroupMembersResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	GetGroupMembersURL = "/group/{gid}/members"
+
+	// swagger:operation POST /group/{gid}/members AddGroupMemberRequest
+	//
+	// Adds a user to given group or changes their role
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: gid
+	//   in: path
+	//   description: group to add the user to
+	//   required: true
+	// - name: AddGroupMemberRequest
+	//   in: body
+	//   description: username and role of the member
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/AddGroupMemberRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AddGroupMemberResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	AddGroupMemberURL = "/group/{gid}/members"
+
+	// swagger:operation DELETE /group/{gid}/members/{uid} RemoveGroupMemberRequest
+	//
+	// Removes a user from given group, members can remove themselves
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: gid
+	//   in: path
+	//   description: group to remove the user from
+	//   required: true
+	// - name: uid
+	//   in: path
+	//   description: id of the user
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RemoveGroupMemberResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	RemoveGroupMemberURL = "/group/{gid}/members/{uid}"
+
+	// swagger:operation POST /share/group ShareListWithGroupRequest
+	//
+	// Shares a list with every member of a group
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: ShareListWithGroupRequest
+	//   in: body
+	//   description: request Parameters for sharing list with a group
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ShareListWithGroupRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ShareListWithGroupResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	ShareListWithGroupURL = "/share/group"
+
+	// swagger:operation DELETE /list/{lid}/groups/{gid} UnshareListWithGroupRequest
+	//
+	// Stops sharing given list with a group
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list shared with the group
+	//   required: true
+	// - name: gid
+	//   in: path
+	//   description: id of the group
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UnshareListWithGroupResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	UnshareListWithGroupURL = "/list/{lid}/groups/{gid}"
+
+	// swagger:operation POST /list/{lid}/links CreatePublicLinkRequest
+	//
+	// Creates a public link to given list for people without an account, only available to the list owner
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list to publish
+	//   required: true
+	// - name: CreatePublicLinkRequest
+	//   in: body
+	//   description: access type and expiry of the link
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CreatePublicLinkRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/CreatePublicLinkResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	CreatePublicLinkURL = "/list/{lid}/links"
+
+	// swagger:operation GET /list/{lid}/links GetPublicLinksRequest
+	//
+	// Returns the public links to given list that still work, only available to the list owner
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list to read the public links of
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/GetPublicLinksResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	GetPublicLinksURL = "/list/{lid}/links"
+
+	// swagger:operation DELETE /list/{lid}/links/{link} RevokePublicLinkRequest
+	//
+	// Revokes a public link to given list, only available to the list owner
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list the link belongs to
+	//   required: true
+	// - name: link
+	//   in: path
+	//   description: link to revoke
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RevokePublicLinkResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	RevokePublicLinkURL = "/list/{lid}/links/{link}"
+
+	// swagger:operation GET /public/{token} GetPublicListRequest
+	//
+	// Returns a list and its items to whoever holds a public link to it, no login is needed
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: token
+	//   in: path
+	//   description: token of the public link
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/GetPublicListResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	GetPublicListURL = "/public/{token}"
+
+	// swagger:operation POST /public/{token}/buy/{iid} BuyPublicItemRequest
+	//
+	// Marks an item bought through a public link with the shopper access type, no login is needed
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: token
+	//   in: path
+	//   description: token of the public link
+	//   required: true
+	// - name: iid
+	//   in: path
+	//   description: item to mark as bought
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/BuyPublicItemResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	BuyPublicItemURL = "/public/{token}/buy/{iid}"
+
+	// swagger:operation POST /categories CreateCategoryRequest
+	//
+	// Registers an item category, names are unique regardless of their case
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: CreateCategoryRequest
+	//   in: body
+	//   description: name and type of the category
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CreateCategoryRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/CreateCategoryResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	CreateCategoryURL = "/categories"
+
+	// swagger:operation PATCH /categories/{cid} UpdateCategoryRequest
+	//
+	// Renames a category or changes its type
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cid
+	//   in: path
+	//   description: category to update
+	//   required: true
+	// - name: UpdateCategoryRequest
+	//   in: body
+	//   description: new name or type of the category
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/UpdateCategoryRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/UpdateCategoryResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	UpdateCategoryURL = "/categories/{cid}"
+
+	// swagger:operation DELETE /categories/{cid} DeleteCategoryRequest
+	//
+	// Deletes a category no item belongs to
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cid
+	//   in: path
+	//   description: category to delete
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/DeleteCategoryResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	DeleteCategoryURL = "/categories/{cid}"
+
+	// swagger:operation POST /categories/{cid}/merge MergeCategoriesRequest
+	//
+	// Moves the items of duplicate categories to given category and deletes the duplicates
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cid
+	//   in: path
+	//   description: category to keep
+	//   required: true
+	// - name: MergeCategoriesRequest
+	//   in: body
+	//   description: categories to merge into the kept one
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/MergeCategoriesRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/MergeCategoriesResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	MergeCategoriesURL = "/categories/{cid}/merge"
+
+	// swagger:operation POST /categories/rules CreateCategoryRuleRequest
+	//
+	// Adds a rule putting items whose title matches a keyword or regular expression in a category
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: CreateCategoryRuleRequest
+	//   in: body
+	//   description: pattern of the rule and category it puts items in
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CreateCategoryRuleRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/CreateCategoryRuleResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	CreateCategoryRuleURL = "/categories/rules"
+
+	// swagger:operation GET /categories/rules GetCategoryRulesRequest
+	//
+	// Returns the rules categorising the items of the user followed by the system rules
+	//
+	// ---
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/GetCategoryRulesResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	GetCategoryRulesURL = "/categories/rules"
+
+	// swagger:operation DELETE /categories/rules/{rule} DeleteCategoryRuleRequest
+	//
+	// Deletes a categorisation rule of the user
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: rule
+	//   in: path
+	//   description: rule to delete
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/DeleteCategoryRuleResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	DeleteCategoryRuleURL = "/categories/rules/{rule}"
+
+	// swagger:operation POST /stores CreateStoreRequest
+	//
+	// Adds a store of the user with its categories in the order its aisles are walked
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: CreateStoreRequest
+	//   in: body
+	//   description: name of the store and its categories in aisle order
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CreateStoreRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/CreateStoreResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	CreateStoreURL = "/stores"
+
+	// swagger:operation GET /stores GetStoresRequest
+	//
+	// Returns the stores of the user with their aisle layout
+	//
+	// ---
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/GetStoresResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	GetStoresURL = "/stores"
+
+	// swagger:operation PATCH /stores/{sid} UpdateStoreRequest
+	//
+	// Renames a store of the user or lays out its aisles again
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: sid
+	//   in: path
+	//   description: store to update
+	//   required: true
+	// - name: UpdateStoreRequest
+	//   in: body
+	//   description: new name and aisle layout of the store
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/UpdateStoreRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/UpdateStoreResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	UpdateStoreURL = "/stores/{sid}"
+
+	// swagger:operation DELETE /stores/{sid} DeleteStoreRequest
+	//
+	// Deletes a store of the user, its lists are unlinked from it
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: sid
+	//   in: path
+	//   description: store to delete
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/DeleteStoreResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	DeleteStoreURL = "/stores/{sid}"
+
+	// swagger:operation POST /list/{lid}/reorder ReorderItemsRequest
+	//
+	// Changes the order of the items of a list, either entirely or by moving one item before or after another
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list to reorder the items of
+	//   required: true
+	// - name: ReorderItemsRequest
+	//   in: body
+	//   description: new order of the items or the item to move
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ReorderItemsRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/ReorderItemsResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	ReorderItemsURL = "/list/{lid}/reorder"
+
+	// swagger:operation POST /list/{lid}/items/move MoveItemsRequest
+	//
+	// Moves items of a list to the end of another list, the items keep their history
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list the items are on
+	//   required: true
+	// - name: MoveItemsRequest
+	//   in: body
+	//   description: items to move and the list to move them to
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/MoveItemsRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/MoveItemsResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	MoveItemsURL = "/list/{lid}/items/move"
+
+	// swagger:operation POST /list/{lid}/items/copy CopyItemsRequest
+	//
+	// Copies items of a list to the end of another list as new items to buy
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list the items are on
+	//   required: true
+	// - name: CopyItemsRequest
+	//   in: body
+	//   description: items to copy and the list to copy them to
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CopyItemsRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/CopyItemsResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	CopyItemsURL = "/list/{lid}/items/copy"
+
+	// swagger:operation POST /list/{lid}/clone CloneListRequest
+	//
+	// Copies a list with all its items still to be bought, the copy belongs to the user
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list to copy
+	//   required: true
+	// - name: CloneListRequest
+	//   in: body
+	//   description: name of the copy, the name of the list when empty
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CloneListRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/CloneListResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	CloneListURL = "/list/{lid}/clone"
+
+	// swagger:operation POST /list/{lid}/template CreateTemplateRequest
+	//
+	// Saves a list as a named template to start new lists from
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: list to save
+	//   required: true
+	// - name: CreateTemplateRequest
+	//   in: body
+	//   description: name of the template
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CreateTemplateRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/CreateTemplateResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	CreateTemplateURL = "/list/{lid}/template"
+
+	// swagger:operation GET /template GetTemplatesRequest
+	//
+	// Returns the templates of the user and the ones shared with them
+	//
+	// ---
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/GetTemplatesResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	GetTemplatesURL = "/template"
+
+	// swagger:operation POST /template/{lid}/instantiate InstantiateTemplateRequest
+	//
+	// Starts a new list of the user from a template
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: lid
+	//   in: path
+	//   description: template to start from
+	//   required: true
+	// - name: InstantiateTemplateRequest
+	//   in: body
+	//   description: name and deadline of the new list, the name of the template when empty
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/InstantiateTemplateRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/InstantiateTemplateResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	InstantiateTemplateURL = "/template/{lid}/instantiate"
+
+	// swagger:operation POST /recurrence CreateRecurrenceRequest
+	//
+	// Starts a fresh list from a list or template of the user weekly, every other week or monthly
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: CreateRecurrenceRequest
+	//   in: body
+	//   description: list to start from and when
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CreateRecurrenceRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/CreateRecurrenceResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	CreateRecurrenceURL = "/recurrence"
+
+	// swagger:operation GET /recurrence GetRecurrencesRequest
+	//
+	// Returns the recurring lists of the user
+	//
+	// ---
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/GetRecurrencesResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	GetRecurrencesURL = "/recurrence"
+
+	// swagger:operation POST /recurrence/{rid}/pause PauseRecurrenceRequest
+	//
+	// Stops a recurrence from starting lists until it is resumed
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: rid
+	//   in: path
+	//   description: recurrence to pause
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/PauseRecurrenceResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	PauseRecurrenceURL = "/recurrence/{rid}/pause"
+
+	// swagger:operation POST /recurrence/{rid}/resume ResumeRecurrenceRequest
+	//
+	// Resumes a paused recurrence, the runs it missed are skipped
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: rid
+	//   in: path
+	//   description: recurrence to resume
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/ResumeRecurrenceResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	ResumeRecurrenceURL = "/recurrence/{rid}/resume"
+
+	// swagger:operation DELETE /recurrence/{rid} DeleteRecurrenceRequest
+	//
+	// Deletes a recurrence of the user, the lists it started are kept
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: rid
+	//   in: path
+	//   description: recurrence to delete
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/DeleteRecurrenceResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	DeleteRecurrenceURL = "/recurrence/{rid}"
+
+	// swagger:operation GET /recurrence/{rid}/instances GetRecurrenceInstancesRequest
+	//
+	// Returns the lists a recurrence started, the latest first
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: rid
+	//   in: path
+	//   description: recurrence
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/GetRecurrenceInstancesResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	GetRecurrenceInstancesURL = "/recurrence/{rid}/instances"
+
+	// swagger:operation GET /item/due GetDueItemsRequest
+	//
+	// Returns the items still to be bought that are overdue or due soon on the lists the user can view
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: within
+	//   in: query
+	//   description: how far ahead an item counts as due soon, such as 48h, 24h by default
+	//   required: false
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/GetDueItemsResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	GetDueItemsURL = "/item/due"
+
+	// swagger:operation GET /reminders/preferences GetReminderPreferencesRequest
+	//
+	// Returns when and how the user is reminded of lists and items before they are due
+	//
+	// ---
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/GetReminderPreferencesResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	GetReminderPreferencesURL = "/reminders/preferences"
+
+	// swagger:operation PUT /reminders/preferences UpdateReminderPreferencesRequest
+	//
+	// Replaces the reminder preferences of the user, fields left out take their default
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: UpdateReminderPreferencesRequest
+	//   in: body
+	//   description: lead time, quiet hours, time zone and channel of reminders
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/UpdateReminderPreferencesRequest"
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/UpdateReminderPreferencesResponse"
+	//   "400":
+	//     "$ref": "#/responses/ServiceError"
+	//   "500":
+	//     "$ref": "#/responses/ServiceError"
+	UpdateReminderPreferencesURL = "/reminders/preferences"
+)
+
+func commonHTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		next.ServeHTTP(w, r)
+	})
+}
+
+type contextKey int
+
+const userContextKey contextKey = iota
+
+type userContextResult struct {
+	uc  api.UserContext
+	err error
+}
+
+// sessionToContext returns a ServerBefore hook that resolves the session cookie
+// of the request into the user context read by the request decoders.
+func sessionToContext(sessions session.Store) httptransport.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		uc, err := api.GetUserContextFromSession(ctx, r, sessions)
+		return context.WithValue(ctx, userContextKey, userContextResult{uc, err})
+	}
+}
+
+// userContextFromContext returns the user context resolved by sessionToContext
+func userContextFromContext(ctx context.Context) (api.UserContext, error) {
+	res, ok := ctx.Value(userContextKey).(userContextResult)
+	if !ok {
+		return api.UserContext{}, errors.New("unauthorised access")
+	}
+	return res.uc, res.err
+}
+
+// NewHTTPHandler returns an HTTP handler that makes a set of endpoints
+// available on predefined paths.
+func NewHTTPHandler(endpoints endpoint.Endpoints, sessions session.Store, logger log.Logger) http.Handler {
+
+	r := mux.NewRouter()
+	r.Use(commonHTTPMiddleware)
+
+	// options for the endpoints that need a logged in user
+	authOptions := []httptransport.ServerOption{
+		httptransport.ServerBefore(sessionToContext(sessions)),
+	}
+
+	r.Methods("GET").Path(PingURL).Handler(httptransport.NewServer(
+		endpoints.Ping,
+		decodeHTTPPingRequest,
+		encodeResponse,
+	))
+
+	r.Methods("POST").Path(SignupURL).Handler(httptransport.NewServer(
+		endpoints.Signup,
+		decodeHTTPSignupRequest,
+		encodeResponse,
+	))
+
+	r.Methods("GET").Path(LoginURL).Handler(httptransport.NewServer(
+		endpoints.Login,
+		decodeHTTPLoginRequest,
+		encodeResponse,
+	))
+
+	r.Methods("POST").Path(LogoutURL).Handler(httptransport.NewServer(
+		endpoints.Logout,
+		decodeHTTPLogoutRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(CreateListURL).Handler(httptransport.NewServer(
+		endpoints.CreateList,
+		decodeHTTPCreateListRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("GET").Path(GetListsURL).Handler(httptransport.NewServer(
+		endpoints.GetLists,
+		decodeHTTPGetListsRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("PATCH").Path(UpdateListURL).Handler(httptransport.NewServer(
+		endpoints.UpdateList,
+		decodeHTTPUpdateListRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(CreateItemURL).Handler(httptransport.NewServer(
+		endpoints.CreateItem,
+		decodeHTTPCreateItemRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("GET").Path(GetListItemsURL).Handler(httptransport.NewServer(
+		endpoints.GetListItems,
+		decodeHTTPGetListItemsRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("PATCH").Path(UpdateItemURL).Handler(httptransport.NewServer(
+		endpoints.UpdateItem,
+		decodeHTTPUpdateItemRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(BuyItemURL).Handler(httptransport.NewServer(
+		endpoints.BuyItem,
+		decodeHTTPBuyItemRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(ShareListURL).Handler(httptransport.NewServer(
+		endpoints.ShareList,
 		decodeHTTPShareListRequest,
 		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("GET").Path(CategoriesURL).Handler(httptransport.NewServer(
+		endpoints.GetAllCategories,
+		decodeHTTPGetAllCategoriesRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(DeleteListURL).Handler(httptransport.NewServer(
+		endpoints.DeleteList,
+		decodeHTTPDeleteListRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(DeleteItemURL).Handler(httptransport.NewServer(
+		endpoints.DeleteItem,
+		decodeHTTPDeleteItemRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(UnbuyItemURL).Handler(httptransport.NewServer(
+		endpoints.UnbuyItem,
+		decodeHTTPUnbuyItemRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(RestoreItemURL).Handler(httptransport.NewServer(
+		endpoints.RestoreItem,
+		decodeHTTPRestoreItemRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("GET").Path(TrashURL).Handler(httptransport.NewServer(
+		endpoints.GetTrash,
+		decodeHTTPGetTrashRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(RestoreListURL).Handler(httptransport.NewServer(
+		endpoints.RestoreList,
+		decodeHTTPRestoreListRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("GET").Path(ContributorsURL).Handler(httptransport.NewServer(
+		endpoints.GetContributors,
+		decodeHTTPGetContributorsRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("PATCH").Path(UpdateContributorURL).Handler(httptransport.NewServer(
+		endpoints.UpdateContributor,
+		decodeHTTPUpdateContributorRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("DELETE").Path(RevokeContributorURL).Handler(httptransport.NewServer(
+		endpoints.RevokeContributor,
+		decodeHTTPRevokeContributorRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(CreateInviteURL).Handler(httptransport.NewServer(
+		endpoints.CreateInvite,
+		decodeHTTPCreateInviteRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("GET").Path(GetInvitesURL).Handler(httptransport.NewServer(
+		endpoints.GetInvites,
+		decodeHTTPGetInvitesRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("DELETE").Path(CancelInviteURL).Handler(httptransport.NewServer(
+		endpoints.CancelInvite,
+		decodeHTTPCancelInviteRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(AcceptInviteURL).Handler(httptransport.NewServer(
+		endpoints.AcceptInvite,
+		decodeHTTPAcceptInviteRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(TransferOwnershipURL).Handler(httptransport.NewServer(
+		endpoints.TransferOwnership,
+		decodeHTTPTransferOwnershipRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(CreateGroupURL).Handler(httptransport.NewServer(
+		endpoints.CreateGroup,
+		decodeHTTPCreateGroupRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("GET").Path(GetGroupsURL).Handler(httptransport.NewServer(
+		endpoints.GetGroups,
+		decodeHTTPGetGroupsRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("GET").Path(GetGroupMembersURL).Handler(httptransport.NewServer(
+		endpoints.GetGroupMembers,
+		decodeHTTPGetGroupMembersRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(AddGroupMemberURL).Handler(httptransport.NewServer(
+		endpoints.AddGroupMember,
+		decodeHTTPAddGroupMemberRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("DELETE").Path(RemoveGroupMemberURL).Handler(httptransport.NewServer(
+		endpoints.RemoveGroupMember,
+		decodeHTTPRemoveGroupMemberRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(ShareListWithGroupURL).Handler(httptransport.NewServer(
+		endpoints.ShareListWithGroup,
+		decodeHTTPShareListWithGroupRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("DELETE").Path(UnshareListWithGroupURL).Handler(httptransport.NewServer(
+		endpoints.UnshareListWithGroup,
+		decodeHTTPUnshareListWithGroupRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(CreatePublicLinkURL).Handler(httptransport.NewServer(
+		endpoints.CreatePublicLink,
+		decodeHTTPCreatePublicLinkRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("GET").Path(GetPublicLinksURL).Handler(httptransport.NewServer(
+		endpoints.GetPublicLinks,
+		decodeHTTPGetPublicLinksRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("DELETE").Path(RevokePublicLinkURL).Handler(httptransport.NewServer(
+		endpoints.RevokePublicLink,
+		decodeHTTPRevokePublicLinkRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	// public links work without a logged in user
+	r.Methods("GET").Path(GetPublicListURL).Handler(httptransport.NewServer(
+		endpoints.GetPublicList,
+		decodeHTTPGetPublicListRequest,
+		encodeResponse,
+	))
+
+	r.Methods("POST").Path(BuyPublicItemURL).Handler(httptransport.NewServer(
+		endpoints.BuyPublicItem,
+		decodeHTTPBuyPublicItemRequest,
+		encodeResponse,
+	))
+
+	r.Methods("POST").Path(CreateCategoryURL).Handler(httptransport.NewServer(
+		endpoints.CreateCategory,
+		decodeHTTPCreateCategoryRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("PATCH").Path(UpdateCategoryURL).Handler(httptransport.NewServer(
+		endpoints.UpdateCategory,
+		decodeHTTPUpdateCategoryRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("DELETE").Path(DeleteCategoryURL).Handler(httptransport.NewServer(
+		endpoints.DeleteCategory,
+		decodeHTTPDeleteCategoryRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(MergeCategoriesURL).Handler(httptransport.NewServer(
+		endpoints.MergeCategories,
+		decodeHTTPMergeCategoriesRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(CreateCategoryRuleURL).Handler(httptransport.NewServer(
+		endpoints.CreateCategoryRule,
+		decodeHTTPCreateCategoryRuleRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("GET").Path(GetCategoryRulesURL).Handler(httptransport.NewServer(
+		endpoints.GetCategoryRules,
+		decodeHTTPGetCategoryRulesRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("DELETE").Path(DeleteCategoryRuleURL).Handler(httptransport.NewServer(
+		endpoints.DeleteCategoryRule,
+		decodeHTTPDeleteCategoryRuleRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(CreateStoreURL).Handler(httptransport.NewServer(
+		endpoints.CreateStore,
+		decodeHTTPCreateStoreRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("GET").Path(GetStoresURL).Handler(httptransport.NewServer(
+		endpoints.GetStores,
+		decodeHTTPGetStoresRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("PATCH").Path(UpdateStoreURL).Handler(httptransport.NewServer(
+		endpoints.UpdateStore,
+		decodeHTTPUpdateStoreRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("DELETE").Path(DeleteStoreURL).Handler(httptransport.NewServer(
+		endpoints.DeleteStore,
+		decodeHTTPDeleteStoreRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(ReorderItemsURL).Handler(httptransport.NewServer(
+		endpoints.ReorderItems,
+		decodeHTTPReorderItemsRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(MoveItemsURL).Handler(httptransport.NewServer(
+		endpoints.MoveItems,
+		decodeHTTPMoveItemsRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(CopyItemsURL).Handler(httptransport.NewServer(
+		endpoints.CopyItems,
+		decodeHTTPCopyItemsRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(CloneListURL).Handler(httptransport.NewServer(
+		endpoints.CloneList,
+		decodeHTTPCloneListRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(CreateTemplateURL).Handler(httptransport.NewServer(
+		endpoints.CreateTemplate,
+		decodeHTTPCreateTemplateRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("GET").Path(GetTemplatesURL).Handler(httptransport.NewServer(
+		endpoints.GetTemplates,
+		decodeHTTPGetTemplatesRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(InstantiateTemplateURL).Handler(httptransport.NewServer(
+		endpoints.InstantiateTemplate,
+		decodeHTTPInstantiateTemplateRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(CreateRecurrenceURL).Handler(httptransport.NewServer(
+		endpoints.CreateRecurrence,
+		decodeHTTPCreateRecurrenceRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("GET").Path(GetRecurrencesURL).Handler(httptransport.NewServer(
+		endpoints.GetRecurrences,
+		decodeHTTPGetRecurrencesRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(PauseRecurrenceURL).Handler(httptransport.NewServer(
+		endpoints.PauseRecurrence,
+		decodeHTTPPauseRecurrenceRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("POST").Path(ResumeRecurrenceURL).Handler(httptransport.NewServer(
+		endpoints.ResumeRecurrence,
+		decodeHTTPResumeRecurrenceRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("DELETE").Path(DeleteRecurrenceURL).Handler(httptransport.NewServer(
+		endpoints.DeleteRecurrence,
+		decodeHTTPDeleteRecurrenceRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("GET").Path(GetRecurrenceInstancesURL).Handler(httptransport.NewServer(
+		endpoints.GetRecurrenceInstances,
+		decodeHTTPGetRecurrenceInstancesRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("GET").Path(GetDueItemsURL).Handler(httptransport.NewServer(
+		endpoints.GetDueItems,
+		decodeHTTPGetDueItemsRequest,
+		encodeResponse,
+		authOptions...,
 	))
 
-	r.Methods("GET").Path(CategoriesURL).Handler(httptransport.NewServer(
-		endpoints.GetAllCategories,
-		decodeHTTPGetAllCategoriesRequest,
-		encodeResponse,
-	))
+	r.Methods("GET").Path(GetReminderPreferencesURL).Handler(httptransport.NewServer(
+		endpoints.GetReminderPreferences,
+		decodeHTTPGetReminderPreferencesRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	r.Methods("PUT").Path(UpdateReminderPreferencesURL).Handler(httptransport.NewServer(
+		endpoints.UpdateReminderPreferences,
+		decodeHTTPUpdateReminderPreferencesRequest,
+		encodeResponse,
+		authOptions...,
+	))
+
+	return r
+}
+
+func decodeHTTPPingRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req api.PingRequest
+	//err := json.NewDecoder(r.Body).Decode(&req)
+	return req, nil
+}
+
+// decodeHTTPSignupRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded signup request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPSignupRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.SignupRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// decodeHTTPLoginRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded login request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPLoginRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.LoginRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// decodeHTTPLogoutRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded login request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPLogoutRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.LogoutRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	req.AllSessions = r.URL.Query().Get("all") == "true"
+	return req, nil
+}
+
+// decodeHTTPCreateListRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded create list request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPCreateListRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.CreateListRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.List.Owner.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPGetListsRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded get lists request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPGetListsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetListsRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPUpdateListRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded update list request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPUpdateListRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.UpdateListRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
+	return req, nil
+}
+
+// decodeHTTPCreateItemRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded create item request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPCreateItemRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.CreateItemRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.Item.CreatedBy.UserID = uc.UserID
+	req.Item.LastModifiedBy.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPGetListItemsRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded get list items request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPGetListItemsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetListItemsRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPUpdateItemRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded update item request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPUpdateItemRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.UpdateItemRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	iid, err := strconv.ParseInt(params["iid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid item id in url")
+	}
+	req.ItemID = iid
+	return req, nil
+}
+
+// decodeHTTPBuyItemRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded buy item request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPBuyItemRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.BuyItemRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPShareListRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded share list request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPShareListRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.ShareListRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPGetAllCategoriesRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded share list request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPGetAllCategoriesRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetAllCategoriesRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPDeleteListRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded share list request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPDeleteListRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.DeleteListRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
+	return req, nil
+}
+
+// decodeHTTPDeleteItemRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded share list request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPDeleteItemRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.DeleteItemRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	iid, err := strconv.ParseInt(params["iid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid item id in url")
+	}
+	req.ItemID = iid
+	return req, nil
+}
+
+// decodeHTTPUnbuyItemRequest is a transport/http.DecodeRequestFunc that decodes a
+// unbuy item request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPUnbuyItemRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.UnbuyItemRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	iid, err := strconv.ParseInt(params["iid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid item id in url")
+	}
+	req.ItemID = iid
+	return req, nil
+}
+
+// decodeHTTPRestoreItemRequest is a transport/http.DecodeRequestFunc that decodes a
+// restore item request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPRestoreItemRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.RestoreItemRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	iid, err := strconv.ParseInt(params["iid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid item id in url")
+	}
+	req.ItemID = iid
+	return req, nil
+}
+
+// decodeHTTPGetTrashRequest is a transport/http.DecodeRequestFunc that decodes a
+// get trash request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPGetTrashRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetTrashRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPRestoreListRequest is a transport/http.DecodeRequestFunc that decodes a
+// restore list request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPRestoreListRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.RestoreListRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
+	return req, nil
+}
+
+// decodeHTTPGetContributorsRequest is a transport/http.DecodeRequestFunc that decodes a
+// get contributors request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPGetContributorsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetContributorsRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
+	return req, nil
+}
+
+// decodeHTTPUpdateContributorRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded update contributor request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPUpdateContributorRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.UpdateContributorRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
+	uid, err := strconv.ParseInt(params["uid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid user id in url")
+	}
+	req.ContributorID = uid
+	return req, nil
+}
+
+// decodeHTTPRevokeContributorRequest is a transport/http.DecodeRequestFunc that decodes a
+// revoke contributor request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPRevokeContributorRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.RevokeContributorRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
+	uid, err := strconv.ParseInt(params["uid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid user id in url")
+	}
+	req.ContributorID = uid
+	return req, nil
+}
+
+// decodeHTTPCreateInviteRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded create invite request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPCreateInviteRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.CreateInviteRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
+	return req, nil
+}
+
+// decodeHTTPGetInvitesRequest is a transport/http.DecodeRequestFunc that decodes a
+// get invites request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPGetInvitesRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetInvitesRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
+	return req, nil
+}
+
+// decodeHTTPCancelInviteRequest is a transport/http.DecodeRequestFunc that decodes a
+// cancel invite request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPCancelInviteRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.CancelInviteRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
+	inv, err := strconv.ParseInt(params["inv"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid invitation id in url")
+	}
+	req.InviteID = inv
+	return req, nil
+}
+
+// decodeHTTPAcceptInviteRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded accept invite request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPAcceptInviteRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.AcceptInviteRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPTransferOwnershipRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded transfer ownership request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPTransferOwnershipRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.TransferOwnershipRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
+	return req, nil
+}
+
+// decodeHTTPCreateGroupRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded create group request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPCreateGroupRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.CreateGroupRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPGetGroupsRequest is a transport/http.DecodeRequestFunc that decodes a
+// get groups request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPGetGroupsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetGroupsRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPGetGroupMembersRequest is a transport/http.DecodeRequestFunc that decodes a
+// get group members request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPGetGroupMembersRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetGroupMembersRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	gid, err := strconv.ParseInt(params["gid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid group id in url")
+	}
+	req.GroupID = gid
+	return req, nil
+}
+
+// decodeHTTPAddGroupMemberRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded add group member request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPAddGroupMemberRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.AddGroupMemberRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	gid, err := strconv.ParseInt(params["gid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid group id in url")
+	}
+	req.GroupID = gid
+	return req, nil
+}
+
+// decodeHTTPRemoveGroupMemberRequest is a transport/http.DecodeRequestFunc that decodes a
+// remove group member request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPRemoveGroupMemberRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.RemoveGroupMemberRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	gid, err := strconv.ParseInt(params["gid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid group id in url")
+	}
+	req.GroupID = gid
+	uid, err := strconv.ParseInt(params["uid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid user id in url")
+	}
+	req.MemberID = uid
+	return req, nil
+}
 
-	r.Methods("POST").Path(DeleteListURL).Handler(httptransport.NewServer(
-		endpoints.DeleteList,
-		decodeHTTPDeleteListRequest,
-		encodeResponse,
-	))
+// decodeHTTPShareListWithGroupRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded share list with group request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPShareListWithGroupRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.ShareListWithGroupRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
 
-	r.Methods("POST").Path(DeleteItemURL).Handler(httptransport.NewServer(
-		endpoints.DeleteItem,
-		decodeHTTPDeleteItemRequest,
-		encodeResponse,
-	))
+// decodeHTTPUnshareListWithGroupRequest is a transport/http.DecodeRequestFunc that decodes a
+// unshare list with group request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPUnshareListWithGroupRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.UnshareListWithGroupRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
+	gid, err := strconv.ParseInt(params["gid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid group id in url")
+	}
+	req.GroupID = gid
+	return req, nil
+}
 
-	return r
+// decodeHTTPCreatePublicLinkRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded create public link request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPCreatePublicLinkRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.CreatePublicLinkRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
+	return req, nil
+}
+
+// decodeHTTPGetPublicLinksRequest is a transport/http.DecodeRequestFunc that decodes a
+// get public links request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPGetPublicLinksRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetPublicLinksRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
+	return req, nil
+}
+
+// decodeHTTPRevokePublicLinkRequest is a transport/http.DecodeRequestFunc that decodes a
+// revoke public link request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPRevokePublicLinkRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.RevokePublicLinkRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
+	link, err := strconv.ParseInt(params["link"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid public link id in url")
+	}
+	req.LinkID = link
+	return req, nil
+}
+
+// decodeHTTPGetPublicListRequest is a transport/http.DecodeRequestFunc that decodes a
+// get public list request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPGetPublicListRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetPublicListRequest
+	req.Token = mux.Vars(r)["token"]
+	return req, nil
+}
+
+// decodeHTTPBuyPublicItemRequest is a transport/http.DecodeRequestFunc that decodes a
+// buy public item request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPBuyPublicItemRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.BuyPublicItemRequest
+	params := mux.Vars(r)
+	req.Token = params["token"]
+	iid, err := strconv.ParseInt(params["iid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid item id in url")
+	}
+	req.ItemID = iid
+	return req, nil
+}
+
+// decodeHTTPCreateCategoryRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded create category request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPCreateCategoryRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.CreateCategoryRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPUpdateCategoryRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded update category request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPUpdateCategoryRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.UpdateCategoryRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	cid, err := strconv.ParseInt(params["cid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid category id in url")
+	}
+	req.CategoryID = cid
+	return req, nil
+}
+
+// decodeHTTPDeleteCategoryRequest is a transport/http.DecodeRequestFunc that decodes a
+// delete category request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPDeleteCategoryRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.DeleteCategoryRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	cid, err := strconv.ParseInt(params["cid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid category id in url")
+	}
+	req.CategoryID = cid
+	return req, nil
+}
+
+// decodeHTTPMergeCategoriesRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded merge categories request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPMergeCategoriesRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.MergeCategoriesRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	cid, err := strconv.ParseInt(params["cid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid category id in url")
+	}
+	req.CategoryID = cid
+	return req, nil
+}
+
+// decodeHTTPCreateCategoryRuleRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded create category rule request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPCreateCategoryRuleRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.CreateCategoryRuleRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPGetCategoryRulesRequest is a transport/http.DecodeRequestFunc that decodes a
+// get category rules request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPGetCategoryRulesRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetCategoryRulesRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPDeleteCategoryRuleRequest is a transport/http.DecodeRequestFunc that decodes a
+// delete category rule request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPDeleteCategoryRuleRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.DeleteCategoryRuleRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	rule, err := strconv.ParseInt(params["rule"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid rule id in url")
+	}
+	req.RuleID = rule
+	return req, nil
+}
+
+// decodeHTTPCreateStoreRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded create store request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPCreateStoreRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.CreateStoreRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPGetStoresRequest is a transport/http.DecodeRequestFunc that decodes a
+// get stores request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPGetStoresRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetStoresRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPUpdateStoreRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded update store request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPUpdateStoreRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.UpdateStoreRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	sid, err := strconv.ParseInt(params["sid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid store id in url")
+	}
+	req.StoreID = sid
+	return req, nil
+}
+
+// decodeHTTPDeleteStoreRequest is a transport/http.DecodeRequestFunc that decodes a
+// delete store request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPDeleteStoreRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.DeleteStoreRequest
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	sid, err := strconv.ParseInt(params["sid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid store id in url")
+	}
+	req.StoreID = sid
+	return req, nil
 }
 
-func decodeHTTPPingRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	var req api.PingRequest
-	//err := json.NewDecoder(r.Body).Decode(&req)
+// decodeHTTPReorderItemsRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded reorder items request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPReorderItemsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.ReorderItemsRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
 	return req, nil
 }
 
-// decodeHTTPSignupRequest is a transport/http.DecodeRequestFunc that decodes a
-// JSON-encoded signup request from the HTTP request body. Primarily useful in a
+// decodeHTTPMoveItemsRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded move items request from the HTTP request body. Primarily useful in a
 // server.
-func decodeHTTPSignupRequest(ctx context.Context, r *http.Request) (interface{}, error) {
-	var req api.SignupRequest
+func decodeHTTPMoveItemsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.MoveItemsRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		return nil, err
 	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
 	return req, nil
 }
 
-// decodeHTTPLoginRequest is a transport/http.DecodeRequestFunc that decodes a
-// JSON-encoded login request from the HTTP request body. Primarily useful in a
+// decodeHTTPCopyItemsRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded copy items request from the HTTP request body. Primarily useful in a
 // server.
-func decodeHTTPLoginRequest(ctx context.Context, r *http.Request) (interface{}, error) {
-	var req api.LoginRequest
+func decodeHTTPCopyItemsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.CopyItemsRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		return nil, err
 	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
 	return req, nil
 }
 
-// decodeHTTPLogoutRequest is a transport/http.DecodeRequestFunc that decodes a
-// JSON-encoded login request from the HTTP request body. Primarily useful in a
+// decodeHTTPCloneListRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded clone list request from the HTTP request body. Primarily useful in a
 // server.
-func decodeHTTPLogoutRequest(ctx context.Context, r *http.Request) (interface{}, error) {
-	var req api.LogoutRequest
-	uc, err := api.GetUserContextFromSession(r)
+func decodeHTTPCloneListRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.CloneListRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
 	}
 	req.UserID = uc.UserID
 	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
 	return req, nil
 }
 
-// decodeHTTPCreateListRequest is a transport/http.DecodeRequestFunc that decodes a
-// JSON-encoded create list request from the HTTP request body. Primarily useful in a
+// decodeHTTPCreateTemplateRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded create template request from the HTTP request body. Primarily useful in a
 // server.
-func decodeHTTPCreateListRequest(ctx context.Context, r *http.Request) (interface{}, error) {
-	var req api.CreateListRequest
+func decodeHTTPCreateTemplateRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.CreateTemplateRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		return nil, err
 	}
-	uc, err := api.GetUserContextFromSession(r)
+	uc, err := userContextFromContext(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
 	}
-	req.List.Owner.UserID = uc.UserID
+	req.UserID = uc.UserID
 	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.ListID = lid
 	return req, nil
 }
 
-// decodeHTTPGetListsRequest is a transport/http.DecodeRequestFunc that decodes a
-// JSON-encoded get lists request from the HTTP request body. Primarily useful in a
+// decodeHTTPGetTemplatesRequest is a transport/http.DecodeRequestFunc that decodes a
+// get templates request from the HTTP request URL. Primarily useful in a
 // server.
-func decodeHTTPGetListsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
-	var req api.GetListsRequest
-	uc, err := api.GetUserContextFromSession(r)
+func decodeHTTPGetTemplatesRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetTemplatesRequest
+	uc, err := userContextFromContext(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
 	}
@@ -478,35 +3134,40 @@ func decodeHTTPGetListsRequest(ctx context.Context, r *http.Request) (interface{
 	return req, nil
 }
 
-// decodeHTTPCreateItemRequest is a transport/http.DecodeRequestFunc that decodes a
-// JSON-encoded create item request from the HTTP request body. Primarily useful in a
+// decodeHTTPInstantiateTemplateRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded instantiate template request from the HTTP request body. Primarily useful in a
 // server.
-func decodeHTTPCreateItemRequest(ctx context.Context, r *http.Request) (interface{}, error) {
-	var req api.CreateItemRequest
+func decodeHTTPInstantiateTemplateRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.InstantiateTemplateRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		return nil, err
 	}
-	uc, err := api.GetUserContextFromSession(r)
+	uc, err := userContextFromContext(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
 	}
-	req.Item.CreatedBy.UserID = uc.UserID
-	req.Item.LastModifiedBy.UserID = uc.UserID
+	req.UserID = uc.UserID
 	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid list id in url")
+	}
+	req.TemplateID = lid
 	return req, nil
 }
 
-// decodeHTTPGetListItemsRequest is a transport/http.DecodeRequestFunc that decodes a
-// JSON-encoded get list items request from the HTTP request body. Primarily useful in a
+// decodeHTTPCreateRecurrenceRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded create recurrence request from the HTTP request body. Primarily useful in a
 // server.
-func decodeHTTPGetListItemsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
-	var req api.GetListItemsRequest
+func decodeHTTPCreateRecurrenceRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.CreateRecurrenceRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		return nil, err
 	}
-	uc, err := api.GetUserContextFromSession(r)
+	uc, err := userContextFromContext(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
 	}
@@ -515,16 +3176,12 @@ func decodeHTTPGetListItemsRequest(ctx context.Context, r *http.Request) (interf
 	return req, nil
 }
 
-// decodeHTTPBuyItemRequest is a transport/http.DecodeRequestFunc that decodes a
-// JSON-encoded buy item request from the HTTP request body. Primarily useful in a
+// decodeHTTPGetRecurrencesRequest is a transport/http.DecodeRequestFunc that decodes a
+// get recurrences request from the HTTP request URL. Primarily useful in a
 // server.
-func decodeHTTPBuyItemRequest(ctx context.Context, r *http.Request) (interface{}, error) {
-	var req api.BuyItemRequest
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		return nil, err
-	}
-	uc, err := api.GetUserContextFromSession(r)
+func decodeHTTPGetRecurrencesRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetRecurrencesRequest
+	uc, err := userContextFromContext(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
 	}
@@ -533,80 +3190,138 @@ func decodeHTTPBuyItemRequest(ctx context.Context, r *http.Request) (interface{}
 	return req, nil
 }
 
-// decodeHTTPShareListRequest is a transport/http.DecodeRequestFunc that decodes a
-// JSON-encoded share list request from the HTTP request body. Primarily useful in a
+// decodeHTTPPauseRecurrenceRequest is a transport/http.DecodeRequestFunc that decodes a
+// pause recurrence request from the HTTP request URL. Primarily useful in a
 // server.
-func decodeHTTPShareListRequest(ctx context.Context, r *http.Request) (interface{}, error) {
-	var req api.ShareListRequest
-	err := json.NewDecoder(r.Body).Decode(&req)
+func decodeHTTPPauseRecurrenceRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.PauseRecurrenceRequest
+	uc, err := userContextFromContext(ctx)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	rid, err := strconv.ParseInt(params["rid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid recurrence id in url")
 	}
-	uc, err := api.GetUserContextFromSession(r)
+	req.RecurrenceID = rid
+	return req, nil
+}
+
+// decodeHTTPResumeRecurrenceRequest is a transport/http.DecodeRequestFunc that decodes a
+// resume recurrence request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPResumeRecurrenceRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.ResumeRecurrenceRequest
+	uc, err := userContextFromContext(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
 	}
 	req.UserID = uc.UserID
 	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	rid, err := strconv.ParseInt(params["rid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid recurrence id in url")
+	}
+	req.RecurrenceID = rid
 	return req, nil
 }
 
-// decodeHTTPGetAllCategoriesRequest is a transport/http.DecodeRequestFunc that decodes a
-// JSON-encoded share list request from the HTTP request body. Primarily useful in a
+// decodeHTTPDeleteRecurrenceRequest is a transport/http.DecodeRequestFunc that decodes a
+// delete recurrence request from the HTTP request URL. Primarily useful in a
 // server.
-func decodeHTTPGetAllCategoriesRequest(ctx context.Context, r *http.Request) (interface{}, error) {
-	var req api.GetAllCategoriesRequest
-	uc, err := api.GetUserContextFromSession(r)
+func decodeHTTPDeleteRecurrenceRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.DeleteRecurrenceRequest
+	uc, err := userContextFromContext(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
 	}
 	req.UserID = uc.UserID
 	req.SessionToken = uc.SessionToken
+	params := mux.Vars(r)
+	rid, err := strconv.ParseInt(params["rid"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid recurrence id in url")
+	}
+	req.RecurrenceID = rid
 	return req, nil
 }
 
-// decodeHTTPDeleteListRequest is a transport/http.DecodeRequestFunc that decodes a
-// JSON-encoded share list request from the HTTP request body. Primarily useful in a
+// decodeHTTPGetRecurrenceInstancesRequest is a transport/http.DecodeRequestFunc that decodes a
+// get recurrence instances request from the HTTP request URL. Primarily useful in a
 // server.
-func decodeHTTPDeleteListRequest(ctx context.Context, r *http.Request) (interface{}, error) {
-	var req api.DeleteListRequest
-	uc, err := api.GetUserContextFromSession(r)
+func decodeHTTPGetRecurrenceInstancesRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetRecurrenceInstancesRequest
+	uc, err := userContextFromContext(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
 	}
 	req.UserID = uc.UserID
 	req.SessionToken = uc.SessionToken
 	params := mux.Vars(r)
-	lid, err := strconv.ParseInt(params["lid"], 10, 64)
+	rid, err := strconv.ParseInt(params["rid"], 10, 64)
 	if err != nil {
-		return nil, errors.Wrapf(err, "invalid list id in url")
+		return nil, errors.Wrapf(err, "invalid recurrence id in url")
 	}
-	req.ListID = lid
+	req.RecurrenceID = rid
 	return req, nil
 }
 
-// decodeHTTPDeleteItemRequest is a transport/http.DecodeRequestFunc that decodes a
-// JSON-encoded share list request from the HTTP request body. Primarily useful in a
+// decodeHTTPGetDueItemsRequest is a transport/http.DecodeRequestFunc that decodes a
+// get due items request from the HTTP request URL. Primarily useful in a
 // server.
-func decodeHTTPDeleteItemRequest(ctx context.Context, r *http.Request) (interface{}, error) {
-	var req api.DeleteItemRequest
-	uc, err := api.GetUserContextFromSession(r)
+func decodeHTTPGetDueItemsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetDueItemsRequest
+	uc, err := userContextFromContext(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
 	}
 	req.UserID = uc.UserID
 	req.SessionToken = uc.SessionToken
-	params := mux.Vars(r)
-	iid, err := strconv.ParseInt(params["iid"], 10, 64)
+	req.Within = r.URL.Query().Get("within")
+	return req, nil
+}
+
+// decodeHTTPGetReminderPreferencesRequest is a transport/http.DecodeRequestFunc that decodes a
+// get reminder preferences request from the HTTP request URL. Primarily useful in a
+// server.
+func decodeHTTPGetReminderPreferencesRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.GetReminderPreferencesRequest
+	uc, err := userContextFromContext(ctx)
 	if err != nil {
-		return nil, errors.Wrapf(err, "invalid item id in url")
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
 	}
-	req.ItemID = iid
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
+	return req, nil
+}
+
+// decodeHTTPUpdateReminderPreferencesRequest is a transport/http.DecodeRequestFunc that decodes a
+// JSON-encoded update reminder preferences request from the HTTP request body. Primarily useful in a
+// server.
+func decodeHTTPUpdateReminderPreferencesRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req api.UpdateReminderPreferencesRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	uc, err := userContextFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unauthorised access,could not read userid from cache")
+	}
+	req.UserID = uc.UserID
+	req.SessionToken = uc.SessionToken
 	return req, nil
 }
 
 func getErrorInfo(err error) (int, string, string) {
 	httpStatus := http.StatusInternalServerError
+	if strings.Contains(err.Error(), "request validation failed") {
+		httpStatus = http.StatusBadRequest
+	}
 	if strings.Contains(err.Error(), "unauthorised access") {
 		httpStatus = http.StatusUnauthorized
 	}
@@ -647,88 +3362,608 @@ func encodeResponse(ctx context.Context, w http.ResponseWriter, response interfa
 		http.SetCookie(w, &http.Cookie{
 			Name:    "session_token",
 			Value:   resp.SessionToken,
-			Expires: time.Now().Add(120 * time.Second),
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.CreateListResponse:
+		resp := response.(api.CreateListResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.GetListsResponse:
+		resp := response.(api.GetListsResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.UpdateListResponse:
+		resp := response.(api.UpdateListResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.CreateItemResponse:
+		resp := response.(api.CreateItemResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.GetListItemsResponse:
+		resp := response.(api.GetListItemsResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.UpdateItemResponse:
+		resp := response.(api.UpdateItemResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.BuyItemResponse:
+		resp := response.(api.BuyItemResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.ShareListResponse:
+		resp := response.(api.ShareListResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.GetAllCategoriesResponse:
+		resp := response.(api.GetAllCategoriesResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.DeleteListResponse:
+		resp := response.(api.DeleteListResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.DeleteItemResponse:
+		resp := response.(api.DeleteItemResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.UnbuyItemResponse:
+		resp := response.(api.UnbuyItemResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
 		})
 		resp.SessionToken = ""
 		return json.NewEncoder(w).Encode(resp)
-	case api.CreateListResponse:
-		resp := response.(api.CreateListResponse)
+	case api.RestoreItemResponse:
+		resp := response.(api.RestoreItemResponse)
 		http.SetCookie(w, &http.Cookie{
 			Name:    "session_token",
 			Value:   resp.SessionToken,
-			Expires: time.Now().Add(120 * time.Second),
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
 		})
 		resp.SessionToken = ""
 		return json.NewEncoder(w).Encode(resp)
-	case api.GetListsResponse:
-		resp := response.(api.GetListsResponse)
+	case api.GetTrashResponse:
+		resp := response.(api.GetTrashResponse)
 		http.SetCookie(w, &http.Cookie{
 			Name:    "session_token",
 			Value:   resp.SessionToken,
-			Expires: time.Now().Add(120 * time.Second),
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
 		})
 		resp.SessionToken = ""
 		return json.NewEncoder(w).Encode(resp)
-	case api.CreateItemResponse:
-		resp := response.(api.CreateItemResponse)
+	case api.RestoreListResponse:
+		resp := response.(api.RestoreListResponse)
 		http.SetCookie(w, &http.Cookie{
 			Name:    "session_token",
 			Value:   resp.SessionToken,
-			Expires: time.Now().Add(120 * time.Second),
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
 		})
 		resp.SessionToken = ""
 		return json.NewEncoder(w).Encode(resp)
-	case api.GetListItemsResponse:
-		resp := response.(api.GetListItemsResponse)
+	case api.GetContributorsResponse:
+		resp := response.(api.GetContributorsResponse)
 		http.SetCookie(w, &http.Cookie{
 			Name:    "session_token",
 			Value:   resp.SessionToken,
-			Expires: time.Now().Add(120 * time.Second),
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
 		})
 		resp.SessionToken = ""
 		return json.NewEncoder(w).Encode(resp)
-	case api.BuyItemResponse:
-		resp := response.(api.BuyItemResponse)
+	case api.UpdateContributorResponse:
+		resp := response.(api.UpdateContributorResponse)
 		http.SetCookie(w, &http.Cookie{
 			Name:    "session_token",
 			Value:   resp.SessionToken,
-			Expires: time.Now().Add(120 * time.Second),
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
 		})
 		resp.SessionToken = ""
 		return json.NewEncoder(w).Encode(resp)
-	case api.ShareListResponse:
-		resp := response.(api.ShareListResponse)
+	case api.RevokeContributorResponse:
+		resp := response.(api.RevokeContributorResponse)
 		http.SetCookie(w, &http.Cookie{
 			Name:    "session_token",
 			Value:   resp.SessionToken,
-			Expires: time.Now().Add(120 * time.Second),
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
 		})
 		resp.SessionToken = ""
 		return json.NewEncoder(w).Encode(resp)
-	case api.GetAllCategoriesResponse:
-		resp := response.(api.GetAllCategoriesResponse)
+	case api.CreateInviteResponse:
+		resp := response.(api.CreateInviteResponse)
 		http.SetCookie(w, &http.Cookie{
 			Name:    "session_token",
 			Value:   resp.SessionToken,
-			Expires: time.Now().Add(120 * time.Second),
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
 		})
 		resp.SessionToken = ""
 		return json.NewEncoder(w).Encode(resp)
-	case api.DeleteListResponse:
-		resp := response.(api.DeleteListResponse)
+	case api.GetInvitesResponse:
+		resp := response.(api.GetInvitesResponse)
 		http.SetCookie(w, &http.Cookie{
 			Name:    "session_token",
 			Value:   resp.SessionToken,
-			Expires: time.Now().Add(120 * time.Second),
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
 		})
 		resp.SessionToken = ""
 		return json.NewEncoder(w).Encode(resp)
-	case api.DeleteItemResponse:
-		resp := response.(api.DeleteItemResponse)
+	case api.CancelInviteResponse:
+		resp := response.(api.CancelInviteResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.AcceptInviteResponse:
+		resp := response.(api.AcceptInviteResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.TransferOwnershipResponse:
+		resp := response.(api.TransferOwnershipResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.CreateGroupResponse:
+		resp := response.(api.CreateGroupResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.GetGroupsResponse:
+		resp := response.(api.GetGroupsResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.GetGroupMembersResponse:
+		resp := response.(api.GetGroupMembersResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.AddGroupMemberResponse:
+		resp := response.(api.AddGroupMemberResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.RemoveGroupMemberResponse:
+		resp := response.(api.RemoveGroupMemberResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.ShareListWithGroupResponse:
+		resp := response.(api.ShareListWithGroupResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.UnshareListWithGroupResponse:
+		resp := response.(api.UnshareListWithGroupResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.CreatePublicLinkResponse:
+		resp := response.(api.CreatePublicLinkResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.GetPublicLinksResponse:
+		resp := response.(api.GetPublicLinksResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.RevokePublicLinkResponse:
+		resp := response.(api.RevokePublicLinkResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.CreateCategoryResponse:
+		resp := response.(api.CreateCategoryResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.UpdateCategoryResponse:
+		resp := response.(api.UpdateCategoryResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.DeleteCategoryResponse:
+		resp := response.(api.DeleteCategoryResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.MergeCategoriesResponse:
+		resp := response.(api.MergeCategoriesResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.CreateCategoryRuleResponse:
+		resp := response.(api.CreateCategoryRuleResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.GetCategoryRulesResponse:
+		resp := response.(api.GetCategoryRulesResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.DeleteCategoryRuleResponse:
+		resp := response.(api.DeleteCategoryRuleResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.CreateStoreResponse:
+		resp := response.(api.CreateStoreResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.GetStoresResponse:
+		resp := response.(api.GetStoresResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.UpdateStoreResponse:
+		resp := response.(api.UpdateStoreResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.DeleteStoreResponse:
+		resp := response.(api.DeleteStoreResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.ReorderItemsResponse:
+		resp := response.(api.ReorderItemsResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.MoveItemsResponse:
+		resp := response.(api.MoveItemsResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.CopyItemsResponse:
+		resp := response.(api.CopyItemsResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.CloneListResponse:
+		resp := response.(api.CloneListResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.CreateTemplateResponse:
+		resp := response.(api.CreateTemplateResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.GetTemplatesResponse:
+		resp := response.(api.GetTemplatesResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.InstantiateTemplateResponse:
+		resp := response.(api.InstantiateTemplateResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.CreateRecurrenceResponse:
+		resp := response.(api.CreateRecurrenceResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.GetRecurrencesResponse:
+		resp := response.(api.GetRecurrencesResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.PauseRecurrenceResponse:
+		resp := response.(api.PauseRecurrenceResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.ResumeRecurrenceResponse:
+		resp := response.(api.ResumeRecurrenceResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.DeleteRecurrenceResponse:
+		resp := response.(api.DeleteRecurrenceResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.GetRecurrenceInstancesResponse:
+		resp := response.(api.GetRecurrenceInstancesResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.GetDueItemsResponse:
+		resp := response.(api.GetDueItemsResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.GetReminderPreferencesResponse:
+		resp := response.(api.GetReminderPreferencesResponse)
+		http.SetCookie(w, &http.Cookie{
+			Name:    "session_token",
+			Value:   resp.SessionToken,
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
+		})
+		resp.SessionToken = ""
+		return json.NewEncoder(w).Encode(resp)
+	case api.UpdateReminderPreferencesResponse:
+		resp := response.(api.UpdateReminderPreferencesResponse)
 		http.SetCookie(w, &http.Cookie{
 			Name:    "session_token",
 			Value:   resp.SessionToken,
-			Expires: time.Now().Add(120 * time.Second),
+			Path:    "/",
+			Expires: time.Now().Add(session.DefaultTTL),
 		})
 		resp.SessionToken = ""
 		return json.NewEncoder(w).Encode(resp)
@@ -0,0 +1,100 @@
+package transport_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/go-kit/kit/log"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"shoppinglist/pkg/api"
+	"shoppinglist/pkg/endpoint"
+	"shoppinglist/pkg/service"
+	"shoppinglist/pkg/session"
+	"shoppinglist/pkg/store"
+	"shoppinglist/pkg/transport"
+	"testing"
+)
+
+// client calls the service over HTTP, keeping the session cookie it is given
+type client struct {
+	t      *testing.T
+	url    string
+	client *http.Client
+}
+
+func newServer(t *testing.T) (*httptest.Server, *client) {
+	logger := log.NewNopLogger()
+	sessionStore := session.NewMemory(session.DefaultTTL)
+	s := service.New(store.NewMemory(), sessionStore, logger, &service.Config{InviteSecret: "secret"}, &service.Info{})
+	server := httptest.NewServer(transport.NewHTTPHandler(endpoint.New(s, logger), sessionStore, logger))
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("failed to create cookie jar: %v", err)
+	}
+	return server, &client{t: t, url: server.URL, client: &http.Client{Jar: jar}}
+}
+
+// do sends body as JSON and decodes the response into out, it returns the status code
+func (c *client) do(method string, path string, body interface{}, out interface{}) int {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		c.t.Fatalf("failed to encode request: %v", err)
+	}
+	req, err := http.NewRequest(method, c.url+path, bytes.NewReader(payload))
+	if err != nil {
+		c.t.Fatalf("failed to build request: %v", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		c.t.Fatalf("%v %v failed: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	if out != nil && resp.StatusCode == http.StatusOK {
+		err = json.NewDecoder(resp.Body).Decode(out)
+		if err != nil {
+			c.t.Fatalf("failed to decode response of %v %v: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestSignupLoginCreateListAddItem(t *testing.T) {
+	server, c := newServer(t)
+	defer server.Close()
+
+	signup := map[string]string{"user_name": "alice", "password": "pw", "status": "active", "email": "alice@example.com"}
+	if code := c.do("POST", "/signup", signup, nil); code != http.StatusOK {
+		t.Fatalf("signup returned %v", code)
+	}
+	login := map[string]string{"user_name": "alice", "password": "pw"}
+	if code := c.do("GET", "/login", login, nil); code != http.StatusOK {
+		t.Fatalf("login returned %v", code)
+	}
+
+	if code := c.do("POST", "/list", map[string]interface{}{"list": map[string]string{"name": "weekly", "status": api.Todo}}, nil); code != http.StatusOK {
+		t.Fatalf("creating a list returned %v", code)
+	}
+	var lists struct {
+		Lists []api.List `json:"lists"`
+	}
+	if code := c.do("GET", "/list", nil, &lists); code != http.StatusOK {
+		t.Fatalf("reading lists returned %v", code)
+	}
+	if len(lists.Lists) != 1 || lists.Lists[0].Name != "weekly" {
+		t.Fatalf("expected the weekly list, got %+v", lists.Lists)
+	}
+	listID := lists.Lists[0].ID
+
+	item := map[string]interface{}{"item": map[string]interface{}{"list_id": listID, "title": "milk"}}
+	if code := c.do("POST", "/item", item, nil); code != http.StatusOK {
+		t.Fatalf("adding an item returned %v", code)
+	}
+	var items api.GetListItemsResponse
+	if code := c.do("GET", "/item", map[string]int64{"list_id": listID}, &items); code != http.StatusOK {
+		t.Fatalf("reading items returned %v", code)
+	}
+	if len(items.Items) != 1 || items.Items[0].Title != "milk" || items.Items[0].Status != api.Todo {
+		t.Fatalf("expected milk to buy, got %+v", items.Items)
+	}
+}